@@ -0,0 +1,74 @@
+package abandoned
+
+import (
+	"context"
+	"time"
+
+	"cart/internal/log"
+	"cart/internal/usecase"
+)
+
+type Job struct {
+	usecase   usecase.CartUseCase
+	interval  time.Duration
+	idleAfter time.Duration
+	retention time.Duration
+	now       func() time.Time
+	logger    log.Logger
+}
+
+// New returns a job that announces carts idle for idleAfter and, when
+// retention is positive, deletes carts idle for retention.
+func New(u usecase.CartUseCase, interval, idleAfter, retention time.Duration, logger log.Logger) *Job {
+	return &Job{
+		usecase:   u,
+		interval:  interval,
+		idleAfter: idleAfter,
+		retention: retention,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+// Run checks for abandoned carts every interval until ctx is cancelled.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("Abandoned cart job stopped")
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *Job) run(ctx context.Context) {
+	now := j.now()
+
+	notified, err := j.usecase.NotifyAbandonedCarts(ctx, now.Add(-j.idleAfter))
+	if err != nil {
+		j.logger.Error("failed to notify abandoned carts", log.Error(err))
+	}
+
+	if notified > 0 {
+		j.logger.Info("Abandoned carts notified", log.Int("count", notified))
+	}
+
+	if j.retention <= 0 {
+		return
+	}
+
+	purged, err := j.usecase.PurgeIdleCarts(ctx, now.Add(-j.retention))
+	if err != nil {
+		j.logger.Error("failed to purge idle carts", log.Error(err))
+		return
+	}
+
+	if purged > 0 {
+		j.logger.Info("Idle carts purged", log.Int64("count", purged))
+	}
+}
@@ -0,0 +1,70 @@
+package abandoned
+
+import (
+	"cart/internal/log/zap"
+	"cart/internal/usecase/mocks"
+	"context"
+	stdErr "errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestJob_Run(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		retention time.Duration
+		mockSetup func(u *mocks.MockCartUseCase)
+	}{
+		{
+			name:      "notifies and purges",
+			retention: 30 * 24 * time.Hour,
+			mockSetup: func(u *mocks.MockCartUseCase) {
+				u.EXPECT().NotifyAbandonedCarts(gomock.Any(), now.Add(-24*time.Hour)).Return(2, nil)
+				u.EXPECT().PurgeIdleCarts(gomock.Any(), now.Add(-30*24*time.Hour)).Return(int64(1), nil)
+			},
+		},
+		{
+			name: "purging is off without a retention",
+			mockSetup: func(u *mocks.MockCartUseCase) {
+				u.EXPECT().NotifyAbandonedCarts(gomock.Any(), now.Add(-24*time.Hour)).Return(0, nil)
+			},
+		},
+		{
+			name:      "a failed notification does not stop the purge",
+			retention: 30 * 24 * time.Hour,
+			mockSetup: func(u *mocks.MockCartUseCase) {
+				u.EXPECT().NotifyAbandonedCarts(gomock.Any(), gomock.Any()).Return(0, stdErr.New("kafka down"))
+				u.EXPECT().PurgeIdleCarts(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger, cleanup, err := zap.NewLogger()
+			if err != nil {
+				t.Fatalf("failed to create logger: %v", err)
+			}
+			defer cleanup()
+
+			mockUsecase := mocks.NewMockCartUseCase(ctrl)
+			tt.mockSetup(mockUsecase)
+
+			job := New(mockUsecase, time.Minute, 24*time.Hour, tt.retention, logger)
+			job.now = func() time.Time { return now }
+
+			job.run(context.Background())
+		})
+	}
+}
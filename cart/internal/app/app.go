@@ -1,8 +1,10 @@
 package app
 
 import (
+	"cart/internal/abandoned"
 	"cart/internal/config"
 	"cart/internal/db"
+	"cart/internal/idempotency"
 	"cart/internal/kafka"
 	"cart/internal/log"
 	"cart/internal/log/zap"
@@ -22,7 +24,7 @@ import (
 	_ "github.com/lib/pq"
 )
 
-const serverCount = 4
+const serverCount = 6
 
 func Run(envFile string) error {
 	cfg, err := config.Load(envFile)
@@ -55,29 +57,44 @@ func Run(envFile string) error {
 	defer database.Close()
 
 	cartRepo := repository.NewPostgresCartRepo(database)
+	orderRepo := repository.NewPostgresOrderRepo(database)
+	promoRepo := repository.NewPostgresPromotionRepo(database)
+	guestRepo := repository.NewPostgresGuestCartRepo(database)
+	idempotencyRepo := repository.NewPostgresIdempotencyRepo(database)
 
 	metricsInstance := metrics.RegisterMetrics()
 
-	stockClient, err := stockclient.NewGRPCClient(os.Getenv("STOCK_SERVICE_URL"), logger, metricsInstance)
+	stockClient, err := stockclient.NewGRPCClient(cfg.StockClientConfig(), logger, metricsInstance, metrics.RegisterStockClientMetrics())
 	if err != nil {
 		logger.Errorf("failed to create stock client: %v", err)
 		return fmt.Errorf("failed to create stock client: %w", err)
 	}
 
+	cacheMetrics := metrics.RegisterStockCacheMetrics()
+	stockCache := stockclient.NewCache(stockClient, cfg.StockCache, logger, cacheMetrics)
+
+	stockConsumerConfig, err := kafka.NewStockConsumerConfigFromEnv()
+	if err != nil {
+		logger.Errorf("failed to create stock events consumer config: %v", err)
+		return fmt.Errorf("failed to create stock events consumer config: %w", err)
+	}
+
+	stockConsumer := kafka.NewStockConsumer(stockConsumerConfig, stockCache, logger, cacheMetrics)
+
 	producerConfig, err := kafka.NewProducerConfigFromEnv()
 	if err != nil {
 		logger.Errorf("failed to create kafka producer config: %v", err)
 		return fmt.Errorf("failed to create kafka producer config: %w", err)
 	}
 
-	producer, err := kafka.NewProducer(producerConfig, logger)
+	producer, err := kafka.NewProducer(producerConfig, logger, metrics.RegisterProducerMetrics())
 	if err != nil {
 		logger.Errorf("failed to create kafka producer: %v", err)
 		return fmt.Errorf("failed to create kafka producer: %w", err)
 	}
 	defer producer.Close()
 
-	cartUseCase := usecase.NewCartUsecase(cartRepo, stockClient, producer, logger)
+	cartUseCase := usecase.NewCartUsecase(cartRepo, orderRepo, promoRepo, guestRepo, stockCache, producer, logger)
 
 	errCh := make(chan error, serverCount)
 
@@ -101,7 +118,7 @@ func Run(envFile string) error {
 		defer wg.Done()
 		logger.Info("Starting gRPC server", log.String("port", cfg.GRPCPort))
 
-		if err := server.StartGRPCServer(ctx, cfg, cartUseCase, logger, metricsInstance); err != nil {
+		if err := server.StartGRPCServer(ctx, cfg, cartUseCase, guestRepo, idempotencyRepo, logger, metricsInstance); err != nil {
 			errCh <- fmt.Errorf("gRPC server failed: %w", err)
 		}
 	}()
@@ -115,6 +132,39 @@ func Run(envFile string) error {
 		}
 	}()
 
+	go func() {
+		defer wg.Done()
+		logger.Info("Starting abandoned cart job",
+			log.String("interval", cfg.AbandonedCartCheckInterval.String()),
+			log.String("idle_after", cfg.AbandonedCartIdleAfter.String()),
+			log.String("retention", cfg.CartRetention.String()),
+		)
+
+		abandoned.New(cartUseCase, cfg.AbandonedCartCheckInterval, cfg.AbandonedCartIdleAfter, cfg.CartRetention, logger).Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		logger.Info("Starting idempotency key cleaner",
+			log.String("ttl", cfg.IdempotencyKeyTTL.String()),
+		)
+
+		idempotency.NewCleaner(idempotencyRepo, config.IdempotencyCleanupInterval, logger).Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		logger.Info("Starting stock events consumer",
+			log.String("topic", stockConsumerConfig.Topic),
+			log.String("group", stockConsumerConfig.Group),
+			log.Int("cache_size", cfg.StockCache.Size),
+			log.String("cache_ttl", cfg.StockCache.TTL.String()),
+			log.String("cache_offline_ttl", cfg.StockCache.OfflineTTL.String()),
+		)
+
+		stockConsumer.Run(ctx)
+	}()
+
 	select {
 	case <-stop:
 		logger.Info("Shutdown signal received")
@@ -126,6 +176,15 @@ func Run(envFile string) error {
 	cancel()
 	wg.Wait()
 
+	// Nothing publishes any more: deliver what is still queued before the
+	// deferred Close.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), producerConfig.Async.FlushTimeout)
+	defer cancelFlush()
+
+	if err := producer.Flush(flushCtx); err != nil {
+		logger.Error("failed to flush kafka producer", log.Error(err))
+	}
+
 	logger.Info("Server gracefully stopped")
 
 	return nil
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+
+	"cart/internal/models"
+)
+
+// Identity is the caller, taken from a verified token. A guest has no user
+// account: GuestID identifies the guest cart its cart token was issued for.
+type Identity struct {
+	UserID  int64
+	Subject string
+	GuestID int64
+}
+
+func (i Identity) IsGuest() bool {
+	return i.GuestID != 0
+}
+
+// CartID is the key of the caller's cart.
+func (i Identity) CartID() int64 {
+	if i.IsGuest() {
+		return models.GuestCartID(i.GuestID)
+	}
+
+	return i.UserID
+}
+
+type identityKey struct{}
+
+func NewContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+type asServiceKey struct{}
+
+// AsService marks calls made with ctx as made by cart itself: the client
+// interceptor presents the service token instead of the caller's.
+func AsService(ctx context.Context) context.Context {
+	return context.WithValue(ctx, asServiceKey{}, true)
+}
+
+func isService(ctx context.Context) bool {
+	asService, _ := ctx.Value(asServiceKey{}).(bool)
+	return asService
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"cart/internal/guest"
+	"cart/internal/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+
+	// CartTokenHeader carries the token of a guest cart.
+	CartTokenHeader = "x-cart-token"
+)
+
+// GuestCarts resolves the hash of a cart token to its guest id.
+type GuestCarts interface {
+	GuestIDByToken(ctx context.Context, tokenHash []byte) (int64, error)
+}
+
+// UnaryServerInterceptor puts the caller's Identity in the handler context.
+// A bearer token identifies a user; without one, a cart token identifies a
+// guest. Calls with neither are rejected, except for the public methods.
+func UnaryServerInterceptor(verifier *Verifier, guests GuestCarts, logger log.Logger, publicMethods ...string) grpc.UnaryServerInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if token, ok := bearerToken(ctx); ok {
+			identity, err := verifier.Verify(token)
+			if err != nil {
+				logger.Warn("rejected token",
+					log.String("method", info.FullMethod),
+					log.Error(err),
+				)
+
+				return nil, status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+			}
+
+			return handler(NewContext(ctx, identity), req)
+		}
+
+		if token, ok := metadataValue(ctx, CartTokenHeader); ok {
+			guestID, err := guests.GuestIDByToken(ctx, guest.Hash(token))
+			if err != nil {
+				logger.Warn("rejected cart token",
+					log.String("method", info.FullMethod),
+					log.Error(err),
+				)
+
+				return nil, status.Error(codes.Unauthenticated, ErrInvalidCartToken.Error())
+			}
+
+			return handler(NewContext(ctx, Identity{GuestID: guestID}), req)
+		}
+
+		if public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+}
+
+// UnaryClientInterceptor forwards the caller's Authorization header to
+// downstream services, so they see the same identity. Calls made for callers
+// without one, such as guests, and calls made AsService present serviceToken
+// instead when it is set.
+func UnaryClientInterceptor(serviceToken string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if isService(ctx) {
+			if serviceToken != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, authorizationHeader, "Bearer "+serviceToken)
+			}
+		} else if value, ok := metadataValue(ctx, authorizationHeader); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationHeader, value)
+		} else if serviceToken != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationHeader, "Bearer "+serviceToken)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func metadataValue(ctx context.Context, key string) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 || values[0] == "" {
+		return "", false
+	}
+
+	return values[0], true
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	value, ok := metadataValue(ctx, authorizationHeader)
+	if !ok {
+		return "", false
+	}
+
+	if len(value) <= len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(value[len(bearerPrefix):]), true
+}
@@ -0,0 +1,185 @@
+package auth_test
+
+import (
+	"bytes"
+	"cart/internal/auth"
+	"cart/internal/errors"
+	"cart/internal/guest"
+	"cart/internal/log/zap"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type guestCarts map[string]int64
+
+func (g guestCarts) GuestIDByToken(_ context.Context, tokenHash []byte) (int64, error) {
+	for token, id := range g {
+		if bytes.Equal(guest.Hash(token), tokenHash) {
+			return id, nil
+		}
+	}
+
+	return 0, errors.ErrGuestCartNotFound
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: testSecret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	const publicMethod = "/cart.CartService/CreateGuestCart"
+
+	interceptor := auth.UnaryServerInterceptor(verifier, guestCarts{"guest-token": 3}, logger, publicMethod)
+
+	tests := []struct {
+		name         string
+		method       string
+		header       string
+		cartToken    string
+		wantCode     codes.Code
+		wantCartID   int64
+		wantNoCaller bool
+	}{
+		{
+			name:       "valid bearer token",
+			header:     "Bearer " + signHMAC(t, testSecret, userClaims("9", time.Hour)),
+			wantCartID: 9,
+		},
+		{
+			name:     "missing header",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "wrong scheme",
+			header:   "Basic dXNlcjpwYXNz",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			header:   "Bearer " + signHMAC(t, "other-secret", userClaims("9", time.Hour)),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:       "guest cart token",
+			cartToken:  "guest-token",
+			wantCartID: -3,
+		},
+		{
+			name:      "unknown cart token",
+			cartToken: "stolen",
+			wantCode:  codes.Unauthenticated,
+		},
+		{
+			name:       "bearer token wins over cart token",
+			header:     "Bearer " + signHMAC(t, testSecret, userClaims("9", time.Hour)),
+			cartToken:  "guest-token",
+			wantCartID: 9,
+		},
+		{
+			name:         "public method without credentials",
+			method:       publicMethod,
+			wantNoCaller: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			md := metadata.MD{}
+			if tt.header != "" {
+				md.Set("authorization", tt.header)
+			}
+
+			if tt.cartToken != "" {
+				md.Set(auth.CartTokenHeader, tt.cartToken)
+			}
+
+			ctx = metadata.NewIncomingContext(ctx, md)
+
+			method := tt.method
+			if method == "" {
+				method = "/cart.CartService/ListCart"
+			}
+
+			var (
+				gotID     int64
+				gotCaller bool
+			)
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				var identity auth.Identity
+
+				identity, gotCaller = auth.FromContext(ctx)
+				gotID = identity.CartID()
+
+				return nil, nil
+			})
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, !tt.wantNoCaller, gotCaller)
+			assert.Equal(t, tt.wantCartID, gotID)
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	t.Parallel()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc"))
+
+	var forwarded []string
+
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		forwarded = md.Get("authorization")
+
+		return nil
+	}
+
+	err := auth.UnaryClientInterceptor("service")(ctx, "/stock.StockService/GetItems", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, []string{"Bearer abc"}, forwarded)
+
+	err = auth.UnaryClientInterceptor("service")(context.Background(), "/stock.StockService/GetItems", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, []string{"Bearer service"}, forwarded, "callers without a token use the service token")
+
+	err = auth.UnaryClientInterceptor("service")(auth.AsService(ctx), "/stock.StockService/ReduceStock", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, []string{"Bearer service"}, forwarded, "calls made as the service never forward the caller's token")
+}
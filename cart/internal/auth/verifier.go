@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const leeway = 30 * time.Second
+
+var (
+	ErrNotConfigured = errors.New("either a JWKS file or an HMAC secret must be configured")
+	ErrInvalidToken  = errors.New("invalid token")
+
+	ErrInvalidCartToken = errors.New("invalid cart token")
+)
+
+type Config struct {
+	JWKSFile   string
+	HMACSecret string
+	Issuer     string
+	Audience   string
+}
+
+// Verifier validates JWTs signed either with an HMAC secret or with one of the
+// keys of a JWKS file, and maps their subject to an Identity.
+type Verifier struct {
+	parser  *jwt.Parser
+	keyfunc jwt.Keyfunc
+}
+
+func NewVerifier(cfg Config) (*Verifier, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	var keyfunc jwt.Keyfunc
+
+	switch {
+	case cfg.JWKSFile != "" && cfg.HMACSecret != "":
+		return nil, fmt.Errorf("JWKS file and HMAC secret are mutually exclusive")
+	case cfg.JWKSFile != "":
+		keys, err := LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+
+		keyfunc = keys.Keyfunc
+		opts = append(opts, jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}))
+	case cfg.HMACSecret != "":
+		secret := []byte(cfg.HMACSecret)
+		keyfunc = func(*jwt.Token) (interface{}, error) { return secret, nil }
+		opts = append(opts, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	default:
+		return nil, ErrNotConfigured
+	}
+
+	return &Verifier{
+		parser:  jwt.NewParser(opts...),
+		keyfunc: keyfunc,
+	}, nil
+}
+
+func (v *Verifier) Verify(tokenString string) (Identity, error) {
+	var claims jwt.RegisteredClaims
+
+	if _, err := v.parser.ParseWithClaims(tokenString, &claims, v.keyfunc); err != nil {
+		return Identity{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return Identity{}, fmt.Errorf("%w: subject %q is not a user id", ErrInvalidToken, claims.Subject)
+	}
+
+	return Identity{
+		UserID:  userID,
+		Subject: claims.Subject,
+	}, nil
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
+	"cart/internal/auth"
+	"cart/internal/stockclient"
+
 	"github.com/joho/godotenv"
 )
 
@@ -16,14 +20,36 @@ type Config struct {
 	DBPassword      string
 	DBName          string
 	StockServiceURL string
-	GRPCPort        string
-	HTTPPort        string
-	GRPCEndpoint    string
-	JaegerEndpoint  string
-	MetricsPort     string
-	ReadTimeout     time.Duration
-	WriteTimeout    time.Duration
-	IdleTimeout     time.Duration
+	// StockServiceToken is the bearer token cart presents to Stocks on behalf
+	// of guests.
+	StockServiceToken string
+	// StockClient tunes retries, the circuit breaker and hedging of the calls
+	// to Stocks; Addr and ServiceToken are taken from the fields above.
+	StockClient stockclient.Config
+	// StockCache bounds the cache of stock items kept fresh by stock events.
+	StockCache     stockclient.CacheConfig
+	GRPCPort       string
+	HTTPPort       string
+	GRPCEndpoint   string
+	JaegerEndpoint string
+	MetricsPort    string
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+	IdleTimeout    time.Duration
+
+	AbandonedCartCheckInterval time.Duration
+	AbandonedCartIdleAfter     time.Duration
+	// CartRetention is how long an idle cart is kept; zero keeps carts forever.
+	CartRetention time.Duration
+
+	// IdempotencyKeyTTL is how long the response to a request with an
+	// Idempotency-Key is replayed.
+	IdempotencyKeyTTL time.Duration
+
+	AuthJWKSFile   string
+	AuthHMACSecret string
+	AuthIssuer     string
+	AuthAudience   string
 }
 
 func Load(envFile string) (*Config, error) {
@@ -34,20 +60,33 @@ func Load(envFile string) (*Config, error) {
 	fmt.Printf("Loaded %s successfully\n", envFile)
 
 	cfg := &Config{
-		DBHost:          os.Getenv("DB_HOST"),
-		DBPort:          os.Getenv("DB_PORT"),
-		DBUser:          os.Getenv("DB_USER"),
-		DBPassword:      os.Getenv("DB_PASSWORD"),
-		DBName:          os.Getenv("DB_NAME"),
-		StockServiceURL: os.Getenv("STOCK_SERVICE_URL"),
-		GRPCPort:        os.Getenv("GRPC_PORT"),
-		HTTPPort:        os.Getenv("HTTP_PORT"),
-		GRPCEndpoint:    os.Getenv("GRPC_ENDPOINT"),
-		JaegerEndpoint:  os.Getenv("JAEGER_ENDPOINT"),
-		MetricsPort:     os.Getenv("METRICS_PORT"),
-		ReadTimeout:     ReadTimeout,
-		WriteTimeout:    WriteTimeout,
-		IdleTimeout:     IdleTimeout,
+		DBHost:            os.Getenv("DB_HOST"),
+		DBPort:            os.Getenv("DB_PORT"),
+		DBUser:            os.Getenv("DB_USER"),
+		DBPassword:        os.Getenv("DB_PASSWORD"),
+		DBName:            os.Getenv("DB_NAME"),
+		StockServiceURL:   os.Getenv("STOCK_SERVICE_URL"),
+		StockServiceToken: os.Getenv("STOCK_SERVICE_TOKEN"),
+		GRPCPort:          os.Getenv("GRPC_PORT"),
+		HTTPPort:          os.Getenv("HTTP_PORT"),
+		GRPCEndpoint:      os.Getenv("GRPC_ENDPOINT"),
+		JaegerEndpoint:    os.Getenv("JAEGER_ENDPOINT"),
+		MetricsPort:       os.Getenv("METRICS_PORT"),
+		ReadTimeout:       ReadTimeout,
+		WriteTimeout:      WriteTimeout,
+		IdleTimeout:       IdleTimeout,
+
+		StockClient: stockclient.DefaultConfig("", ""),
+		StockCache:  stockclient.DefaultCacheConfig(),
+
+		AbandonedCartCheckInterval: DefaultAbandonedCartCheckInterval,
+		AbandonedCartIdleAfter:     DefaultAbandonedCartIdleAfter,
+		IdempotencyKeyTTL:          DefaultIdempotencyKeyTTL,
+
+		AuthJWKSFile:   os.Getenv("AUTH_JWKS_FILE"),
+		AuthHMACSecret: os.Getenv("AUTH_HMAC_SECRET"),
+		AuthIssuer:     os.Getenv("AUTH_ISSUER"),
+		AuthAudience:   os.Getenv("AUTH_AUDIENCE"),
 	}
 
 	log.Println(cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
@@ -56,9 +95,165 @@ func Load(envFile string) (*Config, error) {
 		return nil, fmt.Errorf("missing required environment variables")
 	}
 
+	if cfg.AuthJWKSFile == "" && cfg.AuthHMACSecret == "" {
+		return nil, fmt.Errorf("AUTH_JWKS_FILE or AUTH_HMAC_SECRET must be set")
+	}
+
+	if v := os.Getenv("ABANDONED_CART_CHECK_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid ABANDONED_CART_CHECK_INTERVAL %q", v)
+		}
+
+		cfg.AbandonedCartCheckInterval = interval
+	}
+
+	if v := os.Getenv("ABANDONED_CART_IDLE_AFTER"); v != "" {
+		idleAfter, err := time.ParseDuration(v)
+		if err != nil || idleAfter <= 0 {
+			return nil, fmt.Errorf("invalid ABANDONED_CART_IDLE_AFTER %q", v)
+		}
+
+		cfg.AbandonedCartIdleAfter = idleAfter
+	}
+
+	if v := os.Getenv("CART_RETENTION"); v != "" {
+		retention, err := time.ParseDuration(v)
+		if err != nil || retention < 0 {
+			return nil, fmt.Errorf("invalid CART_RETENTION %q", v)
+		}
+
+		cfg.CartRetention = retention
+	}
+
+	if v := os.Getenv("IDEMPOTENCY_KEY_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL %q", v)
+		}
+
+		cfg.IdempotencyKeyTTL = ttl
+	}
+
+	if err := loadStockClientConfig(&cfg.StockClient); err != nil {
+		return nil, err
+	}
+
+	if err := loadStockCacheConfig(&cfg.StockCache); err != nil {
+		return nil, err
+	}
+
+	if cfg.CartRetention > 0 && cfg.CartRetention <= cfg.AbandonedCartIdleAfter {
+		return nil, fmt.Errorf("CART_RETENTION must be longer than ABANDONED_CART_IDLE_AFTER")
+	}
+
 	return cfg, nil
 }
 
+func loadStockClientConfig(cfg *stockclient.Config) error {
+	if v := os.Getenv("STOCK_CLIENT_MAX_ATTEMPTS"); v != "" {
+		attempts, err := strconv.Atoi(v)
+		if err != nil || attempts <= 0 {
+			return fmt.Errorf("invalid STOCK_CLIENT_MAX_ATTEMPTS %q", v)
+		}
+
+		cfg.MaxAttempts = attempts
+	}
+
+	if v := os.Getenv("STOCK_CLIENT_BREAKER_FAILURES"); v != "" {
+		failures, err := strconv.Atoi(v)
+		if err != nil || failures < 0 {
+			return fmt.Errorf("invalid STOCK_CLIENT_BREAKER_FAILURES %q", v)
+		}
+
+		cfg.BreakerFailures = failures
+	}
+
+	durations := []struct {
+		env   string
+		value *time.Duration
+	}{
+		{"STOCK_CLIENT_BACKOFF_BASE", &cfg.BackoffBase},
+		{"STOCK_CLIENT_BACKOFF_MAX", &cfg.BackoffMax},
+		{"STOCK_CLIENT_ATTEMPT_TIMEOUT", &cfg.AttemptTimeout},
+		{"STOCK_CLIENT_BREAKER_COOLDOWN", &cfg.BreakerCooldown},
+		{"STOCK_CLIENT_HEDGE_DELAY", &cfg.HedgeDelay},
+	}
+
+	for _, d := range durations {
+		v := os.Getenv(d.env)
+		if v == "" {
+			continue
+		}
+
+		value, err := time.ParseDuration(v)
+		if err != nil || value < 0 {
+			return fmt.Errorf("invalid %s %q", d.env, v)
+		}
+
+		*d.value = value
+	}
+
+	if cfg.AttemptTimeout <= 0 {
+		return fmt.Errorf("STOCK_CLIENT_ATTEMPT_TIMEOUT must be positive")
+	}
+
+	return nil
+}
+
+func loadStockCacheConfig(cfg *stockclient.CacheConfig) error {
+	if v := os.Getenv("STOCK_CACHE_SIZE"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 0 {
+			return fmt.Errorf("invalid STOCK_CACHE_SIZE %q", v)
+		}
+
+		cfg.Size = size
+	}
+
+	if v := os.Getenv("STOCK_CACHE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid STOCK_CACHE_TTL %q", v)
+		}
+
+		cfg.TTL = ttl
+	}
+
+	if v := os.Getenv("STOCK_CACHE_OFFLINE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			return fmt.Errorf("invalid STOCK_CACHE_OFFLINE_TTL %q", v)
+		}
+
+		cfg.OfflineTTL = ttl
+	}
+
+	if cfg.OfflineTTL > cfg.TTL {
+		return fmt.Errorf("STOCK_CACHE_OFFLINE_TTL must not be longer than STOCK_CACHE_TTL")
+	}
+
+	return nil
+}
+
+// StockClientConfig is the full configuration of the client of Stocks.
+func (c *Config) StockClientConfig() stockclient.Config {
+	cfg := c.StockClient
+	cfg.Addr = c.StockServiceURL
+	cfg.ServiceToken = c.StockServiceToken
+
+	return cfg
+}
+
+func (c *Config) AuthConfig() auth.Config {
+	return auth.Config{
+		JWKSFile:   c.AuthJWKSFile,
+		HMACSecret: c.AuthHMACSecret,
+		Issuer:     c.AuthIssuer,
+		Audience:   c.AuthAudience,
+	}
+}
+
 func (c *Config) PostgresConnStr() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
 		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName,
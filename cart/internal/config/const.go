@@ -6,4 +6,10 @@ const (
 	ReadTimeout  = 5 * time.Second
 	WriteTimeout = 10 * time.Second
 	IdleTimeout  = 15 * time.Second
+
+	DefaultAbandonedCartCheckInterval = 5 * time.Minute
+	DefaultAbandonedCartIdleAfter     = 24 * time.Hour
+
+	DefaultIdempotencyKeyTTL   = 24 * time.Hour
+	IdempotencyCleanupInterval = 10 * time.Minute
 )
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS orders (
+    order_id    BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL,
+    status      TEXT NOT NULL,
+    total_price NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders (user_id);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    order_id BIGINT NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    sku      BIGINT NOT NULL,
+    count    SMALLINT NOT NULL CHECK (count > 0),
+    price    NUMERIC(10, 2) NOT NULL,
+    PRIMARY KEY (order_id, sku)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE cart_items
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+
+-- Order items are priced in the currency of their order.
+ALTER TABLE orders
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE cart_items
+    DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS promotions (
+    promotion_id BIGSERIAL PRIMARY KEY,
+    code         TEXT NOT NULL UNIQUE,
+    description  TEXT NOT NULL DEFAULT '',
+    kind         TEXT NOT NULL CHECK (kind IN ('percentage', 'fixed_amount', 'buy_x_get_y')),
+    percent      SMALLINT NOT NULL DEFAULT 0 CHECK (percent BETWEEN 0 AND 100),
+    amount       NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    currency     CHAR(3) NOT NULL DEFAULT 'RUB',
+    buy_count    SMALLINT NOT NULL DEFAULT 0 CHECK (buy_count >= 0),
+    free_count   SMALLINT NOT NULL DEFAULT 0 CHECK (free_count >= 0),
+    -- Empty scope fields match every SKU.
+    sku          BIGINT NOT NULL DEFAULT 0,
+    sku_type     TEXT NOT NULL DEFAULT '',
+    starts_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ends_at      TIMESTAMPTZ,
+    -- 0 means unlimited; uses counts placed orders.
+    max_uses     INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    uses         INTEGER NOT NULL DEFAULT 0 CHECK (uses >= 0),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS cart_promotions (
+    user_id      BIGINT NOT NULL,
+    promotion_id BIGINT NOT NULL REFERENCES promotions (promotion_id) ON DELETE CASCADE,
+    applied_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, promotion_id)
+);
+
+CREATE TABLE IF NOT EXISTS order_promotions (
+    order_id     BIGINT NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    promotion_id BIGINT NOT NULL REFERENCES promotions (promotion_id),
+    discount     NUMERIC(12, 2) NOT NULL,
+    PRIMARY KEY (order_id, promotion_id)
+);
+
+ALTER TABLE orders
+    ADD COLUMN discount NUMERIC(12, 2) NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS discount;
+
+DROP TABLE IF EXISTS order_promotions;
+DROP TABLE IF EXISTS cart_promotions;
+DROP TABLE IF EXISTS promotions;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Guest carts are kept in cart_items and cart_promotions under the negated
+-- guest_id in place of a user id.
+CREATE TABLE IF NOT EXISTS guest_carts (
+    guest_id   BIGSERIAL PRIMARY KEY,
+    token_hash BYTEA NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM cart_promotions WHERE user_id < 0;
+DELETE FROM cart_items WHERE user_id < 0;
+DROP TABLE IF EXISTS guest_carts;
+-- +goose StatementEnd
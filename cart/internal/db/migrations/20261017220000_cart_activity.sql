@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE cart_items
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS cart_items_updated_at_idx ON cart_items (updated_at);
+
+-- One row per cart announced as abandoned, with the activity it was idle
+-- since; a cart is announced again only after it has been touched.
+CREATE TABLE IF NOT EXISTS abandoned_carts (
+    user_id          BIGINT PRIMARY KEY,
+    last_activity_at TIMESTAMPTZ NOT NULL,
+    notified_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS abandoned_carts;
+
+DROP INDEX IF EXISTS cart_items_updated_at_idx;
+
+ALTER TABLE cart_items
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
+-- +goose StatementEnd
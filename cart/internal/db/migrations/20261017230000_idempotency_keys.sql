@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- scope is the caller the key belongs to; response is NULL while the request
+-- is in progress.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope         TEXT NOT NULL,
+    key           TEXT NOT NULL,
+    request_hash  BYTEA NOT NULL,
+    response_type TEXT NOT NULL DEFAULT '',
+    response      BYTEA,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
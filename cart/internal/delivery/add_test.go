@@ -5,7 +5,6 @@ import (
 	"cart/internal/errors"
 	"cart/internal/usecase/mocks"
 	cart "cart/pkg/api/cart"
-	"context"
 	stdErr "errors"
 	"testing"
 
@@ -91,7 +90,7 @@ func TestHandler_AddItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := server.AddItem(context.Background(), tt.req)
+			resp, err := server.AddItem(userContext(1), tt.req)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
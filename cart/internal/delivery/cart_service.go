@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 
 	"cart/internal/log"
@@ -10,6 +9,8 @@ import (
 	cartpb "cart/pkg/api/cart"
 
 	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type cartServer struct {
@@ -30,13 +31,18 @@ func (s *cartServer) AddItem(ctx context.Context, req *cartpb.AddItemRequest) (*
 	ctx, span := tr.Start(ctx, "AddItem")
 	defer span.End()
 
+	userID, err := CartIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("AddItem called",
-		log.String("user_id", req.UserId),
+		log.Int64("user_id", userID),
 		log.String("sku", req.Sku),
 		log.Int32("count", req.Count),
 	)
 
-	item, err := CartItemFromAddRequest(req)
+	item, err := CartItemFromAddRequest(userID, req)
 	if err != nil {
 		s.logger.Error("Invalid AddItem request", log.Error(err))
 		return nil, err
@@ -49,7 +55,7 @@ func (s *cartServer) AddItem(ctx context.Context, req *cartpb.AddItemRequest) (*
 	}
 
 	s.logger.Info("Item added successfully",
-		log.String("user_id", req.UserId),
+		log.Int64("user_id", userID),
 		log.String("sku", req.Sku),
 	)
 
@@ -61,17 +67,16 @@ func (s *cartServer) DeleteItem(ctx context.Context, req *cartpb.DeleteItemReque
 	ctx, span := tr.Start(ctx, "DeleteItem")
 	defer span.End()
 
-	s.logger.Info("DeleteItem called",
-		log.String("user_id", req.UserId),
-		log.String("sku", req.Sku),
-	)
-
-	userID, err := ParseUserID(req.UserId)
+	userID, err := CartIDFromContext(ctx)
 	if err != nil {
-		s.logger.Error("Invalid user_id in DeleteItem", log.Error(err))
 		return nil, err
 	}
 
+	s.logger.Info("DeleteItem called",
+		log.Int64("user_id", userID),
+		log.String("sku", req.Sku),
+	)
+
 	sku, err := ParseSKU(req.Sku)
 	if err != nil {
 		s.logger.Error("Invalid sku in DeleteItem", log.Error(err))
@@ -85,7 +90,7 @@ func (s *cartServer) DeleteItem(ctx context.Context, req *cartpb.DeleteItemReque
 	}
 
 	s.logger.Info("Item deleted successfully",
-		log.String("user_id", req.UserId),
+		log.Int64("user_id", userID),
 		log.String("sku", req.Sku),
 	)
 
@@ -97,16 +102,15 @@ func (s *cartServer) ClearCart(ctx context.Context, req *cartpb.ClearCartRequest
 	ctx, span := tr.Start(ctx, "ClearCart")
 	defer span.End()
 
-	s.logger.Info("ClearCart called",
-		log.String("user_id", req.UserId),
-	)
-
-	userID, err := ParseUserID(req.UserId)
+	userID, err := CartIDFromContext(ctx)
 	if err != nil {
-		s.logger.Error("Invalid user_id in ClearCart", log.Error(err))
-		return nil, fmt.Errorf("invalid user_id: %w", err)
+		return nil, err
 	}
 
+	s.logger.Info("ClearCart called",
+		log.Int64("user_id", userID),
+	)
+
 	err = s.useCase.Clear(ctx, userID)
 	if err != nil {
 		s.logger.Error("Failed to clear cart", log.Error(err))
@@ -114,7 +118,7 @@ func (s *cartServer) ClearCart(ctx context.Context, req *cartpb.ClearCartRequest
 	}
 
 	s.logger.Info("Cart cleared successfully",
-		log.String("user_id", req.UserId),
+		log.Int64("user_id", userID),
 	)
 
 	return &cartpb.CartResponse{Message: "Cart cleared successfully"}, nil
@@ -125,41 +129,170 @@ func (s *cartServer) ListCart(ctx context.Context, req *cartpb.ListCartRequest)
 	ctx, span := tr.Start(ctx, "ListCart")
 	defer span.End()
 
+	userID, err := CartIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("ListCart called",
-		log.String("user_id", req.UserId),
+		log.Int64("user_id", userID),
 	)
 
-	userID, err := ParseUserID(req.UserId)
+	cart, err := s.useCase.List(ctx, userID)
 	if err != nil {
-		s.logger.Error("Invalid user_id in ListCart", log.Error(err))
-		return nil, fmt.Errorf("invalid user_id: %w", err)
+		s.logger.Error("Failed to list cart items", log.Error(err))
+		return nil, err
 	}
 
-	items, err := s.useCase.List(ctx, userID)
+	resp := CartToListCartResponse(strconv.FormatInt(userID, 10), cart)
+
+	s.logger.Info("ListCart succeeded",
+		log.Int64("user_id", userID),
+		log.Int("item_count", len(resp.Items)),
+	)
+
+	return resp, nil
+}
+
+func (s *cartServer) Checkout(ctx context.Context, req *cartpb.CheckoutRequest) (*cartpb.CheckoutResponse, error) {
+	tr := otel.Tracer("cart-server")
+	ctx, span := tr.Start(ctx, "Checkout")
+	defer span.End()
+
+	userID, err := UserIDFromContext(ctx)
 	if err != nil {
-		s.logger.Error("Failed to list cart items", log.Error(err))
 		return nil, err
 	}
 
-	var cartItems []*cartpb.CartItem
-	for _, item := range items {
-		cartItems = append(cartItems, &cartpb.CartItem{
-			Sku:   strconv.FormatUint(uint64(item.SKU), 10),
-			Count: int32(item.Count),
-		})
+	s.logger.Info("Checkout called",
+		log.Int64("user_id", userID),
+	)
+
+	order, err := s.useCase.Checkout(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to checkout cart", log.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Checkout succeeded",
+		log.Int64("user_id", userID),
+		log.Int64("order_id", order.ID),
+	)
+
+	return OrderToCheckoutResponse(order), nil
+}
+
+func (s *cartServer) ApplyPromoCode(ctx context.Context, req *cartpb.ApplyPromoCodeRequest) (*cartpb.CartResponse, error) {
+	tr := otel.Tracer("cart-server")
+	ctx, span := tr.Start(ctx, "ApplyPromoCode")
+	defer span.End()
+
+	userID, err := CartIDFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if cartItems == nil {
-		cartItems = []*cartpb.CartItem{}
+	code, err := PromoCode(req.Code)
+	if err != nil {
+		s.logger.Error("Invalid ApplyPromoCode request", log.Error(err))
+		return nil, err
 	}
 
-	s.logger.Info("ListCart succeeded",
-		log.String("user_id", req.UserId),
-		log.Int("item_count", len(cartItems)),
+	s.logger.Info("ApplyPromoCode called",
+		log.Int64("user_id", userID),
+		log.String("code", code),
+	)
+
+	if err = s.useCase.ApplyPromoCode(ctx, userID, code); err != nil {
+		s.logger.Error("Failed to apply promo code", log.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Promo code applied successfully",
+		log.Int64("user_id", userID),
+		log.String("code", code),
+	)
+
+	return &cartpb.CartResponse{Message: "Promo code applied successfully"}, nil
+}
+
+func (s *cartServer) RemovePromoCode(ctx context.Context, req *cartpb.RemovePromoCodeRequest) (*cartpb.CartResponse, error) {
+	tr := otel.Tracer("cart-server")
+	ctx, span := tr.Start(ctx, "RemovePromoCode")
+	defer span.End()
+
+	userID, err := CartIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := PromoCode(req.Code)
+	if err != nil {
+		s.logger.Error("Invalid RemovePromoCode request", log.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("RemovePromoCode called",
+		log.Int64("user_id", userID),
+		log.String("code", code),
+	)
+
+	if err = s.useCase.RemovePromoCode(ctx, userID, code); err != nil {
+		s.logger.Error("Failed to remove promo code", log.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Promo code removed successfully",
+		log.Int64("user_id", userID),
+		log.String("code", code),
+	)
+
+	return &cartpb.CartResponse{Message: "Promo code removed successfully"}, nil
+}
+
+func (s *cartServer) CreateGuestCart(ctx context.Context, _ *cartpb.CreateGuestCartRequest) (*cartpb.CreateGuestCartResponse, error) {
+	tr := otel.Tracer("cart-server")
+	ctx, span := tr.Start(ctx, "CreateGuestCart")
+	defer span.End()
+
+	token, err := s.useCase.CreateGuestCart(ctx)
+	if err != nil {
+		s.logger.Error("Failed to create guest cart", log.Error(err))
+		return nil, err
+	}
+
+	return &cartpb.CreateGuestCartResponse{CartToken: token}, nil
+}
+
+func (s *cartServer) MergeCarts(ctx context.Context, req *cartpb.MergeCartsRequest) (*cartpb.MergeCartsResponse, error) {
+	tr := otel.Tracer("cart-server")
+	ctx, span := tr.Start(ctx, "MergeCarts")
+	defer span.End()
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CartToken == "" {
+		s.logger.Error("Invalid MergeCarts request", log.Int64("user_id", userID))
+		return nil, status.Error(codes.InvalidArgument, "cart_token is required")
+	}
+
+	s.logger.Info("MergeCarts called",
+		log.Int64("user_id", userID),
+	)
+
+	report, err := s.useCase.MergeCarts(ctx, userID, req.CartToken)
+	if err != nil {
+		s.logger.Error("Failed to merge carts", log.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("MergeCarts succeeded",
+		log.Int64("user_id", userID),
+		log.Int("item_count", len(report.Items)),
 	)
 
-	return &cartpb.ListCartResponse{
-		UserId: req.UserId,
-		Items:  cartItems,
-	}, nil
+	return MergeReportToProto(report), nil
 }
@@ -0,0 +1,128 @@
+package delivery_test
+
+import (
+	"cart/internal/delivery"
+	"cart/internal/errors"
+	"cart/internal/models"
+	"cart/internal/money"
+	"cart/internal/usecase/mocks"
+	cart "cart/pkg/api/cart"
+	moneypb "cart/pkg/api/money"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Checkout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewCartServer(mockUsecase, mockLogger)
+	validReq := &cart.CheckoutRequest{
+		UserId: "1",
+	}
+
+	tests := []struct {
+		name           string
+		req            *cart.CheckoutRequest
+		ctx            context.Context
+		mockSetup      func()
+		expectedResult *cart.CheckoutResponse
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Checkout(gomock.Any(), int64(1)).Return(models.Order{
+					ID:     7,
+					UserID: 1,
+					Status: models.OrderStatusCreated,
+					Items: []models.OrderItem{
+						{SKU: 100, Count: 2, Price: money.New("RUB", 1000)},
+					},
+					Subtotal:   money.New("RUB", 2000),
+					TotalPrice: money.New("RUB", 2000),
+				}, nil)
+			},
+			expectedResult: &cart.CheckoutResponse{
+				OrderId: "7",
+				UserId:  "1",
+				Items: []*cart.OrderItem{
+					{Sku: "100", Count: 2, Price: 10, UnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 10}},
+				},
+				TotalPrice:    20,
+				Total:         &moneypb.Money{CurrencyCode: "RUB", Units: 20},
+				Status:        "created",
+				Subtotal:      &moneypb.Money{CurrencyCode: "RUB", Units: 20},
+				Discounts:     []*cart.CartDiscount{},
+				DiscountTotal: &moneypb.Money{CurrencyCode: "RUB"},
+			},
+		},
+		{
+			name:        "unauthenticated",
+			ctx:         context.Background(),
+			req:         validReq,
+			mockSetup:   func() {},
+			expectedErr: "unauthenticated",
+		},
+		{
+			name: "request user_id is ignored",
+			ctx:  userContext(2),
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Checkout(gomock.Any(), int64(2)).Return(models.Order{ID: 8, UserID: 2}, nil)
+			},
+			expectedResult: &cart.CheckoutResponse{
+				OrderId:       "8",
+				UserId:        "2",
+				Items:         []*cart.OrderItem{},
+				Total:         &moneypb.Money{CurrencyCode: "RUB"},
+				Subtotal:      &moneypb.Money{CurrencyCode: "RUB"},
+				Discounts:     []*cart.CartDiscount{},
+				DiscountTotal: &moneypb.Money{CurrencyCode: "RUB"},
+			},
+		},
+		{
+			name: "empty cart",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().Checkout(gomock.Any(), int64(1)).Return(models.Order{}, errors.ErrEmptyCart)
+			},
+			expectedErr: "cart is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = userContext(1)
+			}
+
+			resp, err := server.Checkout(ctx, tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
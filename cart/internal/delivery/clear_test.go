@@ -4,7 +4,6 @@ import (
 	"cart/internal/delivery"
 	"cart/internal/usecase/mocks"
 	cart "cart/pkg/api/cart"
-	"context"
 	stdErrors "errors"
 	"testing"
 
@@ -58,7 +57,7 @@ func TestHandler_ClearCart(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := server.ClearCart(context.Background(), tt.req)
+			resp, err := server.ClearCart(userContext(1), tt.req)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
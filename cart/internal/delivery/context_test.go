@@ -0,0 +1,14 @@
+package delivery_test
+
+import (
+	"cart/internal/auth"
+	"context"
+)
+
+func userContext(userID int64) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{UserID: userID})
+}
+
+func guestContext(guestID int64) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{GuestID: guestID})
+}
@@ -5,7 +5,6 @@ import (
 	"cart/internal/errors"
 	"cart/internal/usecase/mocks"
 	cart "cart/pkg/api/cart"
-	"context"
 	stdErrors "errors"
 	"testing"
 
@@ -71,7 +70,7 @@ func TestHandler_DeleteItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := server.DeleteItem(context.Background(), tt.req)
+			resp, err := server.DeleteItem(userContext(1), tt.req)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
@@ -0,0 +1,116 @@
+package delivery_test
+
+import (
+	"cart/internal/delivery"
+	"cart/internal/errors"
+	"cart/internal/models"
+	"cart/internal/usecase/mocks"
+	cart "cart/pkg/api/cart"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_MergeCarts(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewCartServer(mockUsecase, mockLogger)
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		req            *cart.MergeCartsRequest
+		mockSetup      func()
+		expectedResult *cart.MergeCartsResponse
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			ctx:  userContext(1),
+			req:  &cart.MergeCartsRequest{CartToken: "token"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().MergeCarts(gomock.Any(), int64(1), "token").Return(models.MergeReport{Items: []models.MergedItem{
+					{SKU: 100, GuestCount: 4, UserCount: 2, Count: 5, Adjustment: models.MergeAdjustmentCapped, Reason: "only 5 available"},
+				}}, nil)
+			},
+			expectedResult: &cart.MergeCartsResponse{Items: []*cart.MergedItem{
+				{Sku: "100", GuestCount: 4, UserCount: 2, Count: 5, Adjustment: cart.MergeAdjustment_MERGE_ADJUSTMENT_CAPPED, Reason: "only 5 available"},
+			}},
+		},
+		{
+			name:        "guests must sign in",
+			ctx:         guestContext(3),
+			req:         &cart.MergeCartsRequest{CartToken: "token"},
+			mockSetup:   func() {},
+			expectedErr: "sign in to continue",
+		},
+		{
+			name: "missing cart token",
+			ctx:  userContext(1),
+			req:  &cart.MergeCartsRequest{},
+			mockSetup: func() {
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "cart_token is required",
+		},
+		{
+			name: "unknown cart token",
+			ctx:  userContext(1),
+			req:  &cart.MergeCartsRequest{CartToken: "stolen"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().MergeCarts(gomock.Any(), int64(1), "stolen").Return(models.MergeReport{}, errors.ErrGuestCartNotFound)
+			},
+			expectedErr: errors.ErrGuestCartNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.MergeCarts(tt.ctx, tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_GuestCart(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewCartServer(mockUsecase, mockLogger)
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockUsecase.EXPECT().Clear(gomock.Any(), models.GuestCartID(3)).Return(nil)
+
+	_, err := server.ClearCart(guestContext(3), &cart.ClearCartRequest{})
+	assert.NoError(t, err, "guests manage their own cart")
+
+	_, err = server.Checkout(guestContext(3), &cart.CheckoutRequest{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sign in to continue")
+}
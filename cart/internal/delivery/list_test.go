@@ -3,9 +3,10 @@ package delivery_test
 import (
 	"cart/internal/delivery"
 	"cart/internal/models"
+	"cart/internal/money"
 	"cart/internal/usecase/mocks"
 	cart "cart/pkg/api/cart"
-	"context"
+	moneypb "cart/pkg/api/money"
 	stdErrors "errors"
 	"testing"
 
@@ -40,17 +41,43 @@ func TestHandler_ListItems(t *testing.T) {
 			req:  validReq,
 			mockSetup: func() {
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
-				mockUsecase.EXPECT().List(gomock.Any(), int64(1)).Return([]models.CartItem{
-					{UserID: 1, SKU: 100, Count: 2},
-					{UserID: 1, SKU: 101, Count: 3},
+				mockUsecase.EXPECT().List(gomock.Any(), int64(1)).Return(models.Cart{
+					UserID: 1,
+					Items: []models.CartItem{
+						{UserID: 1, SKU: 100, Count: 2, Stock: 5, Name: "t-shirt", Type: "apparel", Price: money.New("RUB", 1000)},
+						{UserID: 1, SKU: 101, Count: 3, Stock: 1, Price: money.New("RUB", 250), Unfulfillable: true},
+					},
+					Promotions: []models.AppliedPromotion{
+						{Promotion: models.Promotion{Code: "TEN", Description: "10% off"}, Discount: money.New("RUB", 275)},
+						{Promotion: models.Promotion{Code: "OLD"}, Reason: "promotion is not active"},
+					},
+					Subtotal:   money.New("RUB", 2750),
+					Discount:   money.New("RUB", 275),
+					TotalPrice: money.New("RUB", 2475),
 				}, nil)
 			},
 			expectedResult: &cart.ListCartResponse{
 				UserId: "1",
 				Items: []*cart.CartItem{
-					{Sku: "100", Count: 2},
-					{Sku: "101", Count: 3},
+					{
+						Sku: "100", Count: 2, Available: 5, Name: "t-shirt", Type: "apparel", Price: 10, LineTotal: 20,
+						UnitPrice:  &moneypb.Money{CurrencyCode: "RUB", Units: 10},
+						LineAmount: &moneypb.Money{CurrencyCode: "RUB", Units: 20},
+					},
+					{
+						Sku: "101", Count: 3, Available: 1, Price: 2.5, LineTotal: 7.5, Unfulfillable: true,
+						UnitPrice:  &moneypb.Money{CurrencyCode: "RUB", Units: 2, Nanos: 500000000},
+						LineAmount: &moneypb.Money{CurrencyCode: "RUB", Units: 7, Nanos: 500000000},
+					},
 				},
+				TotalPrice: 24.75,
+				Total:      &moneypb.Money{CurrencyCode: "RUB", Units: 24, Nanos: 750000000},
+				Subtotal:   &moneypb.Money{CurrencyCode: "RUB", Units: 27, Nanos: 500000000},
+				Discounts: []*cart.CartDiscount{
+					{Code: "TEN", Description: "10% off", Amount: &moneypb.Money{CurrencyCode: "RUB", Units: 2, Nanos: 750000000}, Applied: true},
+					{Code: "OLD", Amount: &moneypb.Money{CurrencyCode: "RUB"}, Reason: "promotion is not active"},
+				},
+				DiscountTotal: &moneypb.Money{CurrencyCode: "RUB", Units: 2, Nanos: 750000000},
 			},
 		},
 		{
@@ -58,11 +85,15 @@ func TestHandler_ListItems(t *testing.T) {
 			req:  validReq,
 			mockSetup: func() {
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
-				mockUsecase.EXPECT().List(gomock.Any(), int64(1)).Return(nil, nil)
+				mockUsecase.EXPECT().List(gomock.Any(), int64(1)).Return(models.Cart{UserID: 1}, nil)
 			},
 			expectedResult: &cart.ListCartResponse{
-				UserId: "1",
-				Items:  []*cart.CartItem{},
+				UserId:        "1",
+				Items:         []*cart.CartItem{},
+				Total:         &moneypb.Money{CurrencyCode: "RUB"},
+				Subtotal:      &moneypb.Money{CurrencyCode: "RUB"},
+				Discounts:     []*cart.CartDiscount{},
+				DiscountTotal: &moneypb.Money{CurrencyCode: "RUB"},
 			},
 		},
 		{
@@ -71,7 +102,7 @@ func TestHandler_ListItems(t *testing.T) {
 			mockSetup: func() {
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
-				mockUsecase.EXPECT().List(gomock.Any(), int64(1)).Return(nil, stdErrors.New("db error"))
+				mockUsecase.EXPECT().List(gomock.Any(), int64(1)).Return(models.Cart{}, stdErrors.New("db error"))
 			},
 			expectedErr: "db error",
 		},
@@ -81,7 +112,7 @@ func TestHandler_ListItems(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := server.ListCart(context.Background(), tt.req)
+			resp, err := server.ListCart(userContext(1), tt.req)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
@@ -0,0 +1,101 @@
+package delivery_test
+
+import (
+	"cart/internal/delivery"
+	"cart/internal/errors"
+	"cart/internal/usecase/mocks"
+	cart "cart/pkg/api/cart"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ApplyPromoCode(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewCartServer(mockUsecase, mockLogger)
+
+	tests := []struct {
+		name           string
+		req            *cart.ApplyPromoCodeRequest
+		mockSetup      func()
+		expectedResult *cart.CartResponse
+		expectedErr    string
+	}{
+		{
+			name: "success with normalized code",
+			req:  &cart.ApplyPromoCodeRequest{Code: " spring10 "},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().ApplyPromoCode(gomock.Any(), int64(1), "SPRING10").Return(nil)
+			},
+			expectedResult: &cart.CartResponse{Message: "Promo code applied successfully"},
+		},
+		{
+			name: "empty code",
+			req:  &cart.ApplyPromoCodeRequest{},
+			mockSetup: func() {
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "code is required",
+		},
+		{
+			name: "promotion does not apply",
+			req:  &cart.ApplyPromoCodeRequest{Code: "BOOKS"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().ApplyPromoCode(gomock.Any(), int64(1), "BOOKS").Return(errors.ErrPromotionNotApplicable)
+			},
+			expectedErr: errors.ErrPromotionNotApplicable.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.ApplyPromoCode(userContext(1), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_RemovePromoCode(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCartUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewCartServer(mockUsecase, mockLogger)
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+	mockUsecase.EXPECT().RemovePromoCode(gomock.Any(), int64(1), "TEN").Return(nil)
+	mockUsecase.EXPECT().RemovePromoCode(gomock.Any(), int64(1), "OTHER").Return(errors.ErrPromotionNotApplied)
+
+	resp, err := server.RemovePromoCode(userContext(1), &cart.RemovePromoCodeRequest{Code: "ten"})
+	assert.NoError(t, err)
+	assert.Equal(t, &cart.CartResponse{Message: "Promo code removed successfully"}, resp)
+
+	_, err = server.RemovePromoCode(userContext(1), &cart.RemovePromoCodeRequest{Code: "other"})
+	assert.ErrorIs(t, err, errors.ErrPromotionNotApplied)
+}
@@ -1,16 +1,46 @@
 package delivery
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
+	"cart/internal/auth"
 	"cart/internal/models"
+	"cart/internal/money"
 	cartpb "cart/pkg/api/cart"
+	moneypb "cart/pkg/api/money"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func ParseUserID(userIDStr string) (int64, error) {
-	return strconv.ParseInt(userIDStr, 10, 64)
+// CartIDFromContext returns the key of the caller's cart, a user's or a
+// guest's. The user_id fields of the requests are ignored: callers can only
+// act on their own cart.
+func CartIDFromContext(ctx context.Context) (int64, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	return identity.CartID(), nil
+}
+
+// UserIDFromContext returns the signed-in caller; guests are refused.
+func UserIDFromContext(ctx context.Context) (int64, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	if identity.IsGuest() {
+		return 0, status.Error(codes.PermissionDenied, "sign in to continue")
+	}
+
+	return identity.UserID, nil
 }
 
 func ParseSKU(skuStr string) (uint32, error) {
@@ -22,12 +52,7 @@ func ParseSKU(skuStr string) (uint32, error) {
 	return uint32(skuUint64), nil
 }
 
-func CartItemFromAddRequest(req *cartpb.AddItemRequest) (models.CartItem, error) {
-	userID, err := ParseUserID(req.UserId)
-	if err != nil {
-		return models.CartItem{}, fmt.Errorf("invalid user_id: %w", err)
-	}
-
+func CartItemFromAddRequest(userID int64, req *cartpb.AddItemRequest) (models.CartItem, error) {
 	sku, err := ParseSKU(req.Sku)
 	if err != nil {
 		return models.CartItem{}, fmt.Errorf("invalid sku: %w", err)
@@ -44,3 +69,122 @@ func CartItemFromAddRequest(req *cartpb.AddItemRequest) (models.CartItem, error)
 		Count:  count,
 	}, nil
 }
+
+func CartToListCartResponse(userID string, cart models.Cart) *cartpb.ListCartResponse {
+	items := make([]*cartpb.CartItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, &cartpb.CartItem{
+			Sku:           strconv.FormatUint(uint64(item.SKU), 10),
+			Count:         int32(item.Count),
+			Available:     int32(item.Stock),
+			Name:          item.Name,
+			Type:          item.Type,
+			Price:         float32(item.Price.Float64()),
+			LineTotal:     float32(item.LineTotal().Float64()),
+			Unfulfillable: item.Unfulfillable,
+			UnitPrice:     money.ToProto(item.Price),
+			LineAmount:    money.ToProto(item.LineTotal()),
+		})
+	}
+
+	return &cartpb.ListCartResponse{
+		UserId:        userID,
+		Items:         items,
+		TotalPrice:    float32(cart.TotalPrice.Float64()),
+		Total:         totalToProto(cart.TotalPrice),
+		Subtotal:      totalToProto(cart.Subtotal),
+		Discounts:     DiscountsToProto(cart.Promotions, cart.Subtotal.Currency),
+		DiscountTotal: totalToProto(inCurrency(cart.Discount, cart.Subtotal.Currency)),
+	}
+}
+
+func OrderToCheckoutResponse(order models.Order) *cartpb.CheckoutResponse {
+	items := make([]*cartpb.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &cartpb.OrderItem{
+			Sku:       strconv.FormatUint(uint64(item.SKU), 10),
+			Count:     int32(item.Count),
+			Price:     float32(item.Price.Float64()),
+			UnitPrice: money.ToProto(item.Price),
+		})
+	}
+
+	return &cartpb.CheckoutResponse{
+		OrderId:       strconv.FormatInt(order.ID, 10),
+		UserId:        strconv.FormatInt(order.UserID, 10),
+		Items:         items,
+		TotalPrice:    float32(order.TotalPrice.Float64()),
+		Status:        string(order.Status),
+		Total:         totalToProto(order.TotalPrice),
+		Subtotal:      totalToProto(order.Subtotal),
+		Discounts:     DiscountsToProto(order.Promotions, order.Subtotal.Currency),
+		DiscountTotal: totalToProto(inCurrency(order.Discount, order.Subtotal.Currency)),
+	}
+}
+
+func DiscountsToProto(promotions []models.AppliedPromotion, currency string) []*cartpb.CartDiscount {
+	discounts := make([]*cartpb.CartDiscount, 0, len(promotions))
+	for _, p := range promotions {
+		discounts = append(discounts, &cartpb.CartDiscount{
+			Code:        p.Promotion.Code,
+			Description: p.Promotion.Description,
+			Amount:      totalToProto(inCurrency(p.Discount, currency)),
+			Applied:     p.Applied(),
+			Reason:      p.Reason,
+		})
+	}
+
+	return discounts
+}
+
+// PromoCode normalizes a promo code: codes are stored upper-case.
+func PromoCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return "", status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	return code, nil
+}
+
+// inCurrency gives a zero amount without a currency, such as the discount of
+// a cart without promotions, the currency of the cart.
+func inCurrency(amount money.Money, currency string) money.Money {
+	if amount.Currency == "" {
+		amount.Currency = currency
+	}
+
+	return amount
+}
+
+// totalToProto reports the total of an empty cart in the default currency
+// rather than without one.
+func totalToProto(total money.Money) *moneypb.Money {
+	if total.Currency == "" {
+		total.Currency = money.DefaultCurrency
+	}
+
+	return money.ToProto(total)
+}
+
+var mergeAdjustments = map[models.MergeAdjustment]cartpb.MergeAdjustment{
+	models.MergeAdjustmentNone:    cartpb.MergeAdjustment_MERGE_ADJUSTMENT_NONE,
+	models.MergeAdjustmentCapped:  cartpb.MergeAdjustment_MERGE_ADJUSTMENT_CAPPED,
+	models.MergeAdjustmentDropped: cartpb.MergeAdjustment_MERGE_ADJUSTMENT_DROPPED,
+}
+
+func MergeReportToProto(report models.MergeReport) *cartpb.MergeCartsResponse {
+	items := make([]*cartpb.MergedItem, 0, len(report.Items))
+	for _, item := range report.Items {
+		items = append(items, &cartpb.MergedItem{
+			Sku:        strconv.FormatUint(uint64(item.SKU), 10),
+			GuestCount: int32(item.GuestCount),
+			UserCount:  int32(item.UserCount),
+			Count:      int32(item.Count),
+			Adjustment: mergeAdjustments[item.Adjustment],
+			Reason:     item.Reason,
+		})
+	}
+
+	return &cartpb.MergeCartsResponse{Items: items}
+}
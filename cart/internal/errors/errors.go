@@ -3,8 +3,22 @@ package errors
 import "errors"
 
 var (
-	ErrInvalidSKU       = errors.New("invalid SKU — not registered")
-	ErrCartItemNotFound = errors.New("cart item not found")
-	ErrCartItemExists   = errors.New("cart item already exists")
-	ErrNotEnoughStock   = errors.New("not enough stock available")
+	ErrInvalidSKU        = errors.New("invalid SKU — not registered")
+	ErrSKUArchived       = errors.New("SKU is archived")
+	ErrCartItemNotFound  = errors.New("cart item not found")
+	ErrCartItemExists    = errors.New("cart item already exists")
+	ErrNotEnoughStock    = errors.New("not enough stock available")
+	ErrEmptyCart         = errors.New("cart is empty")
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrCurrencyMismatch  = errors.New("currency mismatch")
+	ErrGuestCartNotFound = errors.New("guest cart not found")
+	ErrGuestCartChanged  = errors.New("guest cart changed during the merge")
+	ErrStocksUnavailable = errors.New("stock service unavailable")
+
+	ErrPromotionNotFound       = errors.New("promo code not found")
+	ErrPromotionNotActive      = errors.New("promotion is not active")
+	ErrPromotionUsedUp         = errors.New("promotion usage limit reached")
+	ErrPromotionNotApplicable  = errors.New("promotion does not apply to the cart")
+	ErrPromotionAlreadyApplied = errors.New("promo code already applied")
+	ErrPromotionNotApplied     = errors.New("promo code is not applied to the cart")
 )
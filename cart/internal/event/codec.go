@@ -0,0 +1,143 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	eventspb "cart/pkg/api/events"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// HeaderContentType is the record header that says how the envelope in the
+// record value is encoded. Records without it predate the envelope.
+const HeaderContentType = "content-type"
+
+const (
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeJSON     = "application/json"
+)
+
+// ContentType maps a KAFKA_EVENT_ENCODING value to the content type events
+// are published with.
+func ContentType(encoding string) (string, error) {
+	switch encoding {
+	case "", "protobuf":
+		return ContentTypeProtobuf, nil
+	case "json":
+		return ContentTypeJSON, nil
+	default:
+		return "", fmt.Errorf("unknown event encoding %q, want protobuf or json", encoding)
+	}
+}
+
+// TypeOf returns the event type of env, which is the name of its payload
+// field, or "" if no payload is set.
+func TypeOf(env *eventspb.Envelope) string {
+	m := env.ProtoReflect()
+
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("payload"))
+	if fd == nil {
+		return ""
+	}
+
+	return string(fd.Name())
+}
+
+func Marshal(env *eventspb.Envelope, contentType string) ([]byte, error) {
+	switch mediaType(contentType) {
+	case ContentTypeProtobuf:
+		return proto.Marshal(env)
+	case ContentTypeJSON:
+		return protojson.Marshal(env)
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+}
+
+// Unmarshal decodes a record value published with contentType. A payload
+// unknown to this build leaves the payload of the envelope unset.
+func Unmarshal(contentType string, data []byte) (*eventspb.Envelope, error) {
+	var (
+		env = &eventspb.Envelope{}
+		err error
+	)
+
+	switch mediaType(contentType) {
+	case ContentTypeProtobuf:
+		err = proto.Unmarshal(data, env)
+	case ContentTypeJSON:
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, env)
+	case "":
+		env, err = unmarshalLegacy(data)
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if payloadType := TypeOf(env); payloadType != "" && payloadType != env.GetType() {
+		return nil, fmt.Errorf("%s event carries a %s payload", env.GetType(), payloadType)
+	}
+
+	return env, nil
+}
+
+// legacyMessage is the JSON message published before the envelope. Version
+// is zero in messages that predate payload versions.
+type legacyMessage struct {
+	Type      string          `json:"type"`
+	Version   int32           `json:"version"`
+	Service   string          `json:"service"`
+	Timestamp string          `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// unmarshalLegacy reads a legacy message into an envelope. The JSON names of
+// the payload messages are the keys the legacy payloads used, so a payload is
+// read by the JSON mapping of the field its type names.
+func unmarshalLegacy(data []byte) (*eventspb.Envelope, error) {
+	var msg legacyMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+
+	env := &eventspb.Envelope{
+		Type:          msg.Type,
+		SchemaVersion: max(msg.Version, 1),
+		Source:        msg.Service,
+	}
+
+	if occurredAt, err := time.Parse(time.RFC3339, msg.Timestamp); err == nil {
+		env.OccurredAt = timestamppb.New(occurredAt)
+	}
+
+	m := env.ProtoReflect()
+
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(msg.Type))
+	if fd == nil || fd.ContainingOneof() == nil || len(msg.Payload) == 0 {
+		return env, nil
+	}
+
+	payload := m.NewField(fd)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(msg.Payload, payload.Message().Interface()); err != nil {
+		return nil, fmt.Errorf("invalid legacy %s payload: %w", msg.Type, err)
+	}
+
+	m.Set(fd, payload)
+
+	return env, nil
+}
+
+// mediaType drops the parameters of a content type, e.g. "; charset=utf-8".
+func mediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.TrimSpace(mediaType)
+}
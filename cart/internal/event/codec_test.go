@@ -0,0 +1,133 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	eventspb "cart/pkg/api/events"
+	moneypb "cart/pkg/api/money"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	env := &eventspb.Envelope{
+		EventId:       "5f0c6c1e-8d2a-4b8e-9d51-3f6f1f3c2a10",
+		Type:          TypeCartItemAdded,
+		SchemaVersion: 1,
+		Source:        "cart-service",
+		OccurredAt:    timestamppb.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)),
+		TraceContext:  map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		Payload: &eventspb.Envelope_CartItemAdded{CartItemAdded: &eventspb.CartItemAdded{
+			CartId: "1", Sku: "1001", Count: 2, Status: "added",
+		}},
+	}
+
+	for _, contentType := range []string{ContentTypeProtobuf, ContentTypeJSON, ContentTypeJSON + "; charset=utf-8"} {
+		t.Run(contentType, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := Marshal(env, contentType)
+			assert.NoError(t, err)
+
+			got, err := Unmarshal(contentType, data)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(env, got), "got %v", got)
+		})
+	}
+}
+
+func TestUnmarshal_Legacy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    *eventspb.Envelope
+		wantErr bool
+	}{
+		{
+			name: "versioned",
+			data: `{"type":"stock_changed","version":1,"service":"stocks-service","timestamp":"2026-10-17T12:00:00Z",` +
+				`"payload":{"sku":"1001","count":5,"price":10.5,"unitPrice":{"currencyCode":"RUB","units":10,"nanos":500000000}}}`,
+			want: &eventspb.Envelope{
+				Type:          "stock_changed",
+				SchemaVersion: 1,
+				Source:        "stocks-service",
+				OccurredAt:    timestamppb.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)),
+				Payload: &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{
+					Sku:       "1001",
+					Count:     5,
+					Price:     10.5,
+					UnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 10, Nanos: 500000000},
+				}},
+			},
+		},
+		{
+			name: "unversioned with timestamps and unknown fields",
+			data: `{"type":"reservation_expired","service":"stocks-service","timestamp":"2026-10-17T12:00:00Z",` +
+				`"payload":{"reservationId":"7","items":[{"sku":"1","count":1}],"expiredAt":"2026-10-17T11:00:00Z","extra":true}}`,
+			want: &eventspb.Envelope{
+				Type:          "reservation_expired",
+				SchemaVersion: 1,
+				Source:        "stocks-service",
+				OccurredAt:    timestamppb.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)),
+				Payload: &eventspb.Envelope_ReservationExpired{ReservationExpired: &eventspb.ReservationExpired{
+					ReservationId: "7",
+					Items:         []*eventspb.ReservationItem{{Sku: "1", Count: 1}},
+					ExpiredAt:     timestamppb.New(time.Date(2026, 10, 17, 11, 0, 0, 0, time.UTC)),
+				}},
+			},
+		},
+		{
+			name: "unknown type",
+			data: `{"type":"sku_renamed","version":1,"service":"stocks-service","payload":{"sku":"1"}}`,
+			want: &eventspb.Envelope{Type: "sku_renamed", SchemaVersion: 1, Source: "stocks-service"},
+		},
+		{
+			name:    "invalid payload",
+			data:    `{"type":"stock_changed","version":1,"payload":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			data:    `stock_changed`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Unmarshal("", []byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(tt.want, got), "got %v", got)
+		})
+	}
+}
+
+func TestUnmarshal_TypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	data, err := Marshal(&eventspb.Envelope{
+		Type:    TypeCartCleared,
+		Payload: &eventspb.Envelope_CartItemAdded{CartItemAdded: &eventspb.CartItemAdded{}},
+	}, ContentTypeProtobuf)
+	assert.NoError(t, err)
+
+	_, err = Unmarshal(ContentTypeProtobuf, data)
+	assert.Error(t, err)
+
+	_, err = Unmarshal("text/plain", data)
+	assert.Error(t, err)
+}
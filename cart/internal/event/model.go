@@ -1,23 +1,23 @@
 package event
 
-type KafkaMessage struct {
-	Type      string      `json:"type"`
-	Service   string      `json:"service"`
-	Timestamp string      `json:"timestamp"`
-	Payload   interface{} `json:"payload"`
-}
-
-type CartItemAddedPayload struct {
-	CartID string `json:"cartId"`
-	SKU    string `json:"sku"`
-	Count  int    `json:"count"`
-	Status string `json:"status"`
-}
+// Event types are the names of the payload fields of eventspb.Envelope.
+const (
+	TypeCartItemAdded   = "cart_item_added"
+	TypeCartItemFailed  = "cart_item_failed"
+	TypeCartItemRemoved = "cart_item_removed"
+	TypeCartCleared     = "cart_cleared"
+	TypeOrderCreated    = "order_created"
+	TypeCartAbandoned   = "cart_abandoned"
+)
 
-type CartItemFailedPayload struct {
-	CartID string `json:"cartId"`
-	SKU    string `json:"sku"`
-	Count  int    `json:"count"`
-	Status string `json:"status"`
-	Reason string `json:"reason"`
+// SchemaVersions holds the current payload schema version of every event the
+// service publishes. A version is bumped whenever a payload changes in a way
+// consumers cannot ignore: a field removed, renamed or given a new meaning.
+var SchemaVersions = map[string]int32{
+	TypeCartItemAdded:   1,
+	TypeCartItemFailed:  1,
+	TypeCartItemRemoved: 1,
+	TypeCartCleared:     1,
+	TypeOrderCreated:    1,
+	TypeCartAbandoned:   1,
 }
@@ -0,0 +1,29 @@
+// Package guest issues the opaque tokens that identify guest carts. Only a
+// hash of a token is stored, so a leaked database does not hand out carts.
+package guest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+const tokenBytes = 32
+
+// NewToken returns a random cart token and the hash to store for it.
+func NewToken() (string, []byte, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("failed to generate cart token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return token, Hash(token), nil
+}
+
+func Hash(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
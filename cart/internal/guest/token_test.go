@@ -0,0 +1,26 @@
+package guest_test
+
+import (
+	"cart/internal/guest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewToken(t *testing.T) {
+	t.Parallel()
+
+	token, hash, err := guest.NewToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other, _, err := guest.NewToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.NotEqual(t, token, other)
+	assert.Equal(t, guest.Hash(token), hash)
+	assert.NotEqual(t, guest.Hash(other), hash)
+}
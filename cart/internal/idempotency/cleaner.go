@@ -0,0 +1,50 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"cart/internal/log"
+)
+
+type Cleaner struct {
+	store    Store
+	interval time.Duration
+	logger   log.Logger
+}
+
+func NewCleaner(store Store, interval time.Duration, logger log.Logger) *Cleaner {
+	return &Cleaner{
+		store:    store,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run deletes expired idempotency keys every interval until ctx is cancelled.
+func (c *Cleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Idempotency key cleaner stopped")
+			return
+		case <-ticker.C:
+			c.clean(ctx)
+		}
+	}
+}
+
+func (c *Cleaner) clean(ctx context.Context) {
+	deleted, err := c.store.DeleteExpired(ctx)
+	if err != nil {
+		c.logger.Error("failed to delete expired idempotency keys", log.Error(err))
+		return
+	}
+
+	if deleted > 0 {
+		c.logger.Info("Expired idempotency keys deleted", log.Int64("count", deleted))
+	}
+}
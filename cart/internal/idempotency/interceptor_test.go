@@ -0,0 +1,294 @@
+package idempotency_test
+
+import (
+	"cart/internal/auth"
+	"cart/internal/idempotency"
+	"cart/internal/log/zap"
+	"cart/internal/models"
+	cartpb "cart/pkg/api/cart"
+	"context"
+	stdErr "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const addItem = "/cart.CartService/AddItem"
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]models.IdempotencyRecord
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]models.IdempotencyRecord{}}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, scope, key string, requestHash []byte, _ time.Duration) (models.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[scope+"/"+key]; ok {
+		return record, false, nil
+	}
+
+	record := models.IdempotencyRecord{RequestHash: requestHash}
+	s.records[scope+"/"+key] = record
+
+	return record, true, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, scope, key, responseType string, response []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.records[scope+"/"+key]
+	record.ResponseType = responseType
+	record.Response = response
+	s.records[scope+"/"+key] = record
+
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, scope, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, scope+"/"+key)
+
+	return nil
+}
+
+func (s *memoryStore) DeleteExpired(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func callContext(userID int64, key string) context.Context {
+	ctx := auth.NewContext(context.Background(), auth.Identity{UserID: userID})
+	if key == "" {
+		return ctx
+	}
+
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(idempotency.Header, key))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	req := &cartpb.AddItemRequest{Sku: "100", Count: 2}
+	info := &grpc.UnaryServerInfo{FullMethod: addItem}
+
+	type call struct {
+		ctx      context.Context
+		req      proto.Message
+		method   string
+		fail     bool
+		wantCode codes.Code
+	}
+
+	tests := []struct {
+		name      string
+		calls     []call
+		wantCalls int
+	}{
+		{
+			name: "retry is replayed",
+			calls: []call{
+				{ctx: callContext(1, "k1"), req: req},
+				{ctx: callContext(1, "k1"), req: req},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "key reused with a different request",
+			calls: []call{
+				{ctx: callContext(1, "k1"), req: req},
+				{ctx: callContext(1, "k1"), req: &cartpb.AddItemRequest{Sku: "100", Count: 3}, wantCode: codes.InvalidArgument},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "key reused on another method",
+			calls: []call{
+				{ctx: callContext(1, "k1"), req: req},
+				{ctx: callContext(1, "k1"), req: req, method: "/cart.CartService/DeleteItem", wantCode: codes.InvalidArgument},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "keys belong to the caller",
+			calls: []call{
+				{ctx: callContext(1, "k1"), req: req},
+				{ctx: callContext(2, "k1"), req: req},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "failed requests may be retried",
+			calls: []call{
+				{ctx: callContext(1, "k1"), req: req, fail: true, wantCode: codes.FailedPrecondition},
+				{ctx: callContext(1, "k1"), req: req},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "without a key every call runs",
+			calls: []call{
+				{ctx: callContext(1, ""), req: req},
+				{ctx: callContext(1, ""), req: req},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "key too long",
+			calls: []call{
+				{ctx: callContext(1, string(make([]byte, 256))), req: req, wantCode: codes.InvalidArgument},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			interceptor := idempotency.UnaryServerInterceptor(newMemoryStore(), time.Hour, logger, addItem, "/cart.CartService/DeleteItem")
+
+			calls := 0
+
+			for i, c := range tt.calls {
+				callInfo := info
+				if c.method != "" {
+					callInfo = &grpc.UnaryServerInfo{FullMethod: c.method}
+				}
+
+				resp, err := interceptor(c.ctx, c.req, callInfo, func(context.Context, interface{}) (interface{}, error) {
+					calls++
+
+					if c.fail {
+						return nil, status.Error(codes.FailedPrecondition, "out of stock")
+					}
+
+					return &cartpb.CartResponse{Message: "Item added successfully"}, nil
+				})
+
+				if c.wantCode != codes.OK {
+					assert.Equal(t, c.wantCode, status.Code(err), "call %d", i)
+					continue
+				}
+
+				if err != nil {
+					t.Fatalf("call %d: unexpected error: %v", i, err)
+				}
+
+				assert.True(t, proto.Equal(&cartpb.CartResponse{Message: "Item added successfully"}, resp.(proto.Message)), "call %d", i)
+			}
+
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_InProgress(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	store := newMemoryStore()
+	interceptor := idempotency.UnaryServerInterceptor(store, time.Hour, logger, addItem)
+	info := &grpc.UnaryServerInfo{FullMethod: addItem}
+	req := &cartpb.AddItemRequest{Sku: "100", Count: 2}
+
+	_, err = interceptor(callContext(1, "k1"), req, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		// A retry arrives while the first request is still running.
+		_, err := interceptor(callContext(1, "k1"), req, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, stdErr.New("must not run")
+		})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+
+		return &cartpb.CartResponse{}, nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUnaryServerInterceptor_ClientGone(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	info := &grpc.UnaryServerInfo{FullMethod: addItem}
+	req := &cartpb.AddItemRequest{Sku: "100", Count: 2}
+
+	tests := []struct {
+		name      string
+		fail      bool
+		wantCalls int
+	}{
+		{name: "failed request is run again", fail: true, wantCalls: 2},
+		{name: "completed request is replayed", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			interceptor := idempotency.UnaryServerInterceptor(newMemoryStore(), time.Hour, logger, addItem)
+			calls := 0
+
+			// The client times out while the first call runs, then retries.
+			ctx, cancel := context.WithCancel(callContext(1, "k1"))
+			_, _ = interceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+				calls++
+				cancel()
+
+				if tt.fail {
+					return nil, status.Error(codes.DeadlineExceeded, "context canceled")
+				}
+
+				return &cartpb.CartResponse{Message: "Item added successfully"}, nil
+			})
+
+			resp, err := interceptor(callContext(1, "k1"), req, info, func(context.Context, interface{}) (interface{}, error) {
+				calls++
+
+				return &cartpb.CartResponse{Message: "Item added successfully"}, nil
+			})
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.True(t, proto.Equal(&cartpb.CartResponse{Message: "Item added successfully"}, resp.(proto.Message)))
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// traceHeaders returns the W3C trace context of ctx (traceparent, tracestate,
+// baggage) as Kafka record headers.
+func traceHeaders(ctx context.Context) []sarama.RecordHeader {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	keys := carrier.Keys()
+	sort.Strings(keys)
+
+	headers := make([]sarama.RecordHeader, 0, len(keys))
+	for _, key := range keys {
+		headers = append(headers, sarama.RecordHeader{
+			Key:   []byte(key),
+			Value: []byte(carrier[key]),
+		})
+	}
+
+	return headers
+}
+
+// headerCarrier lets the OpenTelemetry propagator read trace context from
+// consumed Kafka record headers.
+type headerCarrier []*sarama.RecordHeader
+
+func (c headerCarrier) Get(key string) string {
+	for _, h := range c {
+		if h != nil && string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set is a no-op: consumed headers are read-only.
+func (c headerCarrier) Set(string, string) {}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for _, h := range c {
+		if h != nil {
+			keys = append(keys, string(h.Key))
+		}
+	}
+
+	return keys
+}
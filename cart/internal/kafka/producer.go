@@ -2,9 +2,7 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -12,63 +10,150 @@ import (
 
 	"cart/internal/event"
 	"cart/internal/log"
+	"cart/internal/metrics"
+	"cart/internal/models"
+	"cart/internal/money"
+	eventspb "cart/pkg/api/events"
 
 	"github.com/IBM/sarama"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-const maxProducerRetry = 5
+const (
+	maxProducerRetry = 5
 
+	DefaultBatchSize    = 100
+	DefaultLinger       = 10 * time.Millisecond
+	DefaultQueueSize    = 10000
+	DefaultFlushTimeout = 10 * time.Second
+)
+
+// ProducerMode says whether publishing an event waits for Kafka.
+type ProducerMode string
+
+const (
+	// ProducerModeSync returns once Kafka acknowledged the event.
+	ProducerModeSync ProducerMode = "sync"
+	// ProducerModeAsync returns once the event is queued; delivery is
+	// reported to the DeliveryFuncs and the producer metrics.
+	ProducerModeAsync ProducerMode = "async"
+)
+
+// ContentType is event.ContentTypeProtobuf or event.ContentTypeJSON.
+// Idempotent makes the broker drop the duplicates retries would write; it
+// needs RequiredAcks to be sarama.WaitForAll.
 type ProducerConfig struct {
-	Brokers   []string
-	Topic     string
-	Partition int32
-	Service   string
+	Brokers      []string
+	Topic        string
+	Service      string
+	ContentType  string
+	RequiredAcks sarama.RequiredAcks
+	Idempotent   bool
+	Mode         ProducerMode
+	Async        AsyncConfig
 }
 
+// AsyncConfig tunes the async mode. A batch is sent once it holds BatchSize
+// events or Linger passed since its first one; at most QueueSize events wait
+// for a batch, and Backpressure decides what happens to the next one.
+// FlushTimeout bounds how long shutdown waits for queued events.
+type AsyncConfig struct {
+	BatchSize    int
+	Linger       time.Duration
+	QueueSize    int
+	Backpressure BackpressurePolicy
+	FlushTimeout time.Duration
+}
+
+// Producer publishes cart events keyed by cart, so the events of one cart
+// land on one partition and are consumed in order.
 type Producer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	partition int32
-	service   string
-	logger    log.Logger
+	sender      sender
+	topic       string
+	service     string
+	contentType string
+	logger      log.Logger
+	metrics     *metrics.ProducerMetrics
+	onDelivery  []DeliveryFunc
 }
 
-func NewProducer(cfg *ProducerConfig, logger log.Logger) (*Producer, error) {
-	config := sarama.NewConfig()
-	config.Producer.RequiredAcks = sarama.NoResponse
-	config.Producer.Return.Successes = true
-	config.Producer.Retry.Max = maxProducerRetry
-	config.Producer.Partitioner = func(topic string) sarama.Partitioner {
-		return sarama.NewManualPartitioner(topic)
+func NewProducer(cfg *ProducerConfig, logger log.Logger, m *metrics.ProducerMetrics, onDelivery ...DeliveryFunc) (*Producer, error) {
+	p := &Producer{
+		topic:       cfg.Topic,
+		service:     cfg.Service,
+		contentType: cfg.ContentType,
+		logger:      logger,
+		metrics:     m,
+		onDelivery:  onDelivery,
 	}
 
-	producer, err := sarama.NewSyncProducer(cfg.Brokers, config)
-	if err != nil {
-		logger.Error("failed to create Kafka producer", log.Error(err))
-		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+	config := newSaramaConfig(cfg)
+
+	if cfg.Mode == ProducerModeAsync {
+		producer, err := sarama.NewAsyncProducer(cfg.Brokers, config)
+		if err != nil {
+			logger.Error("failed to create Kafka producer", log.Error(err))
+			return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+		}
+
+		p.sender = newAsyncSender(producer, cfg.Async.QueueSize, cfg.Async.Backpressure, p.report, m)
+	} else {
+		producer, err := sarama.NewSyncProducer(cfg.Brokers, config)
+		if err != nil {
+			logger.Error("failed to create Kafka producer", log.Error(err))
+			return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+		}
+
+		p.sender = &syncSender{producer: producer, report: p.report}
 	}
 
 	logger.Info("Kafka producer created",
 		log.Strings("brokers", cfg.Brokers),
 		log.String("topic", cfg.Topic),
-		log.Int32("partition", cfg.Partition),
 		log.String("service", cfg.Service),
+		log.Int("required_acks", int(cfg.RequiredAcks)),
+		log.Bool("idempotent", cfg.Idempotent),
+		log.String("content_type", cfg.ContentType),
+		log.String("mode", string(cfg.Mode)),
 	)
 
-	return &Producer{
-		producer:  producer,
-		topic:     cfg.Topic,
-		partition: cfg.Partition,
-		service:   cfg.Service,
-		logger:    logger,
-	}, nil
+	return p, nil
+}
+
+// newSaramaConfig spreads messages over all partitions of the topic by the
+// hash of their key.
+func newSaramaConfig(cfg *ProducerConfig) *sarama.Config {
+	config := sarama.NewConfig()
+	// Idempotent producing needs Kafka 0.11 or newer.
+	config.Version = sarama.V2_8_0_0
+	config.Producer.RequiredAcks = cfg.RequiredAcks
+	config.Producer.Idempotent = cfg.Idempotent
+	config.Producer.Return.Successes = true
+	config.Producer.Retry.Max = maxProducerRetry
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+
+	if cfg.Mode == ProducerModeAsync {
+		config.Producer.Flush.Messages = cfg.Async.BatchSize
+		config.Producer.Flush.Frequency = cfg.Async.Linger
+	}
+
+	if cfg.Idempotent {
+		// With more requests in flight a retried batch could overtake the
+		// next one and break the order of a key.
+		config.Net.MaxOpenRequests = 1
+	}
+
+	return config
 }
 
 func (p *Producer) SendCartItemAdded(ctx context.Context, cartId, sku string, count int, status string) error {
 	tr := otel.Tracer("kafka-producer")
-	ctx, span := tr.Start(ctx, "SendCartItemAdded")
+	ctx, span := tr.Start(ctx, "SendCartItemAdded", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
@@ -78,12 +163,12 @@ func (p *Producer) SendCartItemAdded(ctx context.Context, cartId, sku string, co
 		attribute.String("status", status),
 	)
 
-	payload := event.CartItemAddedPayload{
-		CartID: cartId,
-		SKU:    sku,
-		Count:  count,
+	payload := &eventspb.Envelope_CartItemAdded{CartItemAdded: &eventspb.CartItemAdded{
+		CartId: cartId,
+		Sku:    sku,
+		Count:  int32(count),
 		Status: status,
-	}
+	}}
 
 	p.logger.Info("Sending cart_item_added event",
 		log.String("cart_id", cartId),
@@ -92,12 +177,12 @@ func (p *Producer) SendCartItemAdded(ctx context.Context, cartId, sku string, co
 		log.String("status", status),
 	)
 
-	return p.send(ctx, "cart_item_added", payload)
+	return p.send(ctx, cartId, &eventspb.Envelope{Payload: payload})
 }
 
 func (p *Producer) SendCartItemFailed(ctx context.Context, cartId, sku string, count int, status, reason string) error {
 	tr := otel.Tracer("kafka-producer")
-	ctx, span := tr.Start(ctx, "SendCartItemFailed")
+	ctx, span := tr.Start(ctx, "SendCartItemFailed", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
@@ -108,13 +193,13 @@ func (p *Producer) SendCartItemFailed(ctx context.Context, cartId, sku string, c
 		attribute.String("reason", reason),
 	)
 
-	payload := event.CartItemFailedPayload{
-		CartID: cartId,
-		SKU:    sku,
-		Count:  count,
+	payload := &eventspb.Envelope_CartItemFailed{CartItemFailed: &eventspb.CartItemFailed{
+		CartId: cartId,
+		Sku:    sku,
+		Count:  int32(count),
 		Status: status,
 		Reason: reason,
-	}
+	}}
 
 	p.logger.Info("Sending cart_item_failed event",
 		log.String("cart_id", cartId),
@@ -124,53 +209,287 @@ func (p *Producer) SendCartItemFailed(ctx context.Context, cartId, sku string, c
 		log.String("reason", reason),
 	)
 
-	return p.send(ctx, "cart_item_failed", payload)
+	return p.send(ctx, cartId, &eventspb.Envelope{Payload: payload})
+}
+
+func (p *Producer) SendCartItemRemoved(ctx context.Context, cartId, sku string, count int) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, "SendCartItemRemoved", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cart_id", cartId),
+		attribute.String("sku", sku),
+		attribute.Int("count", count),
+	)
+
+	payload := &eventspb.Envelope_CartItemRemoved{CartItemRemoved: &eventspb.CartItemRemoved{
+		CartId: cartId,
+		Sku:    sku,
+		Count:  int32(count),
+	}}
+
+	p.logger.Info("Sending cart_item_removed event",
+		log.String("cart_id", cartId),
+		log.String("sku", sku),
+		log.Int("count", count),
+	)
+
+	return p.send(ctx, cartId, &eventspb.Envelope{Payload: payload})
+}
+
+// SendCartCleared publishes the lines a cart held when it was emptied.
+func (p *Producer) SendCartCleared(ctx context.Context, cartID int64, items []models.CartItem, reason models.ClearReason) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, "SendCartCleared", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	id := strconv.FormatInt(cartID, 10)
+
+	span.SetAttributes(
+		attribute.String("cart_id", id),
+		attribute.Int("items_count", len(items)),
+		attribute.String("reason", string(reason)),
+	)
+
+	payload := &eventspb.Envelope_CartCleared{CartCleared: &eventspb.CartCleared{
+		CartId: id,
+		Items:  cartLines(items),
+		Reason: string(reason),
+	}}
+
+	p.logger.Info("Sending cart_cleared event",
+		log.String("cart_id", id),
+		log.Int("items_count", len(items)),
+		log.String("reason", string(reason)),
+	)
+
+	return p.send(ctx, id, &eventspb.Envelope{Payload: payload})
+}
+
+func (p *Producer) SendOrderCreated(ctx context.Context, order models.Order) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, "SendOrderCreated", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	orderID := strconv.FormatInt(order.ID, 10)
+	cartID := strconv.FormatInt(order.UserID, 10)
+
+	span.SetAttributes(
+		attribute.String("order_id", orderID),
+		attribute.String("cart_id", cartID),
+		attribute.Int("items_count", len(order.Items)),
+		attribute.String("total_price", order.TotalPrice.String()),
+	)
+
+	items := make([]*eventspb.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &eventspb.OrderItem{
+			Sku:       strconv.FormatUint(uint64(item.SKU), 10),
+			Count:     int32(item.Count),
+			Price:     item.Price.Float64(),
+			UnitPrice: money.ToProto(item.Price),
+		})
+	}
+
+	promoCodes := make([]string, 0, len(order.Promotions))
+	for _, applied := range order.Promotions {
+		promoCodes = append(promoCodes, applied.Promotion.Code)
+	}
+
+	payload := &eventspb.Envelope_OrderCreated{OrderCreated: &eventspb.OrderCreated{
+		OrderId:    orderID,
+		CartId:     cartID,
+		Items:      items,
+		TotalPrice: order.TotalPrice.Float64(),
+		Subtotal:   money.ToProto(order.Subtotal),
+		Discount:   money.ToProto(order.Discount),
+		PromoCodes: promoCodes,
+		Total:      money.ToProto(order.TotalPrice),
+		Status:     string(order.Status),
+	}}
+
+	p.logger.Info("Sending order_created event",
+		log.String("order_id", orderID),
+		log.String("cart_id", cartID),
+		log.Int("items_count", len(items)),
+		log.String("total_price", order.TotalPrice.String()),
+	)
+
+	return p.send(ctx, cartID, &eventspb.Envelope{Payload: payload})
+}
+
+func (p *Producer) SendCartAbandoned(ctx context.Context, cart models.AbandonedCart) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, "SendCartAbandoned", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	cartID := strconv.FormatInt(cart.UserID, 10)
+	lastActivityAt := cart.LastActivityAt.UTC().Format(time.RFC3339)
+
+	span.SetAttributes(
+		attribute.String("cart_id", cartID),
+		attribute.Int("items_count", len(cart.Items)),
+		attribute.String("last_activity_at", lastActivityAt),
+	)
+
+	items := cartLines(cart.Items)
+
+	payload := &eventspb.Envelope_CartAbandoned{CartAbandoned: &eventspb.CartAbandoned{
+		CartId:         cartID,
+		Items:          items,
+		LastActivityAt: timestamppb.New(cart.LastActivityAt),
+	}}
+
+	p.logger.Info("Sending cart_abandoned event",
+		log.String("cart_id", cartID),
+		log.Int("items_count", len(items)),
+		log.String("last_activity_at", lastActivityAt),
+	)
+
+	// Unlike the other events, cart_abandoned waits for Kafka in async mode
+	// too: the abandoned cart job gives its claim on the cart back when the
+	// event is not delivered.
+	msg, err := p.message(ctx, cartID, &eventspb.Envelope{Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	return p.sendAndWait(ctx, msg)
+}
+
+func cartLines(items []models.CartItem) []*eventspb.CartLine {
+	lines := make([]*eventspb.CartLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, &eventspb.CartLine{
+			Sku:   strconv.FormatUint(uint64(item.SKU), 10),
+			Count: int32(item.Count),
+		})
+	}
+
+	return lines
+}
+
+// send fills in the envelope of an event whose payload is set and publishes
+// it encoded as the configured content type. key picks the partition.
+func (p *Producer) send(ctx context.Context, key string, env *eventspb.Envelope) error {
+	msg, err := p.message(ctx, key, env)
+	if err != nil {
+		return err
+	}
+
+	if err := p.sender.send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send Kafka message: %w", err)
+	}
+
+	return nil
 }
 
-func (p *Producer) send(ctx context.Context, eventType string, payload interface{}) error {
-	msg := event.KafkaMessage{
-		Type:      eventType,
-		Service:   p.service,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Payload:   payload,
+// sendAndWait publishes msg and returns once it was delivered or failed, in
+// either mode.
+func (p *Producer) sendAndWait(ctx context.Context, msg *sarama.ProducerMessage) error {
+	result := make(chan error, 1)
+	msg.Metadata.(*delivery).result = result
+
+	if err := p.sender.send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send Kafka message: %w", err)
+	}
+
+	select {
+	case err := <-result:
+		if err != nil {
+			return fmt.Errorf("failed to deliver Kafka message: %w", err)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("Kafka message not acknowledged yet: %w", ctx.Err())
 	}
+}
+
+// message encodes an event into the message send publishes.
+func (p *Producer) message(ctx context.Context, key string, env *eventspb.Envelope) (*sarama.ProducerMessage, error) {
+	eventType := event.TypeOf(env)
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	env.EventId = uuid.NewString()
+	env.Type = eventType
+	env.SchemaVersion = event.SchemaVersions[eventType]
+	env.Source = p.service
+	env.OccurredAt = timestamppb.Now()
+	env.TraceContext = carrier
 
-	valueBytes, err := json.Marshal(msg)
+	valueBytes, err := event.Marshal(env, p.contentType)
 	if err != nil {
 		p.logger.Error("Failed to marshal Kafka message",
 			log.String("event_type", eventType),
 			log.Error(err),
 		)
-		return fmt.Errorf("failed to marshal Kafka message: %w", err)
+		return nil, fmt.Errorf("failed to marshal Kafka message: %w", err)
 	}
 
-	producerMsg := &sarama.ProducerMessage{
-		Topic:     p.topic,
-		Partition: p.partition,
-		Value:     sarama.ByteEncoder(valueBytes),
-		Key:       sarama.StringEncoder(fmt.Sprintf("%s-%d", p.service, time.Now().UnixNano())),
+	headers := append(traceHeaders(ctx), sarama.RecordHeader{
+		Key:   []byte(event.HeaderContentType),
+		Value: []byte(p.contentType),
+	})
+
+	return &sarama.ProducerMessage{
+		Topic:    p.topic,
+		Value:    sarama.ByteEncoder(valueBytes),
+		Key:      sarama.StringEncoder(key),
+		Headers:  headers,
+		Metadata: &delivery{eventType: eventType, sentAt: time.Now()},
+	}, nil
+}
+
+// report records the outcome of a message the sender accepted.
+func (p *Producer) report(msg *sarama.ProducerMessage, err error) {
+	d, _ := msg.Metadata.(*delivery)
+	if d == nil {
+		d = &delivery{}
 	}
 
-	partition, offset, err := p.producer.SendMessage(producerMsg)
 	if err != nil {
 		p.logger.Error("Failed to send Kafka message",
-			log.String("event_type", eventType),
+			log.String("event_type", d.eventType),
 			log.Error(err),
 		)
-		return fmt.Errorf("failed to send Kafka message: %w", err)
+
+		if p.metrics != nil {
+			p.metrics.DeliveryErrors.WithLabelValues(d.eventType).Inc()
+		}
+	} else {
+		p.logger.Info("Kafka message sent successfully",
+			log.String("event_type", d.eventType),
+			log.Int32("partition", msg.Partition),
+			log.Int64("offset", msg.Offset),
+		)
+
+		if p.metrics != nil {
+			p.metrics.Delivered.WithLabelValues(d.eventType).Inc()
+			p.metrics.DeliveryDuration.Observe(time.Since(d.sentAt).Seconds())
+		}
 	}
 
-	p.logger.Info("Kafka message sent successfully",
-		log.String("event_type", eventType),
-		log.Int32("partition", partition),
-		log.Int64("offset", offset),
-	)
+	for _, fn := range p.onDelivery {
+		fn(d.eventType, err)
+	}
 
-	return nil
+	if d.result != nil {
+		d.result <- err
+	}
+}
+
+// Flush waits until every event published so far was delivered or failed.
+func (p *Producer) Flush(ctx context.Context) error {
+	return p.sender.flush(ctx)
 }
 
+// Close delivers the queued events and releases the producer.
 func (p *Producer) Close() error {
-	err := p.producer.Close()
+	err := p.sender.close()
 	if err != nil {
 		p.logger.Error("failed to close Kafka producer", log.Error(err))
 	} else {
@@ -191,30 +510,126 @@ func NewProducerConfigFromEnv() (*ProducerConfig, error) {
 		topic = "metrics"
 	}
 
-	partitionStr := os.Getenv("PARTITION")
-	if partitionStr == "" {
-		partitionStr = "0"
+	service := os.Getenv("SERVICE_NAME")
+	if service == "" {
+		service = "cart-service"
+	}
+
+	contentType, err := event.ContentType(os.Getenv("KAFKA_EVENT_ENCODING"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KAFKA_EVENT_ENCODING: %w", err)
 	}
 
-	partitionInt, err := strconv.Atoi(partitionStr)
+	acks, err := parseRequiredAcks(os.Getenv("KAFKA_REQUIRED_ACKS"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse PARTITION: %w", err)
+		return nil, fmt.Errorf("failed to parse KAFKA_REQUIRED_ACKS: %w", err)
 	}
-	if partitionInt < math.MinInt32 || partitionInt > math.MaxInt32 {
-		return nil, fmt.Errorf("partition value %d out of int32 range", partitionInt)
+
+	idempotent := true
+	if idempotentStr := os.Getenv("KAFKA_IDEMPOTENT"); idempotentStr != "" {
+		if idempotent, err = strconv.ParseBool(idempotentStr); err != nil {
+			return nil, fmt.Errorf("failed to parse KAFKA_IDEMPOTENT: %w", err)
+		}
 	}
 
-	partition := int32(partitionInt)
+	if idempotent && acks != sarama.WaitForAll {
+		return nil, fmt.Errorf("KAFKA_IDEMPOTENT needs KAFKA_REQUIRED_ACKS=all")
+	}
 
-	service := os.Getenv("SERVICE_NAME")
-	if service == "" {
-		service = "cart-service"
+	mode := ProducerMode(os.Getenv("KAFKA_PRODUCER_MODE"))
+	switch mode {
+	case "":
+		mode = ProducerModeAsync
+	case ProducerModeSync, ProducerModeAsync:
+	default:
+		return nil, fmt.Errorf("unknown KAFKA_PRODUCER_MODE %q, want sync or async", mode)
+	}
+
+	async, err := loadAsyncConfig()
+	if err != nil {
+		return nil, err
 	}
 
 	return &ProducerConfig{
-		Brokers:   brokers,
-		Topic:     topic,
-		Partition: int32(partition),
-		Service:   service,
+		Brokers:      brokers,
+		Topic:        topic,
+		Service:      service,
+		ContentType:  contentType,
+		RequiredAcks: acks,
+		Idempotent:   idempotent,
+		Mode:         mode,
+		Async:        async,
 	}, nil
 }
+
+func loadAsyncConfig() (AsyncConfig, error) {
+	cfg := AsyncConfig{
+		BatchSize:    DefaultBatchSize,
+		Linger:       DefaultLinger,
+		QueueSize:    DefaultQueueSize,
+		Backpressure: BackpressureBlock,
+		FlushTimeout: DefaultFlushTimeout,
+	}
+
+	if v := os.Getenv("KAFKA_BATCH_SIZE"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 {
+			return cfg, fmt.Errorf("KAFKA_BATCH_SIZE must be a positive integer, got %q", v)
+		}
+
+		cfg.BatchSize = size
+	}
+
+	if v := os.Getenv("KAFKA_LINGER"); v != "" {
+		linger, err := time.ParseDuration(v)
+		if err != nil || linger <= 0 {
+			return cfg, fmt.Errorf("KAFKA_LINGER must be a positive duration, got %q", v)
+		}
+
+		cfg.Linger = linger
+	}
+
+	if v := os.Getenv("KAFKA_QUEUE_SIZE"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 {
+			return cfg, fmt.Errorf("KAFKA_QUEUE_SIZE must be a positive integer, got %q", v)
+		}
+
+		cfg.QueueSize = size
+	}
+
+	if v := os.Getenv("KAFKA_BACKPRESSURE"); v != "" {
+		switch policy := BackpressurePolicy(v); policy {
+		case BackpressureBlock, BackpressureDropOldest, BackpressureFail:
+			cfg.Backpressure = policy
+		default:
+			return cfg, fmt.Errorf("unknown KAFKA_BACKPRESSURE %q, want block, drop-oldest or fail", v)
+		}
+	}
+
+	if v := os.Getenv("KAFKA_FLUSH_TIMEOUT"); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			return cfg, fmt.Errorf("KAFKA_FLUSH_TIMEOUT must be a positive duration, got %q", v)
+		}
+
+		cfg.FlushTimeout = timeout
+	}
+
+	return cfg, nil
+}
+
+// parseRequiredAcks reads how many replicas must confirm a write: none,
+// leader or all (the default).
+func parseRequiredAcks(s string) (sarama.RequiredAcks, error) {
+	switch s {
+	case "", "all":
+		return sarama.WaitForAll, nil
+	case "leader":
+		return sarama.WaitForLocal, nil
+	case "none":
+		return sarama.NoResponse, nil
+	default:
+		return 0, fmt.Errorf("unknown acks %q, want none, leader or all", s)
+	}
+}
@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"cart/internal/models"
 	"context"
 )
 
@@ -9,5 +10,9 @@ import (
 type ProducerInterface interface {
 	SendCartItemAdded(ctx context.Context, cartId, sku string, count int, status string) error
 	SendCartItemFailed(ctx context.Context, cartId, sku string, count int, status, reason string) error
+	SendCartItemRemoved(ctx context.Context, cartId, sku string, count int) error
+	SendCartCleared(ctx context.Context, cartID int64, items []models.CartItem, reason models.ClearReason) error
+	SendOrderCreated(ctx context.Context, order models.Order) error
+	SendCartAbandoned(ctx context.Context, cart models.AbandonedCart) error
 	Close() error
 }
@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProducerConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name           string
+		acks           string
+		idempotent     string
+		wantAcks       sarama.RequiredAcks
+		wantIdempotent bool
+		wantErr        bool
+	}{
+		{
+			name:           "defaults",
+			wantAcks:       sarama.WaitForAll,
+			wantIdempotent: true,
+		},
+		{
+			name:       "leader acks",
+			acks:       "leader",
+			idempotent: "false",
+			wantAcks:   sarama.WaitForLocal,
+		},
+		{
+			name:       "no acks",
+			acks:       "none",
+			idempotent: "false",
+			wantAcks:   sarama.NoResponse,
+		},
+		{
+			name:    "idempotent without all acks",
+			acks:    "leader",
+			wantErr: true,
+		},
+		{
+			name:    "unknown acks",
+			acks:    "some",
+			wantErr: true,
+		},
+		{
+			name:       "invalid idempotent",
+			idempotent: "maybe",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_BROKERS", "kafka1:9092,kafka2:9092")
+			t.Setenv("KAFKA_REQUIRED_ACKS", tt.acks)
+			t.Setenv("KAFKA_IDEMPOTENT", tt.idempotent)
+
+			cfg, err := NewProducerConfigFromEnv()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.wantAcks, cfg.RequiredAcks)
+			assert.Equal(t, tt.wantIdempotent, cfg.Idempotent)
+			assert.NoError(t, newSaramaConfig(cfg).Validate(), "sarama accepts the config")
+		})
+	}
+}
+
+func TestNewProducerConfigFromEnv_Async(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    AsyncConfig
+		mode    ProducerMode
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			mode: ProducerModeAsync,
+			want: AsyncConfig{
+				BatchSize:    DefaultBatchSize,
+				Linger:       DefaultLinger,
+				QueueSize:    DefaultQueueSize,
+				Backpressure: BackpressureBlock,
+				FlushTimeout: DefaultFlushTimeout,
+			},
+		},
+		{
+			name: "custom",
+			env: map[string]string{
+				"KAFKA_PRODUCER_MODE": "sync",
+				"KAFKA_BATCH_SIZE":    "500",
+				"KAFKA_LINGER":        "50ms",
+				"KAFKA_QUEUE_SIZE":    "20",
+				"KAFKA_BACKPRESSURE":  "drop-oldest",
+				"KAFKA_FLUSH_TIMEOUT": "3s",
+			},
+			mode: ProducerModeSync,
+			want: AsyncConfig{
+				BatchSize:    500,
+				Linger:       50 * time.Millisecond,
+				QueueSize:    20,
+				Backpressure: BackpressureDropOldest,
+				FlushTimeout: 3 * time.Second,
+			},
+		},
+		{
+			name:    "unknown mode",
+			env:     map[string]string{"KAFKA_PRODUCER_MODE": "fast"},
+			wantErr: true,
+		},
+		{
+			name:    "zero batch size",
+			env:     map[string]string{"KAFKA_BATCH_SIZE": "0"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid linger",
+			env:     map[string]string{"KAFKA_LINGER": "soon"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown backpressure",
+			env:     map[string]string{"KAFKA_BACKPRESSURE": "drop-newest"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_BROKERS", "kafka1:9092")
+			for _, key := range []string{"KAFKA_PRODUCER_MODE", "KAFKA_BATCH_SIZE", "KAFKA_LINGER", "KAFKA_QUEUE_SIZE", "KAFKA_BACKPRESSURE", "KAFKA_FLUSH_TIMEOUT"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg, err := NewProducerConfigFromEnv()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.mode, cfg.Mode)
+			assert.Equal(t, tt.want, cfg.Async)
+
+			cfg.Mode = ProducerModeAsync
+			assert.NoError(t, newSaramaConfig(cfg).Validate(), "sarama accepts the async config")
+		})
+	}
+}
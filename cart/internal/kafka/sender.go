@@ -0,0 +1,280 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cart/internal/metrics"
+
+	"github.com/IBM/sarama"
+)
+
+// BackpressurePolicy decides what the async producer does with an event when
+// its queue is full.
+type BackpressurePolicy string
+
+const (
+	// BackpressureBlock waits for room for as long as the caller's context
+	// allows.
+	BackpressureBlock BackpressurePolicy = "block"
+	// BackpressureDropOldest discards the oldest queued event to make room.
+	BackpressureDropOldest BackpressurePolicy = "drop-oldest"
+	// BackpressureFail rejects the event with ErrQueueFull.
+	BackpressureFail BackpressurePolicy = "fail"
+)
+
+var (
+	ErrQueueFull      = errors.New("kafka producer queue is full")
+	ErrProducerClosed = errors.New("kafka producer is closed")
+	// ErrEventDropped is reported for the queued events BackpressureDropOldest
+	// discards.
+	ErrEventDropped = errors.New("event dropped from the full kafka producer queue")
+)
+
+// DeliveryFunc is called once for every event the producer accepted: with a
+// nil error once Kafka acknowledged it, or with the reason it was not
+// delivered. It runs on the producer's goroutines and must not block.
+type DeliveryFunc func(eventType string, err error)
+
+// delivery travels in the Metadata of a message until it is reported. result,
+// when set, receives the outcome of the message.
+type delivery struct {
+	eventType string
+	sentAt    time.Time
+	result    chan error
+}
+
+// sender hands encoded messages to Kafka and reports each one it accepted.
+type sender interface {
+	// send returns once msg is delivered, for the sync sender, or queued,
+	// for the async one.
+	send(ctx context.Context, msg *sarama.ProducerMessage) error
+	// flush waits until every accepted message was reported.
+	flush(ctx context.Context) error
+	close() error
+}
+
+type reportFunc func(msg *sarama.ProducerMessage, err error)
+
+type syncSender struct {
+	producer sarama.SyncProducer
+	report   reportFunc
+}
+
+func (s *syncSender) send(_ context.Context, msg *sarama.ProducerMessage) error {
+	_, _, err := s.producer.SendMessage(msg)
+	s.report(msg, err)
+
+	return err
+}
+
+func (s *syncSender) flush(context.Context) error {
+	return nil
+}
+
+func (s *syncSender) close() error {
+	return s.producer.Close()
+}
+
+// asyncSender queues messages in front of a sarama.AsyncProducer, which
+// batches them, and reports them as Kafka answers.
+type asyncSender struct {
+	producer sarama.AsyncProducer
+	queue    chan *sarama.ProducerMessage
+	policy   BackpressurePolicy
+	report   reportFunc
+	metrics  *metrics.ProducerMetrics
+
+	// Senders hold mu shared while they use the queue, so close cannot
+	// close it under them.
+	mu     sync.RWMutex
+	closed bool
+
+	// pending counts the accepted messages not reported yet; drained is
+	// closed whenever it drops to zero.
+	pendingMu sync.Mutex
+	pending   int
+	drained   chan struct{}
+
+	dispatched chan struct{}
+	collected  sync.WaitGroup
+}
+
+func newAsyncSender(producer sarama.AsyncProducer, queueSize int, policy BackpressurePolicy, report reportFunc, m *metrics.ProducerMetrics) *asyncSender {
+	s := &asyncSender{
+		producer:   producer,
+		queue:      make(chan *sarama.ProducerMessage, queueSize),
+		policy:     policy,
+		report:     report,
+		metrics:    m,
+		dispatched: make(chan struct{}),
+	}
+
+	s.collected.Add(2)
+
+	go s.dispatch()
+	go s.collectSuccesses()
+	go s.collectErrors()
+
+	return s
+}
+
+func (s *asyncSender) send(ctx context.Context, msg *sarama.ProducerMessage) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrProducerClosed
+	}
+
+	s.acquire()
+
+	switch s.policy {
+	case BackpressureFail:
+		select {
+		case s.queue <- msg:
+		default:
+			s.release()
+			s.dropped()
+
+			return ErrQueueFull
+		}
+	case BackpressureDropOldest:
+		for queued := false; !queued; {
+			select {
+			case s.queue <- msg:
+				queued = true
+			default:
+				select {
+				case oldest := <-s.queue:
+					s.dropped()
+					s.done(oldest, ErrEventDropped)
+				default:
+				}
+			}
+		}
+	default:
+		select {
+		case s.queue <- msg:
+		case <-ctx.Done():
+			s.release()
+			s.dropped()
+
+			return fmt.Errorf("%w: %w", ErrQueueFull, ctx.Err())
+		}
+	}
+
+	s.updateQueueLength()
+
+	return nil
+}
+
+func (s *asyncSender) flush(ctx context.Context) error {
+	s.pendingMu.Lock()
+	pending, drained := s.pending, s.drained
+	s.pendingMu.Unlock()
+
+	if pending == 0 {
+		return nil
+	}
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d events not delivered yet: %w", s.pendingCount(), ctx.Err())
+	}
+}
+
+// close stops taking messages and waits until the queued ones were sent and
+// reported.
+func (s *asyncSender) close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+
+	s.closed = true
+	close(s.queue)
+	s.mu.Unlock()
+
+	<-s.dispatched
+	s.producer.AsyncClose()
+	s.collected.Wait()
+
+	return nil
+}
+
+func (s *asyncSender) dispatch() {
+	defer close(s.dispatched)
+
+	for msg := range s.queue {
+		s.updateQueueLength()
+		s.producer.Input() <- msg
+	}
+}
+
+func (s *asyncSender) collectSuccesses() {
+	defer s.collected.Done()
+
+	for msg := range s.producer.Successes() {
+		s.done(msg, nil)
+	}
+}
+
+func (s *asyncSender) collectErrors() {
+	defer s.collected.Done()
+
+	for perr := range s.producer.Errors() {
+		s.done(perr.Msg, perr.Err)
+	}
+}
+
+func (s *asyncSender) done(msg *sarama.ProducerMessage, err error) {
+	s.report(msg, err)
+	s.release()
+}
+
+func (s *asyncSender) acquire() {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	if s.pending == 0 {
+		s.drained = make(chan struct{})
+	}
+
+	s.pending++
+}
+
+func (s *asyncSender) release() {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	s.pending--
+	if s.pending == 0 {
+		close(s.drained)
+	}
+}
+
+func (s *asyncSender) pendingCount() int {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	return s.pending
+}
+
+func (s *asyncSender) dropped() {
+	if s.metrics != nil {
+		s.metrics.Dropped.WithLabelValues(string(s.policy)).Inc()
+	}
+}
+
+func (s *asyncSender) updateQueueLength() {
+	if s.metrics != nil {
+		s.metrics.QueueLength.Set(float64(len(s.queue)))
+	}
+}
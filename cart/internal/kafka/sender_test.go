@@ -0,0 +1,240 @@
+package kafka
+
+import (
+	"context"
+	stdErr "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"cart/internal/event"
+	"cart/internal/log/zap"
+	"cart/internal/models"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+var errBroker = stdErr.New("broker rejected the message")
+
+// fakeAsyncProducer acks the messages read from Input once start is closed,
+// failing those keyed "bad" or with a key in bad.
+type fakeAsyncProducer struct {
+	sarama.AsyncProducer
+
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+	start     chan struct{}
+	bad       map[string]bool
+}
+
+func newFakeAsyncProducer() *fakeAsyncProducer {
+	f := &fakeAsyncProducer{
+		input:     make(chan *sarama.ProducerMessage),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+		start:     make(chan struct{}),
+		bad:       map[string]bool{"bad": true},
+	}
+
+	go func() {
+		defer close(f.successes)
+		defer close(f.errors)
+
+		<-f.start
+
+		for msg := range f.input {
+			if key, _ := msg.Key.Encode(); f.bad[string(key)] {
+				f.errors <- &sarama.ProducerError{Msg: msg, Err: errBroker}
+				continue
+			}
+
+			f.successes <- msg
+		}
+	}()
+
+	return f
+}
+
+func (f *fakeAsyncProducer) Input() chan<- *sarama.ProducerMessage     { return f.input }
+func (f *fakeAsyncProducer) Successes() <-chan *sarama.ProducerMessage { return f.successes }
+func (f *fakeAsyncProducer) Errors() <-chan *sarama.ProducerError      { return f.errors }
+func (f *fakeAsyncProducer) AsyncClose()                               { close(f.input) }
+
+// reports records what a sender reported, by message key.
+type reports struct {
+	mu      sync.Mutex
+	results map[string]error
+}
+
+func (r *reports) report(msg *sarama.ProducerMessage, err error) {
+	key, _ := msg.Key.Encode()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results[string(key)] = err
+}
+
+func (r *reports) get() map[string]error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make(map[string]error, len(r.results))
+	for k, v := range r.results {
+		results[k] = v
+	}
+
+	return results
+}
+
+func message(key string) *sarama.ProducerMessage {
+	return &sarama.ProducerMessage{Topic: "metrics", Key: sarama.StringEncoder(key)}
+}
+
+func TestProducer_Async(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	var (
+		mu        sync.Mutex
+		delivered []string
+		failed    []string
+	)
+
+	p := &Producer{
+		topic:       "metrics",
+		service:     "cart-service",
+		contentType: event.ContentTypeProtobuf,
+		logger:      logger,
+		onDelivery: []DeliveryFunc{func(eventType string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				failed = append(failed, eventType)
+			} else {
+				delivered = append(delivered, eventType)
+			}
+		}},
+	}
+
+	fake := newFakeAsyncProducer()
+	p.sender = newAsyncSender(fake, 10, BackpressureBlock, p.report, nil)
+	close(fake.start)
+
+	ctx := context.Background()
+	assert.NoError(t, p.SendCartItemAdded(ctx, "1", "1001", 2, "added"))
+	assert.NoError(t, p.SendCartItemRemoved(ctx, "bad", "1001", 2))
+
+	flushCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	assert.NoError(t, p.Flush(flushCtx))
+
+	mu.Lock()
+	assert.Equal(t, []string{event.TypeCartItemAdded}, delivered)
+	assert.Equal(t, []string{event.TypeCartItemRemoved}, failed)
+	mu.Unlock()
+
+	assert.NoError(t, p.Close())
+	assert.ErrorIs(t, p.SendCartItemAdded(ctx, "1", "1001", 1, "added"), ErrProducerClosed)
+}
+
+func TestProducer_Async_CartAbandonedWaitsForDelivery(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	p := &Producer{topic: "metrics", service: "cart-service", contentType: event.ContentTypeProtobuf, logger: logger}
+
+	fake := newFakeAsyncProducer()
+	fake.bad["13"] = true
+	p.sender = newAsyncSender(fake, 10, BackpressureBlock, p.report, nil)
+	t.Cleanup(func() { _ = p.Close() })
+
+	ctx := context.Background()
+	lastActivityAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	// Nothing is acknowledged until start is closed.
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.SendCartAbandoned(cancelled, models.AbandonedCart{UserID: 12, LastActivityAt: lastActivityAt}), context.DeadlineExceeded)
+
+	close(fake.start)
+
+	assert.NoError(t, p.SendCartAbandoned(ctx, models.AbandonedCart{UserID: 12, LastActivityAt: lastActivityAt}))
+	assert.ErrorIs(t, p.SendCartAbandoned(ctx, models.AbandonedCart{UserID: 13, LastActivityAt: lastActivityAt}), errBroker,
+		"a failed delivery reaches the abandoned cart job, which gives the claim back")
+}
+
+func TestAsyncSender_Backpressure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy  BackpressurePolicy
+		wantErr error
+		want    map[string]error
+	}{
+		{
+			policy:  BackpressureFail,
+			wantErr: ErrQueueFull,
+			want:    map[string]error{"1": nil, "2": nil},
+		},
+		{
+			policy: BackpressureDropOldest,
+			want:   map[string]error{"1": nil, "2": ErrEventDropped, "3": nil},
+		},
+		{
+			policy:  BackpressureBlock,
+			wantErr: context.DeadlineExceeded,
+			want:    map[string]error{"1": nil, "2": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			t.Parallel()
+
+			r := &reports{results: make(map[string]error)}
+			fake := newFakeAsyncProducer()
+			s := newAsyncSender(fake, 1, tt.policy, r.report, nil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			// 1 is taken by the dispatcher, which waits for the broker, and
+			// 2 fills the queue.
+			assert.NoError(t, s.send(ctx, message("1")))
+			assert.Eventually(t, func() bool { return len(s.queue) == 0 }, time.Second, time.Millisecond)
+			assert.NoError(t, s.send(ctx, message("2")))
+
+			err := s.send(ctx, message("3"))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			flushCtx, cancelFlush := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancelFlush()
+
+			assert.ErrorIs(t, s.flush(flushCtx), context.DeadlineExceeded, "nothing is delivered while the broker waits")
+
+			close(fake.start)
+			assert.NoError(t, s.close())
+			assert.Equal(t, tt.want, r.get())
+			assert.NoError(t, s.flush(context.Background()))
+		})
+	}
+}
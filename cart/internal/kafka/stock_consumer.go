@@ -0,0 +1,287 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"cart/internal/event"
+	"cart/internal/log"
+	"cart/internal/metrics"
+	eventspb "cart/pkg/api/events"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultStockEventsGroup = "cart-stock-cache"
+	stockConsumerRetryDelay = 5 * time.Second
+)
+
+// StockCache is what the stock events consumer keeps up to date.
+type StockCache interface {
+	Invalidate(skus ...uint32)
+	SetOnline(online bool)
+}
+
+type StockConsumerConfig struct {
+	Brokers []string
+	Topic   string
+	Group   string
+}
+
+// NewStockConsumerConfigFromEnv reads the stock events topic, which defaults
+// to the topic the stocks service publishes to. Every cart instance caches on
+// its own, so the default consumer group is per host: a group shared by the
+// instances would hand each of them only some of the partitions.
+func NewStockConsumerConfigFromEnv() (*StockConsumerConfig, error) {
+	brokersEnv := os.Getenv("KAFKA_BROKERS")
+	if brokersEnv == "" {
+		return nil, fmt.Errorf("KAFKA_BROKERS env var is not set")
+	}
+
+	topic := os.Getenv("STOCK_EVENTS_TOPIC")
+	if topic == "" {
+		topic = "metrics"
+	}
+
+	group := os.Getenv("STOCK_EVENTS_GROUP")
+	if group == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hostname: %w", err)
+		}
+
+		group = defaultStockEventsGroup + "-" + hostname
+	}
+
+	return &StockConsumerConfig{
+		Brokers: strings.Split(brokersEnv, ","),
+		Topic:   topic,
+		Group:   group,
+	}, nil
+}
+
+// StockConsumer invalidates cached stock items as the stocks service reports
+// changes to them.
+type StockConsumer struct {
+	cfg     *StockConsumerConfig
+	config  *sarama.Config
+	cache   StockCache
+	logger  log.Logger
+	metrics *metrics.StockCacheMetrics
+	tracer  trace.Tracer
+}
+
+func NewStockConsumer(cfg *StockConsumerConfig, cache StockCache, logger log.Logger, m *metrics.StockCacheMetrics) *StockConsumer {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_8_0_0
+	// The cache starts empty, so older events have nothing to invalidate.
+	config.Consumer.Offsets.Initial = sarama.OffsetNewest
+
+	return &StockConsumer{
+		cfg:     cfg,
+		config:  config,
+		cache:   cache,
+		logger:  logger,
+		metrics: m,
+		tracer:  otel.Tracer("cart-stock-consumer"),
+	}
+}
+
+// Run consumes stock events until ctx is done. Kafka being unavailable is
+// not fatal: the cache falls back to its offline TTL and Run reconnects.
+func (c *StockConsumer) Run(ctx context.Context) {
+	defer c.cache.SetOnline(false)
+
+	for {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			c.logger.Error("stock events consumer failed", log.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(stockConsumerRetryDelay):
+		}
+	}
+}
+
+func (c *StockConsumer) consume(ctx context.Context) error {
+	group, err := sarama.NewConsumerGroup(c.cfg.Brokers, c.cfg.Group, c.config)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	defer func() {
+		if err := group.Close(); err != nil {
+			c.logger.Error("failed to close stock events consumer group", log.Error(err))
+		}
+	}()
+
+	for {
+		// Consume returns on every rebalance.
+		if err := group.Consume(ctx, []string{c.cfg.Topic}, c); err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (c *StockConsumer) Setup(sarama.ConsumerGroupSession) error {
+	c.cache.SetOnline(true)
+	return nil
+}
+
+func (c *StockConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	c.cache.SetOnline(false)
+	return nil
+}
+
+func (c *StockConsumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		c.handle(sess.Context(), msg)
+		sess.MarkMessage(msg, "")
+	}
+
+	return nil
+}
+
+// handle never fails: an event that cannot be read has nothing the cache
+// could use, and the TTL still bounds the entry it was about.
+func (c *StockConsumer) handle(ctx context.Context, msg *sarama.ConsumerMessage) {
+	headers := headerCarrier(msg.Headers)
+	evt, decodeErr := event.Unmarshal(headers.Get(event.HeaderContentType), msg.Value)
+
+	ctx = extractTrace(ctx, headers, evt)
+	_, span := c.tracer.Start(ctx, "ConsumeStockEvent", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("kafka.topic", msg.Topic),
+		attribute.Int64("kafka.offset", msg.Offset),
+		attribute.Int("kafka.partition", int(msg.Partition)),
+	)
+
+	if decodeErr != nil {
+		c.logger.Error("failed to unmarshal stock event", log.Int64("offset", msg.Offset), log.Error(decodeErr))
+		span.RecordError(decodeErr)
+		span.SetStatus(codes.Error, "unmarshal failed")
+
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event.type", evt.GetType()),
+		attribute.String("event.id", evt.GetEventId()),
+	)
+
+	skus, err := affectedSKUs(evt)
+	if err != nil {
+		c.logger.Error("failed to read stock event",
+			log.String("event_type", evt.GetType()),
+			log.Int64("offset", msg.Offset),
+			log.Error(err),
+		)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid payload")
+
+		return
+	}
+
+	if len(skus) == 0 {
+		return
+	}
+
+	c.cache.Invalidate(skus...)
+
+	if c.metrics != nil {
+		c.metrics.EventsApplied.WithLabelValues(evt.GetType()).Inc()
+	}
+}
+
+// extractTrace continues the publisher's trace from the record headers, or
+// from the envelope when the headers carry none.
+func extractTrace(ctx context.Context, headers headerCarrier, evt *eventspb.Envelope) context.Context {
+	propagator := otel.GetTextMapPropagator()
+
+	extracted := propagator.Extract(ctx, headers)
+	if trace.SpanContextFromContext(extracted).IsValid() || evt == nil {
+		return extracted
+	}
+
+	return propagator.Extract(ctx, propagation.MapCarrier(evt.GetTraceContext()))
+}
+
+// affectedSKUs returns the SKUs whose cached item evt makes stale, or none
+// for events that are not about stock. The payloads are not applied to the
+// cached items: their counts are on hand in one location, while the cache
+// holds what is available across all of them.
+func affectedSKUs(evt *eventspb.Envelope) ([]uint32, error) {
+	var sku string
+
+	switch payload := evt.GetPayload().(type) {
+	case *eventspb.Envelope_SkuCreated:
+		sku = payload.SkuCreated.GetSku()
+	case *eventspb.Envelope_StockChanged:
+		sku = payload.StockChanged.GetSku()
+	case *eventspb.Envelope_StockDeleted:
+		sku = payload.StockDeleted.GetSku()
+	case *eventspb.Envelope_PriceChanged:
+		sku = payload.PriceChanged.GetSku()
+	case *eventspb.Envelope_CatalogSkuCreated:
+		sku = payload.CatalogSkuCreated.GetSku()
+	case *eventspb.Envelope_CatalogSkuUpdated:
+		sku = payload.CatalogSkuUpdated.GetSku()
+	case *eventspb.Envelope_CatalogSkuArchived:
+		sku = payload.CatalogSkuArchived.GetSku()
+	case *eventspb.Envelope_ReservationExpired:
+		items := payload.ReservationExpired.GetItems()
+
+		skus := make([]uint32, 0, len(items))
+		for _, item := range items {
+			sku, err := parseSKU(item.GetSku())
+			if err != nil {
+				return nil, err
+			}
+
+			skus = append(skus, sku)
+		}
+
+		return skus, nil
+	default:
+		return nil, nil
+	}
+
+	parsed, err := parseSKU(sku)
+	if err != nil {
+		return nil, err
+	}
+
+	return []uint32{parsed}, nil
+}
+
+func parseSKU(s string) (uint32, error) {
+	sku, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sku %q: %w", s, err)
+	}
+
+	return uint32(sku), nil
+}
@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"testing"
+
+	eventspb "cart/pkg/api/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffectedSKUs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		evt     *eventspb.Envelope
+		want    []uint32
+		wantErr bool
+	}{
+		{
+			name: "stock changed",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{
+				Sku: "1001", Count: 5,
+			}}},
+			want: []uint32{1001},
+		},
+		{
+			name: "sku created",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_SkuCreated{SkuCreated: &eventspb.SKUCreated{
+				Sku: "1002", Count: 5,
+			}}},
+			want: []uint32{1002},
+		},
+		{
+			name: "catalog sku archived",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_CatalogSkuArchived{CatalogSkuArchived: &eventspb.CatalogSKU{
+				Sku: "1003", Archived: true,
+			}}},
+			want: []uint32{1003},
+		},
+		{
+			name: "stock deleted",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_StockDeleted{StockDeleted: &eventspb.StockDeleted{
+				Sku: "1004", Location: "loc1", Count: 2,
+			}}},
+			want: []uint32{1004},
+		},
+		{
+			name: "price changed",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_PriceChanged{PriceChanged: &eventspb.PriceChanged{
+				Sku: "1005", Location: "loc1",
+			}}},
+			want: []uint32{1005},
+		},
+		{
+			name: "reservation expired",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_ReservationExpired{ReservationExpired: &eventspb.ReservationExpired{
+				ReservationId: "7",
+				Items:         []*eventspb.ReservationItem{{Sku: "1", Count: 1}, {Sku: "2", Count: 3}},
+			}}},
+			want: []uint32{1, 2},
+		},
+		{
+			name: "not a stock event",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_CartItemAdded{CartItemAdded: &eventspb.CartItemAdded{
+				CartId: "1", Sku: "1001",
+			}}},
+		},
+		{
+			name: "unknown event",
+			evt:  &eventspb.Envelope{Type: "sku_renamed"},
+		},
+		{
+			name: "invalid sku",
+			evt: &eventspb.Envelope{Payload: &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{
+				Sku: "abc",
+			}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			skus, err := affectedSKUs(tt.evt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, skus)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// ProducerMetrics describe the delivery of cart events to Kafka.
+type ProducerMetrics struct {
+	Delivered        *prometheus.CounterVec
+	DeliveryErrors   *prometheus.CounterVec
+	DeliveryDuration prometheus.Histogram
+	Dropped          *prometheus.CounterVec
+	QueueLength      prometheus.Gauge
+}
+
+func RegisterProducerMetrics() *ProducerMetrics {
+	m := &ProducerMetrics{
+		Delivered: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kafka_producer_delivered_total",
+				Help: "Total number of events acknowledged by Kafka, by event type",
+			},
+			[]string{"type"},
+		),
+		DeliveryErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kafka_producer_delivery_errors_total",
+				Help: "Total number of events that could not be delivered to Kafka, by event type",
+			},
+			[]string{"type"},
+		),
+		DeliveryDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "kafka_producer_delivery_duration_seconds",
+				Help:    "Time from publishing an event to its acknowledgement by Kafka",
+				Buckets: prometheus.DefBuckets,
+			},
+		),
+		Dropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kafka_producer_dropped_total",
+				Help: "Total number of events not queued for Kafka because the queue was full, by backpressure policy",
+			},
+			[]string{"policy"},
+		),
+		QueueLength: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "kafka_producer_queue_length",
+				Help: "Number of events waiting in the producer queue",
+			},
+		),
+	}
+
+	prometheus.MustRegister(m.Delivered, m.DeliveryErrors, m.DeliveryDuration, m.Dropped, m.QueueLength)
+
+	return m
+}
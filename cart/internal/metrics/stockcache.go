@@ -0,0 +1,54 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// StockCacheMetrics describe how well the in-process cache of stock items
+// spares calls to the Stocks service.
+type StockCacheMetrics struct {
+	Hits          prometheus.Counter
+	Misses        prometheus.Counter
+	Evictions     *prometheus.CounterVec
+	Entries       prometheus.Gauge
+	EventsApplied *prometheus.CounterVec
+}
+
+func RegisterStockCacheMetrics() *StockCacheMetrics {
+	m := &StockCacheMetrics{
+		Hits: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "stock_cache_hits_total",
+				Help: "Total number of stock item lookups served from the cache",
+			},
+		),
+		Misses: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "stock_cache_misses_total",
+				Help: "Total number of stock item lookups that had to ask the stock service",
+			},
+		),
+		Evictions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "stock_cache_evictions_total",
+				Help: "Total number of stock cache entries dropped, by reason",
+			},
+			[]string{"reason"},
+		),
+		Entries: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "stock_cache_entries",
+				Help: "Number of stock items currently cached",
+			},
+		),
+		EventsApplied: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "stock_cache_events_total",
+				Help: "Total number of stock events applied to the cache, by event type",
+			},
+			[]string{"type"},
+		),
+	}
+
+	prometheus.MustRegister(m.Hits, m.Misses, m.Evictions, m.Entries, m.EventsApplied)
+
+	return m
+}
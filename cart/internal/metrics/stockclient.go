@@ -0,0 +1,48 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// StockClientMetrics describe how the cart's calls to the Stocks service cope
+// with failures.
+type StockClientMetrics struct {
+	Retries            *prometheus.CounterVec
+	Hedges             *prometheus.CounterVec
+	CircuitState       prometheus.Gauge
+	CircuitTransitions *prometheus.CounterVec
+}
+
+func RegisterStockClientMetrics() *StockClientMetrics {
+	m := &StockClientMetrics{
+		Retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "stockclient_retries_total",
+				Help: "Total number of retried calls to the stock service, by method",
+			},
+			[]string{"method"},
+		),
+		Hedges: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "stockclient_hedged_requests_total",
+				Help: "Total number of hedged requests sent to the stock service, by method",
+			},
+			[]string{"method"},
+		),
+		CircuitState: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "stockclient_circuit_state",
+				Help: "State of the stock service circuit breaker: 0 closed, 1 half open, 2 open",
+			},
+		),
+		CircuitTransitions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "stockclient_circuit_transitions_total",
+				Help: "Total number of stock service circuit breaker transitions, by new state",
+			},
+			[]string{"state"},
+		),
+	}
+
+	prometheus.MustRegister(m.Retries, m.Hedges, m.CircuitState, m.CircuitTransitions)
+
+	return m
+}
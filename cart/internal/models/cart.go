@@ -1,9 +1,59 @@
 package models
 
+import (
+	"time"
+
+	"cart/internal/money"
+)
+
+// GuestCartID is the key of a guest cart. Carts are keyed by the user id of
+// their owner; user ids are positive, so guest carts take the negated guest id
+// and the two never collide.
+func GuestCartID(guestID int64) int64 {
+	return -guestID
+}
+
 type CartItem struct {
-	UserID int64
-	SKU    uint32
-	Count  int16
-	Price  float64
-	Stock  int16
+	UserID        int64
+	SKU           uint32
+	Count         int16
+	Price         money.Money
+	Stock         int16
+	Name          string
+	Type          string
+	Unfulfillable bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (i CartItem) LineTotal() money.Money {
+	return i.Price.Mul(int64(i.Count))
 }
+
+// Cart totals: Subtotal sums the lines, Discount the applied promotions and
+// TotalPrice is what is left to pay.
+type Cart struct {
+	UserID     int64
+	Items      []CartItem
+	Promotions []AppliedPromotion
+	Subtotal   money.Money
+	Discount   money.Money
+	TotalPrice money.Money
+}
+
+// AbandonedCart is a cart nobody has touched since LastActivityAt.
+type AbandonedCart struct {
+	UserID         int64
+	Items          []CartItem
+	LastActivityAt time.Time
+}
+
+// ClearReason tells why a cart was emptied.
+type ClearReason string
+
+const (
+	ClearReasonUser     ClearReason = "cleared"
+	ClearReasonCheckout ClearReason = "checkout"
+	ClearReasonMerged   ClearReason = "merged"
+	ClearReasonExpired  ClearReason = "expired"
+)
@@ -0,0 +1,14 @@
+package models
+
+// IdempotencyRecord is the request and, once it has completed, the response
+// stored under an idempotency key. ResponseType is empty while the request is
+// in progress.
+type IdempotencyRecord struct {
+	RequestHash  []byte
+	ResponseType string
+	Response     []byte
+}
+
+func (r IdempotencyRecord) Completed() bool {
+	return r.ResponseType != ""
+}
@@ -0,0 +1,24 @@
+package models
+
+type MergeAdjustment string
+
+const (
+	MergeAdjustmentNone    MergeAdjustment = "none"
+	MergeAdjustmentCapped  MergeAdjustment = "capped"
+	MergeAdjustmentDropped MergeAdjustment = "dropped"
+)
+
+// MergedItem reports how one line of a guest cart was folded into the user's
+// cart: Count is the user's quantity after the merge.
+type MergedItem struct {
+	SKU        uint32
+	GuestCount int16
+	UserCount  int16
+	Count      int16
+	Adjustment MergeAdjustment
+	Reason     string
+}
+
+type MergeReport struct {
+	Items []MergedItem
+}
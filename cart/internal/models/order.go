@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"cart/internal/money"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusNew     OrderStatus = "new"
+	OrderStatusCreated OrderStatus = "created"
+	OrderStatusFailed  OrderStatus = "failed"
+)
+
+type Order struct {
+	ID         int64
+	UserID     int64
+	Status     OrderStatus
+	Items      []OrderItem
+	Promotions []AppliedPromotion // applied promotions only
+	Subtotal   money.Money
+	Discount   money.Money
+	TotalPrice money.Money // Subtotal - Discount
+	CreatedAt  time.Time
+}
+
+type OrderItem struct {
+	SKU   uint32
+	Count int16
+	Price money.Money
+}
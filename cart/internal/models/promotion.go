@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"cart/internal/money"
+)
+
+type PromotionKind string
+
+const (
+	PromotionPercentage  PromotionKind = "percentage"
+	PromotionFixedAmount PromotionKind = "fixed_amount"
+	PromotionBuyXGetY    PromotionKind = "buy_x_get_y"
+)
+
+// Promotion is a discount rule redeemable with a promo code. SKU and SKUType
+// narrow it to matching cart lines; left empty they match every line.
+type Promotion struct {
+	ID          int64
+	Code        string
+	Description string
+	Kind        PromotionKind
+	Percent     int64       // percentage: share of the eligible lines taken off
+	Amount      money.Money // fixed_amount: taken off the eligible lines
+	BuyCount    int16       // buy_x_get_y: every BuyCount+FreeCount units of
+	FreeCount   int16       // a line, FreeCount of them are free
+	SKU         uint32
+	SKUType     string
+	StartsAt    time.Time
+	EndsAt      time.Time // zero when the promotion does not expire
+	MaxUses     int32     // zero when unlimited
+	Uses        int32
+}
+
+// AppliedPromotion is a promotion evaluated against a cart. Reason says why a
+// promotion applied to the cart earns no discount; it is empty otherwise.
+type AppliedPromotion struct {
+	Promotion Promotion
+	Discount  money.Money
+	Reason    string
+}
+
+func (a AppliedPromotion) Applied() bool {
+	return a.Reason == ""
+}
@@ -1,10 +1,13 @@
 package models
 
+import "cart/internal/money"
+
 type StockItem struct {
 	SKU      uint32
 	Name     string
 	Location string
 	Type     string
-	Price    float64
+	Price    money.Money
 	Count    int16
+	Archived bool
 }
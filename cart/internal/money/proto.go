@@ -0,0 +1,35 @@
+package money
+
+import (
+	"fmt"
+
+	moneypb "cart/pkg/api/money"
+)
+
+const nanosPerCent = 10_000_000
+
+func ToProto(m Money) *moneypb.Money {
+	return &moneypb.Money{
+		CurrencyCode: m.Currency,
+		Units:        m.Cents / centsPerUnit,
+		Nanos:        int32(m.Cents%centsPerUnit) * nanosPerCent,
+	}
+}
+
+// FromProto checks that the message is a well-formed amount with at most two
+// decimals. The currency code is returned as is; callers decide whether an
+// empty one means a default.
+func FromProto(pb *moneypb.Money) (Money, error) {
+	units, nanos := pb.GetUnits(), pb.GetNanos()
+
+	switch {
+	case nanos <= -1e9 || nanos >= 1e9:
+		return Money{}, fmt.Errorf("%w: nanos out of range", ErrInvalidAmount)
+	case units > 0 && nanos < 0, units < 0 && nanos > 0:
+		return Money{}, fmt.Errorf("%w: units and nanos have different signs", ErrInvalidAmount)
+	case nanos%nanosPerCent != 0:
+		return Money{}, fmt.Errorf("%w: more than two decimals", ErrInvalidAmount)
+	}
+
+	return New(pb.GetCurrencyCode(), units*centsPerUnit+int64(nanos/nanosPerCent)), nil
+}
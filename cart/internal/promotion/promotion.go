@@ -0,0 +1,187 @@
+// Package promotion evaluates promotions against the lines of a cart. It is
+// pure: prices come from the caller, which reads them from the Stocks service.
+package promotion
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	"cart/internal/errors"
+	"cart/internal/models"
+	"cart/internal/money"
+)
+
+// Validate checks the validity window and usage limit of p at now.
+func Validate(p models.Promotion, now time.Time) error {
+	if now.Before(p.StartsAt) {
+		return fmt.Errorf("%w: starts at %s", errors.ErrPromotionNotActive, p.StartsAt.UTC().Format(time.RFC3339))
+	}
+
+	if !p.EndsAt.IsZero() && !now.Before(p.EndsAt) {
+		return fmt.Errorf("%w: ended at %s", errors.ErrPromotionNotActive, p.EndsAt.UTC().Format(time.RFC3339))
+	}
+
+	if p.MaxUses > 0 && p.Uses >= p.MaxUses {
+		return errors.ErrPromotionUsedUp
+	}
+
+	return nil
+}
+
+// Discount is what p takes off items at now. Unfulfillable lines never earn a
+// discount.
+func Discount(p models.Promotion, items []models.CartItem, now time.Time) (money.Money, error) {
+	if err := Validate(p, now); err != nil {
+		return money.Money{}, err
+	}
+
+	eligible := make([]models.CartItem, 0, len(items))
+	for _, item := range items {
+		if matches(p, item) {
+			eligible = append(eligible, item)
+		}
+	}
+
+	if len(eligible) == 0 {
+		return money.Money{}, fmt.Errorf("%w: no eligible items", errors.ErrPromotionNotApplicable)
+	}
+
+	var (
+		discount money.Money
+		err      error
+	)
+
+	switch p.Kind {
+	case models.PromotionPercentage:
+		var subtotal money.Money
+
+		subtotal, err = linesTotal(eligible)
+		discount = subtotal.Percent(p.Percent)
+	case models.PromotionFixedAmount:
+		discount, err = fixedAmount(p, eligible)
+	case models.PromotionBuyXGetY:
+		discount, err = freeUnits(p, eligible)
+	default:
+		return money.Money{}, fmt.Errorf("%w: unknown kind %q", errors.ErrPromotionNotApplicable, p.Kind)
+	}
+
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	if discount.Cents <= 0 {
+		return money.Money{}, fmt.Errorf("%w: no discount earned", errors.ErrPromotionNotApplicable)
+	}
+
+	return discount, nil
+}
+
+// Apply evaluates promotions in order. Promotions that do not apply are kept
+// with the reason; the discounts of the others never exceed subtotal.
+func Apply(items []models.CartItem, promotions []models.Promotion, subtotal money.Money, now time.Time) ([]models.AppliedPromotion, money.Money, error) {
+	applied := make([]models.AppliedPromotion, 0, len(promotions))
+	total := money.New(subtotal.Currency, 0)
+
+	for _, p := range promotions {
+		discount, err := Discount(p, items, now)
+		if err != nil {
+			if !isPromotionError(err) {
+				return nil, money.Money{}, err
+			}
+
+			applied = append(applied, models.AppliedPromotion{Promotion: p, Reason: err.Error()})
+
+			continue
+		}
+
+		if left := subtotal.Cents - total.Cents; discount.Cents > left {
+			discount.Cents = left
+		}
+
+		if total, err = total.Add(discount); err != nil {
+			return nil, money.Money{}, err
+		}
+
+		applied = append(applied, models.AppliedPromotion{Promotion: p, Discount: discount})
+	}
+
+	return applied, total, nil
+}
+
+func matches(p models.Promotion, item models.CartItem) bool {
+	if item.Unfulfillable {
+		return false
+	}
+
+	if p.SKU != 0 && p.SKU != item.SKU {
+		return false
+	}
+
+	return p.SKUType == "" || p.SKUType == item.Type
+}
+
+func linesTotal(items []models.CartItem) (money.Money, error) {
+	var total money.Money
+
+	for _, item := range items {
+		var err error
+
+		if total, err = total.Add(item.LineTotal()); err != nil {
+			return money.Money{}, err
+		}
+	}
+
+	return total, nil
+}
+
+func fixedAmount(p models.Promotion, items []models.CartItem) (money.Money, error) {
+	subtotal, err := linesTotal(items)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	if subtotal.Currency != p.Amount.Currency {
+		return money.Money{}, fmt.Errorf("%w: the discount is in %s", errors.ErrPromotionNotApplicable, p.Amount.Currency)
+	}
+
+	if p.Amount.Cents > subtotal.Cents {
+		return subtotal, nil
+	}
+
+	return p.Amount, nil
+}
+
+func freeUnits(p models.Promotion, items []models.CartItem) (money.Money, error) {
+	group := int64(p.BuyCount) + int64(p.FreeCount)
+	if p.BuyCount <= 0 || p.FreeCount <= 0 {
+		return money.Money{}, fmt.Errorf("%w: invalid buy %d get %d", errors.ErrPromotionNotApplicable, p.BuyCount, p.FreeCount)
+	}
+
+	var discount money.Money
+
+	for _, item := range items {
+		free := int64(item.Count) / group * int64(p.FreeCount)
+		if free == 0 {
+			continue
+		}
+
+		var err error
+
+		if discount, err = discount.Add(item.Price.Mul(free)); err != nil {
+			return money.Money{}, err
+		}
+	}
+
+	if discount.IsZero() {
+		return money.Money{}, fmt.Errorf("%w: buy %d to get %d free", errors.ErrPromotionNotApplicable, p.BuyCount, p.FreeCount)
+	}
+
+	return discount, nil
+}
+
+func isPromotionError(err error) bool {
+	return stdErrors.Is(err, errors.ErrPromotionNotActive) ||
+		stdErrors.Is(err, errors.ErrPromotionUsedUp) ||
+		stdErrors.Is(err, errors.ErrPromotionNotApplicable)
+}
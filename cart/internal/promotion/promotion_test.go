@@ -0,0 +1,145 @@
+package promotion_test
+
+import (
+	"cart/internal/errors"
+	"cart/internal/models"
+	"cart/internal/money"
+	"cart/internal/promotion"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+func rub(cents int64) money.Money {
+	return money.New("RUB", cents)
+}
+
+func TestDiscount(t *testing.T) {
+	t.Parallel()
+
+	items := []models.CartItem{
+		{SKU: 100, Count: 3, Type: "apparel", Price: rub(1000)},
+		{SKU: 101, Count: 1, Type: "shoes", Price: rub(4999)},
+		{SKU: 102, Count: 2, Type: "apparel", Price: rub(500), Unfulfillable: true},
+	}
+
+	tests := []struct {
+		name      string
+		promotion models.Promotion
+		want      money.Money
+		wantErr   error
+	}{
+		{
+			name:      "percentage of the whole cart",
+			promotion: models.Promotion{Kind: models.PromotionPercentage, Percent: 10},
+			want:      rub(800),
+		},
+		{
+			name:      "percentage of one sku type",
+			promotion: models.Promotion{Kind: models.PromotionPercentage, Percent: 15, SKUType: "apparel"},
+			want:      rub(450),
+		},
+		{
+			name:      "fixed amount",
+			promotion: models.Promotion{Kind: models.PromotionFixedAmount, Amount: rub(500)},
+			want:      rub(500),
+		},
+		{
+			name:      "fixed amount is capped by the eligible lines",
+			promotion: models.Promotion{Kind: models.PromotionFixedAmount, Amount: rub(10000), SKU: 101},
+			want:      rub(4999),
+		},
+		{
+			name:      "fixed amount in another currency",
+			promotion: models.Promotion{Kind: models.PromotionFixedAmount, Amount: money.New("USD", 500)},
+			wantErr:   errors.ErrPromotionNotApplicable,
+		},
+		{
+			name:      "buy two get one",
+			promotion: models.Promotion{Kind: models.PromotionBuyXGetY, BuyCount: 2, FreeCount: 1},
+			want:      rub(1000),
+		},
+		{
+			name:      "buy x get y without enough units",
+			promotion: models.Promotion{Kind: models.PromotionBuyXGetY, BuyCount: 2, FreeCount: 1, SKU: 101},
+			wantErr:   errors.ErrPromotionNotApplicable,
+		},
+		{
+			name:      "unfulfillable lines are not eligible",
+			promotion: models.Promotion{Kind: models.PromotionPercentage, Percent: 50, SKU: 102},
+			wantErr:   errors.ErrPromotionNotApplicable,
+		},
+		{
+			name:      "no matching sku type",
+			promotion: models.Promotion{Kind: models.PromotionPercentage, Percent: 50, SKUType: "books"},
+			wantErr:   errors.ErrPromotionNotApplicable,
+		},
+		{
+			name:      "not started",
+			promotion: models.Promotion{Kind: models.PromotionPercentage, Percent: 10, StartsAt: now.Add(time.Hour)},
+			wantErr:   errors.ErrPromotionNotActive,
+		},
+		{
+			name:      "expired",
+			promotion: models.Promotion{Kind: models.PromotionPercentage, Percent: 10, EndsAt: now},
+			wantErr:   errors.ErrPromotionNotActive,
+		},
+		{
+			name:      "usage limit reached",
+			promotion: models.Promotion{Kind: models.PromotionPercentage, Percent: 10, MaxUses: 5, Uses: 5},
+			wantErr:   errors.ErrPromotionUsedUp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := promotion.Discount(tt.promotion, items, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	items := []models.CartItem{
+		{SKU: 100, Count: 2, Type: "apparel", Price: rub(1000)},
+	}
+
+	promotions := []models.Promotion{
+		{Code: "HALF", Kind: models.PromotionPercentage, Percent: 50},
+		{Code: "OLD", Kind: models.PromotionPercentage, Percent: 10, EndsAt: now.Add(-time.Hour)},
+		{Code: "BIG", Kind: models.PromotionFixedAmount, Amount: rub(1500)},
+	}
+
+	applied, discount, err := promotion.Apply(items, promotions, rub(2000), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, rub(2000), discount, "discounts never exceed the subtotal")
+
+	if len(applied) != 3 {
+		t.Fatalf("expected 3 promotions, got %d", len(applied))
+	}
+
+	assert.Equal(t, rub(1000), applied[0].Discount)
+	assert.True(t, applied[0].Applied())
+	assert.False(t, applied[1].Applied())
+	assert.Contains(t, applied[1].Reason, errors.ErrPromotionNotActive.Error())
+	assert.Equal(t, rub(1000), applied[2].Discount)
+}
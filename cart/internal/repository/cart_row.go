@@ -1,17 +1,25 @@
 package repository
 
-import "cart/internal/models"
+import (
+	"time"
+
+	"cart/internal/models"
+)
 
 type CartItemRow struct {
-	UserID int64
-	SKU    uint32
-	Count  int16
+	UserID    int64
+	SKU       uint32
+	Count     int16
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 func (r *CartItemRow) ToDomain() models.CartItem {
 	return models.CartItem{
-		UserID: r.UserID,
-		SKU:    r.SKU,
-		Count:  r.Count,
+		UserID:    r.UserID,
+		SKU:       r.SKU,
+		Count:     r.Count,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
 	}
 }
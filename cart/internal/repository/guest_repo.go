@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=guest_repo.go -destination=../usecase/mocks/guest_repository_mock.go -package=mocks
+
+type GuestCartRepository interface {
+	Create(ctx context.Context, tokenHash []byte) (int64, error)
+	GuestIDByToken(ctx context.Context, tokenHash []byte) (int64, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"cart/internal/models"
+	"context"
+)
+
+//go:generate mockgen -source=order_repo.go -destination=../usecase/mocks/order_repository_mock.go -package=mocks
+
+type OrderRepository interface {
+	Create(ctx context.Context, order models.Order) (int64, error)
+	UpdateStatus(ctx context.Context, orderID int64, status models.OrderStatus) error
+	// Fail marks the order failed and gives back the promotion uses it
+	// redeemed.
+	Fail(ctx context.Context, orderID int64) error
+}
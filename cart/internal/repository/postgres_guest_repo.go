@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"cart/internal/errors"
+	"context"
+	"database/sql"
+	stdErrors "errors"
+)
+
+type PostgresGuestCartRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresGuestCartRepo(db *sql.DB) *PostgresGuestCartRepo {
+	return &PostgresGuestCartRepo{db: db}
+}
+
+func (r *PostgresGuestCartRepo) Create(ctx context.Context, tokenHash []byte) (int64, error) {
+	var guestID int64
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO guest_carts (token_hash)
+		VALUES ($1)
+		RETURNING guest_id
+	`, tokenHash).Scan(&guestID)
+
+	return guestID, err
+}
+
+func (r *PostgresGuestCartRepo) GuestIDByToken(ctx context.Context, tokenHash []byte) (int64, error) {
+	var guestID int64
+
+	err := r.db.QueryRowContext(ctx, `SELECT guest_id FROM guest_carts WHERE token_hash = $1`, tokenHash).Scan(&guestID)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return 0, errors.ErrGuestCartNotFound
+	}
+
+	return guestID, err
+}
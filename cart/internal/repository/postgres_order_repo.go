@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"cart/internal/errors"
+	"cart/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type PostgresOrderRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresOrderRepo(db *sql.DB) *PostgresOrderRepo {
+	return &PostgresOrderRepo{db: db}
+}
+
+func (r *PostgresOrderRepo) Create(ctx context.Context, order models.Order) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+
+	var orderID int64
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO orders (user_id, status, total_price, discount, currency)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING order_id
+	`, order.UserID, order.Status, order.TotalPrice.Decimal(), order.Discount.Decimal(), order.TotalPrice.Currency).Scan(&orderID)
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	for _, item := range order.Items {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO order_items (order_id, sku, count, price)
+			VALUES ($1, $2, $3, $4)
+		`, orderID, item.SKU, item.Count, item.Price.Decimal())
+		if err != nil {
+			return 0, rollback(tx, err)
+		}
+	}
+
+	for _, applied := range order.Promotions {
+		if err = redeem(ctx, tx, orderID, applied); err != nil {
+			return 0, rollback(tx, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return orderID, nil
+}
+
+func (r *PostgresOrderRepo) UpdateStatus(ctx context.Context, orderID int64, status models.OrderStatus) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE orders SET status = $1 WHERE order_id = $2`, status, orderID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.ErrOrderNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresOrderRepo) Fail(ctx context.Context, orderID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE orders SET status = $1 WHERE order_id = $2`, models.OrderStatusFailed, orderID)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	if affected == 0 {
+		return rollback(tx, errors.ErrOrderNotFound)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE promotions p
+		SET uses = p.uses - 1
+		FROM order_promotions op
+		WHERE op.order_id = $1 AND op.promotion_id = p.promotion_id AND p.uses > 0
+	`, orderID)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM order_promotions WHERE order_id = $1`, orderID)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// redeem counts a use of the promotion against its limit, so two orders
+// cannot both take the last use.
+func redeem(ctx context.Context, tx *sql.Tx, orderID int64, applied models.AppliedPromotion) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE promotions
+		SET uses = uses + 1
+		WHERE promotion_id = $1 AND (max_uses = 0 OR uses < max_uses)
+	`, applied.Promotion.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.ErrPromotionUsedUp
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO order_promotions (order_id, promotion_id, discount)
+		VALUES ($1, $2, $3)
+	`, orderID, applied.Promotion.ID, applied.Discount.Decimal())
+
+	return err
+}
+
+func rollback(tx *sql.Tx, err error) error {
+	if rbErr := tx.Rollback(); rbErr != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+	}
+
+	return err
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"cart/internal/errors"
+	"cart/internal/models"
+	"context"
+	"database/sql"
+	stdErrors "errors"
+
+	"github.com/lib/pq"
+)
+
+const uniqueViolation = "23505"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type PostgresPromotionRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresPromotionRepo(db *sql.DB) *PostgresPromotionRepo {
+	return &PostgresPromotionRepo{db: db}
+}
+
+func scanPromotion(row rowScanner) (models.Promotion, error) {
+	var r PromotionRow
+
+	err := row.Scan(
+		&r.ID, &r.Code, &r.Description, &r.Kind, &r.Percent, &r.Amount, &r.Currency,
+		&r.BuyCount, &r.FreeCount, &r.SKU, &r.SKUType, &r.StartsAt, &r.EndsAt, &r.MaxUses, &r.Uses,
+	)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return models.Promotion{}, errors.ErrPromotionNotFound
+	}
+
+	if err != nil {
+		return models.Promotion{}, err
+	}
+
+	return r.ToDomain()
+}
+
+func (r *PostgresPromotionRepo) GetByCode(ctx context.Context, code string) (models.Promotion, error) {
+	return scanPromotion(r.db.QueryRowContext(ctx, `
+		SELECT `+promotionColumns+`
+		FROM promotions p
+		WHERE p.code = $1
+	`, code))
+}
+
+func (r *PostgresPromotionRepo) ListApplied(ctx context.Context, userID int64) ([]models.Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+promotionColumns+`
+		FROM cart_promotions c
+		JOIN promotions p ON p.promotion_id = c.promotion_id
+		WHERE c.user_id = $1
+		ORDER BY c.applied_at, p.promotion_id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promotions []models.Promotion
+
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (r *PostgresPromotionRepo) Apply(ctx context.Context, userID, promotionID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO cart_promotions (user_id, promotion_id)
+		VALUES ($1, $2)
+	`, userID, promotionID)
+
+	var pqErr *pq.Error
+	if stdErrors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return errors.ErrPromotionAlreadyApplied
+	}
+
+	return err
+}
+
+func (r *PostgresPromotionRepo) Remove(ctx context.Context, userID int64, code string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM cart_promotions c
+		USING promotions p
+		WHERE p.promotion_id = c.promotion_id AND c.user_id = $1 AND p.code = $2
+	`, userID, code)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.ErrPromotionNotApplied
+	}
+
+	return nil
+}
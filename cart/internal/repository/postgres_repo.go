@@ -6,6 +6,8 @@ import (
 	"context"
 	"database/sql"
 	stdErrors "errors"
+	"fmt"
+	"time"
 )
 
 type PostgresCartRepo struct {
@@ -43,36 +45,35 @@ func (r *PostgresCartRepo) Add(ctx context.Context, item models.CartItem) error
 
 func (r *PostgresCartRepo) Upsert(ctx context.Context, item models.CartItem) error {
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO cart_items (user_id, sku, count, price)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO cart_items (user_id, sku, count, price, currency)
+		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT (user_id, sku)
 		DO UPDATE SET 
-			count = cart_items.count + EXCLUDED.count
-	`, item.UserID, item.SKU, item.Count, item.Price)
+			count = cart_items.count + EXCLUDED.count,
+			updated_at = now()
+	`, item.UserID, item.SKU, item.Count, item.Price.Decimal(), item.Price.Currency)
 
 	return err
 }
 
-func (r *PostgresCartRepo) Delete(ctx context.Context, userID int64, sku uint32) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM cart_items WHERE user_id = $1 AND sku = $2`, userID, sku)
-	if err != nil {
-		return err
-	}
+// Delete removes the line of sku and returns the count it held.
+func (r *PostgresCartRepo) Delete(ctx context.Context, userID int64, sku uint32) (int16, error) {
+	var count int16
 
-	affected, err := res.RowsAffected()
-	if err != nil {
-		return err
+	err := r.db.QueryRowContext(ctx, `DELETE FROM cart_items WHERE user_id = $1 AND sku = $2 RETURNING count`, userID, sku).Scan(&count)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return 0, errors.ErrCartItemNotFound
 	}
 
-	if affected == 0 {
-		return errors.ErrCartItemNotFound
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	return count, nil
 }
 
 func (r *PostgresCartRepo) List(ctx context.Context, userID int64) ([]models.CartItem, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT user_id, sku, count FROM cart_items WHERE user_id = $1`, userID)
+	rows, err := r.db.QueryContext(ctx, `SELECT user_id, sku, count, created_at, updated_at FROM cart_items WHERE user_id = $1`, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +84,7 @@ func (r *PostgresCartRepo) List(ctx context.Context, userID int64) ([]models.Car
 	for rows.Next() {
 		var row CartItemRow
 
-		if err = rows.Scan(&row.UserID, &row.SKU, &row.Count); err != nil {
+		if err = rows.Scan(&row.UserID, &row.SKU, &row.Count, &row.CreatedAt, &row.UpdatedAt); err != nil {
 			return nil, err
 		}
 
@@ -97,7 +98,232 @@ func (r *PostgresCartRepo) List(ctx context.Context, userID int64) ([]models.Car
 	return items, nil
 }
 
-func (r *PostgresCartRepo) Clear(ctx context.Context, userID int64) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM cart_items WHERE user_id = $1`, userID)
+// Clear empties the cart together with its applied promo codes and returns
+// the lines it held.
+func (r *PostgresCartRepo) Clear(ctx context.Context, userID int64) ([]models.CartItem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH promotions AS (
+			DELETE FROM cart_promotions WHERE user_id = $1
+		)
+		DELETE FROM cart_items WHERE user_id = $1
+		RETURNING user_id, sku, count
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCartLines(rows)
+}
+
+// scanCartLines reads rows of user_id, sku and count and closes them.
+func scanCartLines(rows *sql.Rows) ([]models.CartItem, error) {
+	defer rows.Close()
+
+	var items []models.CartItem
+
+	for rows.Next() {
+		var item models.CartItem
+
+		if err := rows.Scan(&item.UserID, &item.SKU, &item.Count); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Merge folds a guest cart into the user's cart in one transaction. guestItems
+// are the guest lines the merge was computed from, and added holds the units
+// each SKU adds to the user's cart, so that lines the user changes meanwhile
+// are kept. Only guestItems are removed from the guest cart; if one of them
+// has changed since, nothing is merged and ErrGuestCartChanged is returned.
+// The guest cart itself, with its promo codes, is removed once it is empty;
+// the promo codes are carried over to the user's cart.
+func (r *PostgresCartRepo) Merge(ctx context.Context, guestID, userID int64, guestItems, added []models.CartItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	// Merges of the same guest cart run one after the other.
+	var locked int64
+
+	err = tx.QueryRowContext(ctx, `SELECT guest_id FROM guest_carts WHERE guest_id = $1 FOR UPDATE`, guestID).Scan(&locked)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return rollback(tx, errors.ErrGuestCartNotFound)
+	}
+
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	guestCartID := models.GuestCartID(guestID)
+
+	for _, item := range guestItems {
+		res, err := tx.ExecContext(ctx, `
+			DELETE FROM cart_items
+			WHERE user_id = $1 AND sku = $2 AND count = $3 AND updated_at = $4
+		`, guestCartID, item.SKU, item.Count, item.UpdatedAt)
+		if err != nil {
+			return rollback(tx, err)
+		}
+
+		deleted, err := res.RowsAffected()
+		if err != nil {
+			return rollback(tx, err)
+		}
+
+		if deleted == 0 {
+			return rollback(tx, errors.ErrGuestCartChanged)
+		}
+	}
+
+	for _, item := range added {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO cart_items (user_id, sku, count, price, currency)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, sku)
+			DO UPDATE SET count = cart_items.count + EXCLUDED.count, price = EXCLUDED.price, currency = EXCLUDED.currency, updated_at = now()
+		`, userID, item.SKU, item.Count, item.Price.Decimal(), item.Price.Currency)
+		if err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO cart_promotions (user_id, promotion_id, applied_at)
+		SELECT $2, promotion_id, applied_at
+		FROM cart_promotions
+		WHERE user_id = $1
+		ON CONFLICT (user_id, promotion_id) DO NOTHING
+	`, guestCartID, userID)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	// Lines added to the guest cart during the merge keep it alive.
+	_, err = tx.ExecContext(ctx, `
+		WITH promotions AS (
+			DELETE FROM cart_promotions
+			WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM cart_items WHERE user_id = $1)
+		)
+		DELETE FROM guest_carts
+		WHERE guest_id = $2 AND NOT EXISTS (SELECT 1 FROM cart_items WHERE user_id = $1)
+	`, guestCartID, guestID)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// ListAbandoned returns the user carts, oldest first, whose last change is
+// before idleSince and which have not been marked abandoned since. Guest carts
+// are left out: nobody can be reached about them. Items are not loaded.
+func (r *PostgresCartRepo) ListAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]models.AbandonedCart, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.user_id, max(c.updated_at) AS last_activity_at
+		FROM cart_items c
+		LEFT JOIN abandoned_carts a ON a.user_id = c.user_id
+		WHERE c.user_id > 0
+		GROUP BY c.user_id, a.last_activity_at
+		HAVING max(c.updated_at) < $1
+			AND (a.last_activity_at IS NULL OR a.last_activity_at < max(c.updated_at))
+		ORDER BY last_activity_at, c.user_id
+		LIMIT $2
+	`, idleSince, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var carts []models.AbandonedCart
+
+	for rows.Next() {
+		var cart models.AbandonedCart
+
+		if err = rows.Scan(&cart.UserID, &cart.LastActivityAt); err != nil {
+			return nil, err
+		}
+
+		carts = append(carts, cart)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return carts, nil
+}
+
+// ClaimAbandoned records that cart is being announced as abandoned. It
+// reports false if the cart was already claimed for this period of inactivity,
+// e.g. by another instance, so that each period is announced once.
+func (r *PostgresCartRepo) ClaimAbandoned(ctx context.Context, cart models.AbandonedCart) (bool, error) {
+	var userID int64
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO abandoned_carts (user_id, last_activity_at)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id)
+		DO UPDATE SET last_activity_at = EXCLUDED.last_activity_at, notified_at = now()
+		WHERE abandoned_carts.last_activity_at < EXCLUDED.last_activity_at
+		RETURNING user_id
+	`, cart.UserID, cart.LastActivityAt).Scan(&userID)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseAbandoned drops the claim ClaimAbandoned took on cart, so that the
+// next run announces it again.
+func (r *PostgresCartRepo) ReleaseAbandoned(ctx context.Context, cart models.AbandonedCart) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM abandoned_carts WHERE user_id = $1 AND last_activity_at = $2
+	`, cart.UserID, cart.LastActivityAt)
 	return err
 }
+
+// PurgeIdle deletes every cart, guest carts included, whose last change is
+// before idleSince, together with its promo codes, and guest carts created
+// before idleSince that were never filled. It returns the deleted lines.
+func (r *PostgresCartRepo) PurgeIdle(ctx context.Context, idleSince time.Time) ([]models.CartItem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH stale AS (
+			SELECT user_id FROM cart_items GROUP BY user_id HAVING max(updated_at) < $1
+		), promotions AS (
+			DELETE FROM cart_promotions WHERE user_id IN (SELECT user_id FROM stale)
+		), abandoned AS (
+			DELETE FROM abandoned_carts a
+			WHERE a.user_id IN (SELECT user_id FROM stale)
+				OR NOT EXISTS (SELECT 1 FROM cart_items c WHERE c.user_id = a.user_id)
+		), guests AS (
+			DELETE FROM guest_carts g
+			WHERE -g.guest_id IN (SELECT user_id FROM stale)
+				OR (g.created_at < $1 AND NOT EXISTS (SELECT 1 FROM cart_items c WHERE c.user_id = -g.guest_id))
+		)
+		DELETE FROM cart_items WHERE user_id IN (SELECT user_id FROM stale)
+		RETURNING user_id, sku, count
+	`, idleSince)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCartLines(rows)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"cart/internal/models"
+	"context"
+)
+
+//go:generate mockgen -source=promotion_repo.go -destination=../usecase/mocks/promotion_repository_mock.go -package=mocks
+
+type PromotionRepository interface {
+	GetByCode(ctx context.Context, code string) (models.Promotion, error)
+	ListApplied(ctx context.Context, userID int64) ([]models.Promotion, error)
+	Apply(ctx context.Context, userID, promotionID int64) error
+	Remove(ctx context.Context, userID int64, code string) error
+}
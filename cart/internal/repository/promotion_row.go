@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"cart/internal/models"
+	"cart/internal/money"
+)
+
+const promotionColumns = `
+	p.promotion_id, p.code, p.description, p.kind, p.percent, p.amount::text, p.currency,
+	p.buy_count, p.free_count, p.sku, p.sku_type, p.starts_at, p.ends_at, p.max_uses, p.uses`
+
+type PromotionRow struct {
+	ID          int64
+	Code        string
+	Description string
+	Kind        string
+	Percent     int64
+	Amount      string
+	Currency    string
+	BuyCount    int16
+	FreeCount   int16
+	SKU         uint32
+	SKUType     string
+	StartsAt    time.Time
+	EndsAt      sql.NullTime
+	MaxUses     int32
+	Uses        int32
+}
+
+func (r *PromotionRow) ToDomain() (models.Promotion, error) {
+	amount, err := money.Parse(r.Currency, r.Amount)
+	if err != nil {
+		return models.Promotion{}, err
+	}
+
+	return models.Promotion{
+		ID:          r.ID,
+		Code:        r.Code,
+		Description: r.Description,
+		Kind:        models.PromotionKind(r.Kind),
+		Percent:     r.Percent,
+		Amount:      amount,
+		BuyCount:    r.BuyCount,
+		FreeCount:   r.FreeCount,
+		SKU:         r.SKU,
+		SKUType:     r.SKUType,
+		StartsAt:    r.StartsAt,
+		EndsAt:      r.EndsAt.Time,
+		MaxUses:     r.MaxUses,
+		Uses:        r.Uses,
+	}, nil
+}
@@ -3,14 +3,20 @@ package repository
 import (
 	"cart/internal/models"
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=repo.go -destination=../../usecase/mocks/cart_repository_mock.go -package=mocks
 
 type CartRepository interface {
 	Add(ctx context.Context, item models.CartItem) error
-	Delete(ctx context.Context, userID int64, sku uint32) error
+	Delete(ctx context.Context, userID int64, sku uint32) (int16, error)
 	List(ctx context.Context, userID int64) ([]models.CartItem, error)
-	Clear(ctx context.Context, userID int64) error
+	Clear(ctx context.Context, userID int64) ([]models.CartItem, error)
 	Upsert(ctx context.Context, item models.CartItem) error
+	Merge(ctx context.Context, guestID, userID int64, guestItems, added []models.CartItem) error
+	ListAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]models.AbandonedCart, error)
+	ClaimAbandoned(ctx context.Context, cart models.AbandonedCart) (bool, error)
+	ReleaseAbandoned(ctx context.Context, cart models.AbandonedCart) error
+	PurgeIdle(ctx context.Context, idleSince time.Time) ([]models.CartItem, error)
 }
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"cart/internal/auth"
 	"cart/internal/config"
+	"cart/internal/idempotency"
 	"cart/internal/log"
 	"cart/internal/metrics"
 
@@ -28,7 +31,7 @@ const (
 func NewGatewayMux(ctx context.Context, cfg *config.Config) (http.Handler, error) {
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	mux := runtime.NewServeMux()
+	mux := newServeMux()
 	err := cartpb.RegisterCartServiceHandlerFromEndpoint(ctx, mux, cfg.GRPCEndpoint, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register gateway: %w", err)
@@ -37,10 +40,27 @@ func NewGatewayMux(ctx context.Context, cfg *config.Config) (http.Handler, error
 	return mux, nil
 }
 
+// newServeMux passes the Authorization header on as "authorization" metadata
+// and X-Cart-Token as "x-cart-token", which is what the gRPC auth interceptor
+// reads, and Idempotency-Key as "idempotency-key".
+func newServeMux() *runtime.ServeMux {
+	return runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+		if strings.EqualFold(key, auth.CartTokenHeader) {
+			return auth.CartTokenHeader, true
+		}
+
+		if strings.EqualFold(key, idempotency.Header) {
+			return idempotency.Header, true
+		}
+
+		return runtime.DefaultHeaderMatcher(key)
+	}))
+}
+
 func StartGatewayServer(ctx context.Context, cfg *config.Config, logger log.Logger, m metrics.MetricsInterface) error {
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	mux := runtime.NewServeMux()
+	mux := newServeMux()
 
 	err := cartpb.RegisterCartServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCPort, opts)
 	if err != nil {
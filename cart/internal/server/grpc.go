@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net"
 
+	"cart/internal/auth"
 	"cart/internal/config"
+	"cart/internal/idempotency"
 	"cart/internal/log"
 	"cart/internal/log/zap"
 	"cart/internal/metrics"
@@ -90,18 +92,34 @@ func TracingInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
-func StartGRPCServer(ctx context.Context, cfg *config.Config, cartUC usecase.CartUseCase, logger *zap.Logger, m *metrics.Metrics) error {
+func StartGRPCServer(ctx context.Context, cfg *config.Config, cartUC usecase.CartUseCase, guests auth.GuestCarts, keys idempotency.Store, logger *zap.Logger, m *metrics.Metrics) error {
 	lis, err := net.Listen("tcp", cfg.GRPCPort)
 	if err != nil {
 		logger.Error("failed to listen on port", log.String("port", cfg.GRPCPort), log.Error(err))
 		return fmt.Errorf("failed to listen on port %s: %w", cfg.GRPCPort, err)
 	}
 
+	verifier, err := auth.NewVerifier(cfg.AuthConfig())
+	if err != nil {
+		logger.Error("failed to configure authentication", log.Error(err))
+		return fmt.Errorf("failed to configure authentication: %w", err)
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			metrics.UnaryServerInterceptor(m),
 			TracingInterceptor(),
 			LoggingInterceptor(logger),
+			auth.UnaryServerInterceptor(verifier, guests, logger, cartpb.CartService_CreateGuestCart_FullMethodName),
+			idempotency.UnaryServerInterceptor(keys, cfg.IdempotencyKeyTTL, logger,
+				cartpb.CartService_AddItem_FullMethodName,
+				cartpb.CartService_DeleteItem_FullMethodName,
+				cartpb.CartService_ClearCart_FullMethodName,
+				cartpb.CartService_Checkout_FullMethodName,
+				cartpb.CartService_ApplyPromoCode_FullMethodName,
+				cartpb.CartService_RemovePromoCode_FullMethodName,
+				cartpb.CartService_MergeCarts_FullMethodName,
+			),
 		),
 	)
 
@@ -0,0 +1,132 @@
+package stockclient
+
+import (
+	"sync"
+	"time"
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitHalfOpen
+	circuitOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitHalfOpen:
+		return "half_open"
+	case circuitOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// breaker is a consecutive-failures circuit breaker. While half open it lets
+// a single probe through; the probe's outcome closes or reopens the circuit.
+type breaker struct {
+	mu        sync.Mutex
+	state     circuitState
+	failures  int
+	threshold int
+	cooldown  time.Duration
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+	onChange  func(from, to circuitState)
+}
+
+func newBreaker(threshold int, cooldown time.Duration, onChange func(from, to circuitState)) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		onChange:  onChange,
+	}
+}
+
+// allow reports whether a call may go through. Every allowed call must be
+// followed by record.
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+
+		b.setState(circuitHalfOpen)
+		b.probing = true
+
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+
+		b.probing = true
+
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *breaker) record(failed bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.probing = false
+
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.setState(circuitClosed)
+		}
+
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitClosed && b.failures >= b.threshold {
+		b.open()
+	}
+}
+
+func (b *breaker) open() {
+	b.openedAt = b.now()
+	b.failures = 0
+	b.setState(circuitOpen)
+}
+
+func (b *breaker) setState(state circuitState) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+
+	if b.onChange != nil {
+		b.onChange(from, state)
+	}
+}
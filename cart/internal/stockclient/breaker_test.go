@@ -0,0 +1,58 @@
+package stockclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	var transitions []circuitState
+
+	b := newBreaker(2, 10*time.Second, func(_, to circuitState) {
+		transitions = append(transitions, to)
+	})
+	b.now = func() time.Time { return now }
+
+	assert.True(t, b.allow())
+	b.record(true)
+	assert.True(t, b.allow())
+	b.record(false)
+	assert.True(t, b.allow(), "a success resets the failure count")
+	b.record(true)
+	assert.True(t, b.allow())
+	b.record(true)
+
+	assert.False(t, b.allow(), "open after two consecutive failures")
+
+	now = now.Add(10 * time.Second)
+
+	assert.True(t, b.allow(), "a probe goes through after the cooldown")
+	assert.False(t, b.allow(), "only one probe at a time")
+	b.record(true)
+	assert.False(t, b.allow(), "a failed probe reopens the circuit")
+
+	now = now.Add(10 * time.Second)
+
+	assert.True(t, b.allow())
+	b.record(false)
+	assert.True(t, b.allow(), "a successful probe closes the circuit")
+
+	assert.Equal(t, []circuitState{circuitOpen, circuitHalfOpen, circuitOpen, circuitHalfOpen, circuitClosed}, transitions)
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	t.Parallel()
+
+	b := newBreaker(0, time.Second, nil)
+
+	for range 10 {
+		assert.True(t, b.allow())
+		b.record(true)
+	}
+}
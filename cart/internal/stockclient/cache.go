@@ -0,0 +1,279 @@
+package stockclient
+
+import (
+	"cart/internal/log"
+	"cart/internal/metrics"
+	"cart/internal/models"
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	evictionCapacity    = "capacity"
+	evictionExpired     = "expired"
+	evictionInvalidated = "invalidated"
+)
+
+// Cache is a bounded, least recently used cache of stock items in front of
+// another StockRepository. Entries are dropped by Invalidate, which the stock
+// events consumer calls as stock changes, and expire after the configured
+// TTL in case an event never arrives.
+type Cache struct {
+	next    StockRepository
+	cfg     CacheConfig
+	logger  log.Logger
+	metrics *metrics.StockCacheMetrics
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[uint32]*list.Element
+	lru     *list.List
+	// fetching counts the lookups of a SKU in flight and invalidated marks
+	// the SKUs invalidated meanwhile: those lookups may have read the stock
+	// before the change, so their result is not cached.
+	fetching    map[uint32]int
+	invalidated map[uint32]bool
+	online      bool
+}
+
+type cacheEntry struct {
+	item     models.StockItem
+	cachedAt time.Time
+}
+
+func NewCache(next StockRepository, cfg CacheConfig, logger log.Logger, m *metrics.StockCacheMetrics) *Cache {
+	return &Cache{
+		next:        next,
+		cfg:         cfg,
+		logger:      logger,
+		metrics:     m,
+		now:         time.Now,
+		entries:     make(map[uint32]*list.Element),
+		lru:         list.New(),
+		fetching:    make(map[uint32]int),
+		invalidated: make(map[uint32]bool),
+	}
+}
+
+type freshKey struct{}
+
+// Fresh marks stock lookups made with ctx as needing the current stock: the
+// cache fetches them from the stocks service, never serving a cached copy,
+// and caches what it fetched.
+func Fresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshKey{}, true)
+}
+
+func isFresh(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshKey{}).(bool)
+	return fresh
+}
+
+func (c *Cache) GetBySKU(ctx context.Context, sku uint32) (models.StockItem, error) {
+	if !isFresh(ctx) {
+		if item, ok := c.lookup(sku); ok {
+			return item, nil
+		}
+	}
+
+	c.beginFetch(sku)
+
+	item, err := c.next.GetBySKU(ctx, sku)
+	if err != nil {
+		c.endFetch(nil, sku)
+		return models.StockItem{}, err
+	}
+
+	c.endFetch(map[uint32]models.StockItem{sku: item}, sku)
+
+	return item, nil
+}
+
+// GetBySKUs serves the cached items and fetches the rest in a single call.
+func (c *Cache) GetBySKUs(ctx context.Context, skus []uint32) (map[uint32]models.StockItem, error) {
+	items := make(map[uint32]models.StockItem, len(skus))
+	missing := make([]uint32, 0, len(skus))
+	fresh := isFresh(ctx)
+
+	for _, sku := range skus {
+		if !fresh {
+			if item, ok := c.lookup(sku); ok {
+				items[sku] = item
+				continue
+			}
+		}
+
+		missing = append(missing, sku)
+	}
+
+	if len(missing) == 0 {
+		return items, nil
+	}
+
+	c.beginFetch(missing...)
+
+	fetched, err := c.next.GetBySKUs(ctx, missing)
+	if err != nil {
+		c.endFetch(nil, missing...)
+		return nil, err
+	}
+
+	c.endFetch(fetched, missing...)
+
+	for sku, item := range fetched {
+		items[sku] = item
+	}
+
+	return items, nil
+}
+
+// ReduceStock always goes to the stocks service and drops the reduced items,
+// whether or not the reduction went through.
+func (c *Cache) ReduceStock(ctx context.Context, orderID int64, items []models.OrderItem) error {
+	err := c.next.ReduceStock(ctx, orderID, items)
+
+	skus := make([]uint32, 0, len(items))
+	for _, item := range items {
+		skus = append(skus, item.SKU)
+	}
+
+	c.Invalidate(skus...)
+
+	return err
+}
+
+// Invalidate drops skus from the cache.
+func (c *Cache) Invalidate(skus ...uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, sku := range skus {
+		if c.fetching[sku] > 0 {
+			c.invalidated[sku] = true
+		}
+
+		if el, ok := c.entries[sku]; ok {
+			c.remove(el, evictionInvalidated)
+		}
+	}
+
+	c.updateSize()
+}
+
+// SetOnline records whether stock events are being consumed, which decides
+// the TTL applied to the entries.
+func (c *Cache) SetOnline(online bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.online != online {
+		c.logger.Info("stock cache invalidation state changed", log.Bool("online", online))
+	}
+
+	c.online = online
+}
+
+func (c *Cache) lookup(sku uint32) (models.StockItem, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[sku]
+	if ok {
+		entry := el.Value.(*cacheEntry)
+		if c.now().Sub(entry.cachedAt) < c.ttl() {
+			c.lru.MoveToFront(el)
+
+			if c.metrics != nil {
+				c.metrics.Hits.Inc()
+			}
+
+			return entry.item, true
+		}
+
+		c.remove(el, evictionExpired)
+		c.updateSize()
+	}
+
+	if c.metrics != nil {
+		c.metrics.Misses.Inc()
+	}
+
+	return models.StockItem{}, false
+}
+
+func (c *Cache) ttl() time.Duration {
+	if c.online {
+		return c.cfg.TTL
+	}
+
+	return c.cfg.OfflineTTL
+}
+
+func (c *Cache) beginFetch(skus ...uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, sku := range skus {
+		c.fetching[sku]++
+	}
+}
+
+// endFetch caches the fetched items of skus that were not invalidated while
+// they were being fetched.
+func (c *Cache) endFetch(fetched map[uint32]models.StockItem, skus ...uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	for _, sku := range skus {
+		item, ok := fetched[sku]
+		if ok && !c.invalidated[sku] {
+			c.store(item, now)
+		}
+
+		c.fetching[sku]--
+		if c.fetching[sku] <= 0 {
+			delete(c.fetching, sku)
+			delete(c.invalidated, sku)
+		}
+	}
+
+	c.updateSize()
+}
+
+func (c *Cache) store(item models.StockItem, now time.Time) {
+	if c.cfg.Size <= 0 {
+		return
+	}
+
+	if el, ok := c.entries[item.SKU]; ok {
+		el.Value = &cacheEntry{item: item, cachedAt: now}
+		c.lru.MoveToFront(el)
+
+		return
+	}
+
+	for c.lru.Len() >= c.cfg.Size {
+		c.remove(c.lru.Back(), evictionCapacity)
+	}
+
+	c.entries[item.SKU] = c.lru.PushFront(&cacheEntry{item: item, cachedAt: now})
+}
+
+func (c *Cache) remove(el *list.Element, reason string) {
+	entry := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, entry.item.SKU)
+
+	if c.metrics != nil {
+		c.metrics.Evictions.WithLabelValues(reason).Inc()
+	}
+}
+
+func (c *Cache) updateSize() {
+	if c.metrics != nil {
+		c.metrics.Entries.Set(float64(c.lru.Len()))
+	}
+}
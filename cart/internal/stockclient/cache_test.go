@@ -0,0 +1,246 @@
+package stockclient
+
+import (
+	"cart/internal/log/zap"
+	"cart/internal/models"
+	"context"
+	stdErr "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingRepo serves every SKU as in stock and counts the SKUs it was asked
+// for. onFetch, when set, runs before it answers.
+type countingRepo struct {
+	mu      sync.Mutex
+	fetched map[uint32]int
+	reduced int
+	err     error
+	onFetch func()
+}
+
+func (r *countingRepo) GetBySKU(_ context.Context, sku uint32) (models.StockItem, error) {
+	items, err := r.GetBySKUs(context.Background(), []uint32{sku})
+	if err != nil {
+		return models.StockItem{}, err
+	}
+
+	return items[sku], nil
+}
+
+func (r *countingRepo) GetBySKUs(_ context.Context, skus []uint32) (map[uint32]models.StockItem, error) {
+	if r.onFetch != nil {
+		r.onFetch()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	items := make(map[uint32]models.StockItem, len(skus))
+	for _, sku := range skus {
+		r.fetched[sku]++
+		items[sku] = models.StockItem{SKU: sku, Count: 10}
+	}
+
+	return items, nil
+}
+
+func (r *countingRepo) ReduceStock(context.Context, int64, []models.OrderItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reduced++
+
+	return r.err
+}
+
+func newTestCache(t *testing.T, cfg CacheConfig) (*Cache, *countingRepo, *time.Time) {
+	t.Helper()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	repo := &countingRepo{fetched: make(map[uint32]int)}
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	cache := NewCache(repo, cfg, logger, nil)
+	cache.now = func() time.Time { return now }
+	cache.SetOnline(true)
+
+	return cache, repo, &now
+}
+
+func TestCache_GetBySKU(t *testing.T) {
+	t.Parallel()
+
+	cache, repo, now := newTestCache(t, CacheConfig{Size: 10, TTL: time.Minute, OfflineTTL: time.Second})
+	ctx := context.Background()
+
+	for range 3 {
+		item, err := cache.GetBySKU(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int16(10), item.Count)
+	}
+	assert.Equal(t, 1, repo.fetched[1], "repeated lookups are served from the cache")
+
+	*now = now.Add(time.Minute)
+	_, err := cache.GetBySKU(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, repo.fetched[1], "an expired entry is fetched again")
+
+	cache.SetOnline(false)
+	*now = now.Add(2 * time.Second)
+	_, err = cache.GetBySKU(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, repo.fetched[1], "offline, entries expire after the offline TTL")
+}
+
+func TestCache_GetBySKUs(t *testing.T) {
+	t.Parallel()
+
+	cache, repo, _ := newTestCache(t, CacheConfig{Size: 10, TTL: time.Minute})
+	ctx := context.Background()
+
+	_, err := cache.GetBySKU(ctx, 1)
+	assert.NoError(t, err)
+
+	items, err := cache.GetBySKUs(ctx, []uint32{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, map[uint32]int{1: 1, 2: 1, 3: 1}, repo.fetched, "only the missing SKUs are fetched")
+
+	items, err = cache.GetBySKUs(ctx, []uint32{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, map[uint32]int{1: 1, 2: 1, 3: 1}, repo.fetched)
+
+	repo.err = stdErr.New("stocks down")
+	cache.Invalidate(2)
+
+	_, err = cache.GetBySKUs(ctx, []uint32{1, 2})
+	assert.ErrorIs(t, err, repo.err)
+}
+
+func TestCache_Fresh(t *testing.T) {
+	t.Parallel()
+
+	cache, repo, _ := newTestCache(t, CacheConfig{Size: 10, TTL: time.Minute})
+	ctx := context.Background()
+
+	_, err := cache.GetBySKUs(ctx, []uint32{1, 2})
+	assert.NoError(t, err)
+
+	_, err = cache.GetBySKU(Fresh(ctx), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, repo.fetched[1], "a fresh lookup skips the cached entry")
+
+	items, err := cache.GetBySKUs(Fresh(ctx), []uint32{1, 2})
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, map[uint32]int{1: 3, 2: 2}, repo.fetched)
+
+	_, err = cache.GetBySKU(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, repo.fetched[1], "what a fresh lookup fetched is cached")
+}
+
+func TestCache_Eviction(t *testing.T) {
+	t.Parallel()
+
+	cache, repo, _ := newTestCache(t, CacheConfig{Size: 2, TTL: time.Minute})
+	ctx := context.Background()
+
+	for _, sku := range []uint32{1, 2, 1, 3} {
+		_, err := cache.GetBySKU(ctx, sku)
+		assert.NoError(t, err)
+	}
+
+	// 2 was the least recently used when 3 came in.
+	_, err := cache.GetBySKUs(ctx, []uint32{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]int{1: 1, 2: 2, 3: 1}, repo.fetched)
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drops the entry", func(t *testing.T) {
+		t.Parallel()
+
+		cache, repo, _ := newTestCache(t, CacheConfig{Size: 10, TTL: time.Minute})
+		ctx := context.Background()
+
+		_, err := cache.GetBySKU(ctx, 1)
+		assert.NoError(t, err)
+
+		cache.Invalidate(1)
+
+		_, err = cache.GetBySKU(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, repo.fetched[1])
+	})
+
+	t.Run("during a fetch", func(t *testing.T) {
+		t.Parallel()
+
+		cache, repo, _ := newTestCache(t, CacheConfig{Size: 10, TTL: time.Minute})
+		ctx := context.Background()
+
+		repo.onFetch = func() {
+			repo.onFetch = nil
+			cache.Invalidate(1)
+		}
+
+		_, err := cache.GetBySKU(ctx, 1)
+		assert.NoError(t, err)
+
+		_, err = cache.GetBySKU(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, repo.fetched[1], "a result read before the change is not cached")
+
+		_, err = cache.GetBySKU(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, repo.fetched[1])
+	})
+
+	t.Run("on reduce stock", func(t *testing.T) {
+		t.Parallel()
+
+		cache, repo, _ := newTestCache(t, CacheConfig{Size: 10, TTL: time.Minute})
+		ctx := context.Background()
+
+		_, err := cache.GetBySKUs(ctx, []uint32{1, 2})
+		assert.NoError(t, err)
+
+		err = cache.ReduceStock(ctx, 42, []models.OrderItem{{SKU: 1, Count: 1}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, repo.reduced)
+
+		_, err = cache.GetBySKUs(ctx, []uint32{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, map[uint32]int{1: 2, 2: 1}, repo.fetched)
+	})
+}
+
+func TestCache_Disabled(t *testing.T) {
+	t.Parallel()
+
+	cache, repo, _ := newTestCache(t, CacheConfig{Size: 0, TTL: time.Minute})
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := cache.GetBySKU(ctx, 1)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, repo.fetched[1])
+}
@@ -0,0 +1,78 @@
+package stockclient
+
+import "time"
+
+const (
+	DefaultMaxAttempts      = 3
+	DefaultBackoffBase      = 100 * time.Millisecond
+	DefaultBackoffMax       = time.Second
+	DefaultAttemptTimeout   = 2 * time.Second
+	DefaultBreakerFailures  = 5
+	DefaultBreakerCooldown  = 10 * time.Second
+	DefaultCacheSize        = 10000
+	DefaultCacheTTL         = time.Minute
+	DefaultCacheOfflineTTL  = 5 * time.Second
+	defaultTimeout          = 5 * time.Second
+	maxHedgedRequests       = 2
+	idempotencyKeyHeader    = "idempotency-key"
+	circuitOpenErrorMessage = "stock service circuit breaker is open"
+)
+
+type Config struct {
+	Addr string
+	// ServiceToken is presented for callers without a token of their own,
+	// such as guests; it may be empty.
+	ServiceToken string
+
+	// MaxAttempts bounds the attempts of a call, the first one included.
+	MaxAttempts int
+	// Retries wait a random duration up to BackoffBase doubled per attempt,
+	// capped at BackoffMax.
+	BackoffBase    time.Duration
+	BackoffMax     time.Duration
+	AttemptTimeout time.Duration
+
+	// The circuit opens after BreakerFailures consecutive failed attempts and
+	// lets a probe through after BreakerCooldown.
+	BreakerFailures int
+	BreakerCooldown time.Duration
+
+	// HedgeDelay, when positive, sends a second read to the stocks service if
+	// the first has not answered within it; the first answer wins.
+	HedgeDelay time.Duration
+}
+
+// DefaultConfig has retries and the circuit breaker on and hedging off.
+func DefaultConfig(addr, serviceToken string) Config {
+	return Config{
+		Addr:            addr,
+		ServiceToken:    serviceToken,
+		MaxAttempts:     DefaultMaxAttempts,
+		BackoffBase:     DefaultBackoffBase,
+		BackoffMax:      DefaultBackoffMax,
+		AttemptTimeout:  DefaultAttemptTimeout,
+		BreakerFailures: DefaultBreakerFailures,
+		BreakerCooldown: DefaultBreakerCooldown,
+	}
+}
+
+// CacheConfig bounds the stock cache. Stock events invalidate entries as
+// stock changes; the TTLs bound how stale an entry can get when an event is
+// missed.
+type CacheConfig struct {
+	// Size is the maximum number of cached items; zero turns the cache off.
+	Size int
+	// TTL is how long an item is served while stock events are consumed.
+	TTL time.Duration
+	// OfflineTTL replaces TTL while the stock events consumer is not
+	// connected, since no invalidation can reach the cache then.
+	OfflineTTL time.Duration
+}
+
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		Size:       DefaultCacheSize,
+		TTL:        DefaultCacheTTL,
+		OfflineTTL: DefaultCacheOfflineTTL,
+	}
+}
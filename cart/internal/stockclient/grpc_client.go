@@ -1,9 +1,12 @@
 package stockclient
 
 import (
+	"cart/internal/auth"
+	"cart/internal/errors"
 	"cart/internal/log"
 	"cart/internal/metrics"
 	"cart/internal/models"
+	"cart/internal/money"
 	"context"
 	"fmt"
 	"strconv"
@@ -12,39 +15,72 @@ import (
 	stockpb "cart/pkg/api/stocks"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-const (
-	defaultTimeout = 5 * time.Second
-)
-
 type GRPCClient struct {
-	client  stockpb.StockServiceClient
-	conn    *grpc.ClientConn
-	logger  log.Logger
-	metrics *metrics.Metrics
+	client        stockpb.StockServiceClient
+	conn          *grpc.ClientConn
+	cfg           Config
+	breaker       *breaker
+	logger        log.Logger
+	metrics       *metrics.Metrics
+	clientMetrics *metrics.StockClientMetrics
 }
 
-func NewGRPCClient(addr string, logger log.Logger, m *metrics.Metrics) (*GRPCClient, error) {
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewGRPCClient connects to the Stocks service.
+func NewGRPCClient(cfg Config, logger log.Logger, m *metrics.Metrics, cm *metrics.StockClientMetrics) (*GRPCClient, error) {
+	conn, err := grpc.NewClient(cfg.Addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor(cfg.ServiceToken)),
+	)
 	if err != nil {
-		logger.Error("failed to dial stock service", log.String("address", addr), log.Error(err))
+		logger.Error("failed to dial stock service", log.String("address", cfg.Addr), log.Error(err))
 		return nil, fmt.Errorf("invalid stock service URL: %w", err)
 	}
 
-	logger.Info("connected to stock service", log.String("address", addr))
+	logger.Info("connected to stock service",
+		log.String("address", cfg.Addr),
+		log.Int("max_attempts", cfg.MaxAttempts),
+		log.String("hedge_delay", cfg.HedgeDelay.String()),
+	)
 
-	client := stockpb.NewStockServiceClient(conn)
-	return &GRPCClient{
-		client:  client,
-		conn:    conn,
-		logger:  logger,
-		metrics: m,
-	}, nil
+	c := newClient(stockpb.NewStockServiceClient(conn), cfg, logger, m, cm)
+	c.conn = conn
+
+	return c, nil
+}
+
+func newClient(client stockpb.StockServiceClient, cfg Config, logger log.Logger, m *metrics.Metrics, cm *metrics.StockClientMetrics) *GRPCClient {
+	c := &GRPCClient{
+		client:        client,
+		cfg:           cfg,
+		logger:        logger,
+		metrics:       m,
+		clientMetrics: cm,
+	}
+
+	c.breaker = newBreaker(cfg.BreakerFailures, cfg.BreakerCooldown, c.circuitChanged)
+
+	return c
+}
+
+func (c *GRPCClient) circuitChanged(from, to circuitState) {
+	c.logger.Warn("stock service circuit breaker changed state",
+		log.String("from", from.String()),
+		log.String("to", to.String()),
+	)
+
+	if c.clientMetrics != nil {
+		c.clientMetrics.CircuitState.Set(float64(to))
+		c.clientMetrics.CircuitTransitions.WithLabelValues(to.String()).Inc()
+	}
 }
 
 func (c *GRPCClient) Close() error {
@@ -74,12 +110,14 @@ func (c *GRPCClient) GetBySKU(ctx context.Context, sku uint32) (models.StockItem
 	defer cancel()
 
 	start := time.Now()
+	// No location: ask for the stock summed over every warehouse.
 	req := &stockpb.GetItemRequest{
-		Sku:      skuStr,
-		Location: "default-location",
+		Sku: skuStr,
 	}
 
-	resp, err := c.client.GetItem(ctx, req)
+	resp, err := call(ctx, c, "GetItem", true, func(ctx context.Context) (*stockpb.StockItem, error) {
+		return c.client.GetItem(ctx, req)
+	})
 	duration := time.Since(start).Seconds()
 
 	if c.metrics != nil {
@@ -93,14 +131,173 @@ func (c *GRPCClient) GetBySKU(ctx context.Context, sku uint32) (models.StockItem
 
 	if err != nil {
 		c.logger.Error("failed to get stock item", log.String("sku", skuStr), log.Error(err))
-		return models.StockItem{}, fmt.Errorf("create request: %w", err)
+		return models.StockItem{}, stockError("get item", err)
+	}
+
+	price, err := stockPrice(resp)
+	if err != nil {
+		c.logger.Error("stock service returned invalid price", log.String("sku", skuStr), log.Error(err))
+		return models.StockItem{}, err
 	}
 
 	return models.StockItem{
 		SKU:      sku,
+		Name:     resp.Name,
+		Type:     resp.Type,
 		Location: resp.Location,
-		Count:    int16(resp.Count),
-		Price:    float64(resp.Price),
+		Count:    int16(resp.Available),
+		Price:    price,
+		Archived: resp.GetArchived(),
 	}, nil
+}
+
+// GetBySKUs fetches all skus in a single call. SKUs unknown to the stocks
+// service are left out of the returned map.
+func (c *GRPCClient) GetBySKUs(ctx context.Context, skus []uint32) (map[uint32]models.StockItem, error) {
+	tracer := otel.Tracer("stockclient")
+	ctx, span := tracer.Start(ctx, "GetBySKUs")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("stock.skus_count", len(skus)),
+	)
+
+	items := make(map[uint32]models.StockItem, len(skus))
+	if len(skus) == 0 {
+		return items, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	req := &stockpb.GetItemsRequest{
+		Skus: make([]string, 0, len(skus)),
+	}
+	for _, sku := range skus {
+		req.Skus = append(req.Skus, strconv.FormatUint(uint64(sku), 10))
+	}
+
+	start := time.Now()
+	resp, err := call(ctx, c, "GetItems", true, func(ctx context.Context) (*stockpb.GetItemsResponse, error) {
+		return c.client.GetItems(ctx, req)
+	})
+	duration := time.Since(start).Seconds()
+
+	if c.metrics != nil {
+		c.metrics.RequestsTotal.WithLabelValues("stockclient.GetBySKUs", "GRPC").Inc()
+		c.metrics.RequestDuration.WithLabelValues("stockclient.GetBySKUs", "GRPC").Observe(duration)
+
+		if err != nil {
+			c.metrics.RequestErrors.WithLabelValues("stockclient.GetBySKUs", "GRPC").Inc()
+		}
+	}
+
+	if err != nil {
+		c.logger.Error("failed to get stock items", log.Int("skus_count", len(skus)), log.Error(err))
+		return nil, stockError("get items", err)
+	}
+
+	for _, result := range resp.GetResults() {
+		if result.GetStatus() != stockpb.ItemStatus_ITEM_STATUS_FOUND {
+			continue
+		}
 
+		sku, err := strconv.ParseUint(result.GetSku(), 10, 32)
+		if err != nil {
+			c.logger.Error("stock service returned invalid sku", log.String("sku", result.GetSku()), log.Error(err))
+			continue
+		}
+
+		item := result.GetItem()
+
+		price, err := stockPrice(item)
+		if err != nil {
+			c.logger.Error("stock service returned invalid price", log.String("sku", result.GetSku()), log.Error(err))
+			continue
+		}
+
+		items[uint32(sku)] = models.StockItem{
+			SKU:      uint32(sku),
+			Name:     item.GetName(),
+			Type:     item.GetType(),
+			Location: item.GetLocation(),
+			Count:    int16(item.GetAvailable()),
+			Price:    price,
+			Archived: item.GetArchived(),
+		}
+	}
+
+	return items, nil
+}
+
+// stockPrice prefers the exact unit_price and falls back to the deprecated
+// float for stocks deployments that do not send it yet.
+func stockPrice(item *stockpb.StockItem) (money.Money, error) {
+	if item.GetUnitPrice() == nil {
+		return money.FromFloat(money.DefaultCurrency, float64(item.GetPrice())), nil
+	}
+
+	return money.FromProto(item.GetUnitPrice())
+}
+
+func (c *GRPCClient) ReduceStock(ctx context.Context, orderID int64, items []models.OrderItem) error {
+	tracer := otel.Tracer("stockclient")
+	ctx, span := tracer.Start(ctx, "ReduceStock")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("order.id", orderID),
+		attribute.Int("stock.items_count", len(items)),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	reqItems := make([]*stockpb.ReduceStockItem, 0, len(items))
+	for _, item := range items {
+		reqItems = append(reqItems, &stockpb.ReduceStockItem{
+			Sku:   strconv.FormatUint(uint64(item.SKU), 10),
+			Count: int32(item.Count),
+		})
+	}
+
+	// The key names the order, so the stocks service applies the reduction
+	// once however many attempts, or calls for the order, reach it.
+	ctx = metadata.AppendToOutgoingContext(ctx, idempotencyKeyHeader, orderIdempotencyKey(orderID))
+
+	// Buyers may not write stock off themselves: cart does it as a service,
+	// on behalf of the buyer checking out.
+	var buyerID uint64
+	if identity, ok := auth.FromContext(ctx); ok && identity.UserID > 0 {
+		buyerID = uint64(identity.UserID)
+	}
+
+	ctx = auth.AsService(ctx)
+
+	start := time.Now()
+	_, err := call(ctx, c, "ReduceStock", false, func(ctx context.Context) (*stockpb.StockResponse, error) {
+		return c.client.ReduceStock(ctx, &stockpb.ReduceStockRequest{Items: reqItems, UserId: buyerID})
+	})
+	duration := time.Since(start).Seconds()
+
+	if c.metrics != nil {
+		c.metrics.RequestsTotal.WithLabelValues("stockclient.ReduceStock", "GRPC").Inc()
+		c.metrics.RequestDuration.WithLabelValues("stockclient.ReduceStock", "GRPC").Observe(duration)
+
+		if err != nil {
+			c.metrics.RequestErrors.WithLabelValues("stockclient.ReduceStock", "GRPC").Inc()
+		}
+	}
+
+	if err != nil {
+		c.logger.Error("failed to reduce stock", log.Int("items_count", len(items)), log.Error(err))
+
+		if status.Code(err) == codes.FailedPrecondition {
+			return errors.ErrNotEnoughStock
+		}
+
+		return stockError("reduce stock", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,252 @@
+package stockclient
+
+import (
+	"cart/internal/auth"
+	"cart/internal/errors"
+	"cart/internal/log/zap"
+	stockpb "cart/pkg/api/stocks"
+	"context"
+	stdErr "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStocks answers GetItem and ReduceStock from scripted errors, in order;
+// once the script runs out every call succeeds.
+type fakeStocks struct {
+	stockpb.StockServiceClient
+
+	mu    sync.Mutex
+	errs  []error
+	delay time.Duration
+	calls int
+	keys  []string
+	// userIDs are the users ReduceStock was called for.
+	userIDs []uint64
+}
+
+func (f *fakeStocks) next(ctx context.Context) error {
+	f.mu.Lock()
+	f.calls++
+	call := f.calls
+
+	var err error
+	if len(f.errs) > 0 {
+		err, f.errs = f.errs[0], f.errs[1:]
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		f.keys = append(f.keys, md.Get(idempotencyKeyHeader)...)
+	}
+	f.mu.Unlock()
+
+	// Only the first call is slow, so a hedged request overtakes it.
+	if call == 1 && f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	return err
+}
+
+func (f *fakeStocks) GetItem(ctx context.Context, in *stockpb.GetItemRequest, _ ...grpc.CallOption) (*stockpb.StockItem, error) {
+	if err := f.next(ctx); err != nil {
+		return nil, err
+	}
+
+	return &stockpb.StockItem{Sku: in.GetSku(), Name: "t-shirt", Available: 5}, nil
+}
+
+func (f *fakeStocks) ReduceStock(ctx context.Context, in *stockpb.ReduceStockRequest, _ ...grpc.CallOption) (*stockpb.StockResponse, error) {
+	f.mu.Lock()
+	f.userIDs = append(f.userIDs, in.GetUserId())
+	f.mu.Unlock()
+
+	if err := f.next(ctx); err != nil {
+		return nil, err
+	}
+
+	return &stockpb.StockResponse{}, nil
+}
+
+func testConfig() Config {
+	cfg := DefaultConfig("", "")
+	cfg.BackoffBase = time.Millisecond
+	cfg.BackoffMax = time.Millisecond
+
+	return cfg
+}
+
+func TestGRPCClient_GetBySKU(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   error
+		wantCode  codes.Code
+		wantCalls int
+	}{
+		{
+			name:      "retries transient failures",
+			errs:      []error{unavailable, unavailable},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after the last attempt",
+			errs:      []error{unavailable, unavailable, unavailable},
+			wantErr:   errors.ErrStocksUnavailable,
+			wantCode:  codes.Unavailable,
+			wantCalls: 3,
+		},
+		{
+			name:      "not found is not retried",
+			errs:      []error{status.Error(codes.NotFound, "sku not found")},
+			wantErr:   errors.ErrInvalidSKU,
+			wantCalls: 1,
+		},
+		{
+			name:      "permission denied is not retried",
+			errs:      []error{status.Error(codes.PermissionDenied, "denied")},
+			wantCode:  codes.PermissionDenied,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stocks := &fakeStocks{errs: tt.errs}
+			c := newClient(stocks, testConfig(), logger, nil, nil)
+
+			item, err := c.GetBySKU(context.Background(), 100)
+
+			assert.Equal(t, tt.wantCalls, stocks.calls)
+
+			if tt.wantErr == nil && tt.wantCode == codes.OK {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				assert.Equal(t, "t-shirt", item.Name)
+
+				return
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestGRPCClient_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	stocks := &fakeStocks{errs: []error{unavailable, unavailable, unavailable}}
+
+	cfg := testConfig()
+	cfg.BreakerFailures = 3
+	cfg.BreakerCooldown = time.Hour
+
+	c := newClient(stocks, cfg, logger, nil, nil)
+
+	_, err = c.GetBySKU(context.Background(), 100)
+	assert.ErrorIs(t, err, errors.ErrStocksUnavailable)
+
+	_, err = c.GetBySKU(context.Background(), 100)
+	assert.ErrorIs(t, err, errors.ErrStocksUnavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, stocks.calls, "an open circuit fails fast")
+}
+
+func TestGRPCClient_Hedging(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	stocks := &fakeStocks{delay: time.Second}
+
+	cfg := testConfig()
+	cfg.HedgeDelay = 10 * time.Millisecond
+
+	c := newClient(stocks, cfg, logger, nil, nil)
+
+	start := time.Now()
+
+	item, err := c.GetBySKU(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, "t-shirt", item.Name)
+	assert.Less(t, time.Since(start), time.Second, "the hedged request answers first")
+	assert.Equal(t, 2, stocks.calls)
+}
+
+func TestGRPCClient_ReduceStock(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	stocks := &fakeStocks{errs: []error{status.Error(codes.Unavailable, "connection reset")}}
+	c := newClient(stocks, testConfig(), logger, nil, nil)
+
+	err = c.ReduceStock(auth.NewContext(context.Background(), auth.Identity{UserID: 7}), 42, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, []uint64{7, 7}, stocks.userIDs, "stock is reduced on behalf of the buyer")
+
+	if len(stocks.keys) != 2 {
+		t.Fatalf("expected an idempotency key on both attempts, got %v", stocks.keys)
+	}
+
+	assert.Equal(t, []string{"cart-order-42", "cart-order-42"}, stocks.keys, "the key names the order, on every attempt")
+
+	stocks = &fakeStocks{errs: []error{status.Error(codes.FailedPrecondition, "not enough stock")}}
+	c = newClient(stocks, testConfig(), logger, nil, nil)
+
+	err = c.ReduceStock(context.Background(), 42, nil)
+	assert.True(t, stdErr.Is(err, errors.ErrNotEnoughStock))
+	assert.Equal(t, 1, stocks.calls)
+}
@@ -0,0 +1,163 @@
+package stockclient
+
+import (
+	"cart/internal/errors"
+	"context"
+	"fmt"
+	randv2 "math/rand/v2"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type result[T any] struct {
+	value T
+	err   error
+}
+
+// call runs fn under the circuit breaker, retrying transient failures with
+// jittered backoff. Reads may be hedged; writes must be safe to retry.
+func call[T any](ctx context.Context, c *GRPCClient, method string, hedge bool, fn func(ctx context.Context) (T, error)) (T, error) {
+	var (
+		value T
+		err   error
+	)
+
+	for i := 0; i < max(c.cfg.MaxAttempts, 1); i++ {
+		if i > 0 {
+			if c.clientMetrics != nil {
+				c.clientMetrics.Retries.WithLabelValues(method).Inc()
+			}
+
+			if sleepErr := sleep(ctx, c.backoff(i)); sleepErr != nil {
+				return value, err
+			}
+		}
+
+		if !c.breaker.allow() {
+			return value, status.Error(codes.Unavailable, circuitOpenErrorMessage)
+		}
+
+		value, err = attempt(ctx, c, method, hedge, fn)
+
+		c.breaker.record(isFailure(err) && ctx.Err() == nil)
+
+		if err == nil || !isRetryable(err) || ctx.Err() != nil {
+			return value, err
+		}
+	}
+
+	return value, err
+}
+
+// attempt makes one call bounded by the attempt timeout. A hedged attempt
+// sends a second request if the first is slow and returns the first success.
+func attempt[T any](ctx context.Context, c *GRPCClient, method string, hedge bool, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.AttemptTimeout)
+	defer cancel()
+
+	if !hedge || c.cfg.HedgeDelay <= 0 {
+		return fn(ctx)
+	}
+
+	results := make(chan result[T], maxHedgedRequests)
+	send := func() {
+		value, err := fn(ctx)
+		results <- result[T]{value: value, err: err}
+	}
+
+	go send()
+
+	sent, inFlight := 1, 1
+
+	timer := time.NewTimer(c.cfg.HedgeDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if sent < maxHedgedRequests {
+				if c.clientMetrics != nil {
+					c.clientMetrics.Hedges.WithLabelValues(method).Inc()
+				}
+
+				go send()
+
+				sent++
+				inFlight++
+			}
+		case res := <-results:
+			inFlight--
+
+			if res.err == nil || inFlight == 0 {
+				return res.value, res.err
+			}
+		}
+	}
+}
+
+func (c *GRPCClient) backoff(attempt int) time.Duration {
+	ceiling := c.cfg.BackoffBase
+	for i := 1; i < attempt && ceiling < c.cfg.BackoffMax; i++ {
+		ceiling *= 2
+	}
+
+	ceiling = min(ceiling, c.cfg.BackoffMax)
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return randv2.N(ceiling + 1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// isFailure tells the circuit breaker whether the stocks service is unwell;
+// answers such as NotFound mean it is fine.
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
+
+// stockError maps a stocks error to a cart error. Transient failures become
+// ErrStocksUnavailable and keep the Unavailable code for cart's own callers.
+func stockError(op string, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return errors.ErrInvalidSKU
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return fmt.Errorf("%s: %w: %w", op, errors.ErrStocksUnavailable,
+			status.Error(codes.Unavailable, status.Convert(err).Message()))
+	default:
+		return fmt.Errorf("%s: %w", op, err)
+	}
+}
+
+func orderIdempotencyKey(orderID int64) string {
+	return "cart-order-" + strconv.FormatInt(orderID, 10)
+}
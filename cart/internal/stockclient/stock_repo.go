@@ -9,4 +9,8 @@ import (
 
 type StockRepository interface {
 	GetBySKU(ctx context.Context, sku uint32) (models.StockItem, error)
+	GetBySKUs(ctx context.Context, skus []uint32) (map[uint32]models.StockItem, error)
+	// ReduceStock writes the items of order orderID off. Calls for the same
+	// order reduce the stock once.
+	ReduceStock(ctx context.Context, orderID int64, items []models.OrderItem) error
 }
@@ -2,29 +2,45 @@ package usecase
 
 import (
 	"cart/internal/errors"
+	"cart/internal/guest"
 	"cart/internal/kafka"
 	"cart/internal/log"
 	"cart/internal/models"
+	"cart/internal/money"
+	"cart/internal/promotion"
 	"cart/internal/repository"
 	"cart/internal/stockclient"
 	"context"
+	stdErrors "errors"
+	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 )
 
+// abandonedBatchSize bounds the carts loaded per query by NotifyAbandonedCarts.
+const abandonedBatchSize = 100
+
 type cartUseCase struct {
 	repo      repository.CartRepository
+	orderRepo repository.OrderRepository
+	promoRepo repository.PromotionRepository
+	guestRepo repository.GuestCartRepository
 	stockRepo stockclient.StockRepository
 	producer  kafka.ProducerInterface
 	logger    log.Logger
 }
 
-func NewCartUsecase(repo repository.CartRepository, stockRepo stockclient.StockRepository, producer kafka.ProducerInterface, logger log.Logger) CartUseCase {
+func NewCartUsecase(repo repository.CartRepository, orderRepo repository.OrderRepository, promoRepo repository.PromotionRepository, guestRepo repository.GuestCartRepository, stockRepo stockclient.StockRepository, producer kafka.ProducerInterface, logger log.Logger) CartUseCase {
 	return &cartUseCase{
 		repo:      repo,
+		orderRepo: orderRepo,
+		promoRepo: promoRepo,
+		guestRepo: guestRepo,
 		stockRepo: stockRepo,
 		producer:  producer,
 		logger:    logger,
@@ -46,6 +62,18 @@ func (u *cartUseCase) sendFailedEvent(ctx context.Context, userID int64, sku uin
 	}
 }
 
+// sendClearedEvent publishes cart_cleared for a cart that held items; failures
+// are only logged since the cart is already empty.
+func (u *cartUseCase) sendClearedEvent(ctx context.Context, cartID int64, items []models.CartItem, reason models.ClearReason) {
+	if len(items) == 0 {
+		return
+	}
+
+	if err := u.producer.SendCartCleared(ctx, cartID, items, reason); err != nil {
+		u.logger.Error("failed to send CartCleared event", log.Int64("cart_id", cartID), log.Error(err))
+	}
+}
+
 func (u *cartUseCase) Add(ctx context.Context, item models.CartItem) error {
 	tracer := otel.Tracer("cart-usecase")
 	ctx, span := tracer.Start(ctx, "Add")
@@ -58,7 +86,7 @@ func (u *cartUseCase) Add(ctx context.Context, item models.CartItem) error {
 	)
 
 	stockItem, err := u.stockRepo.GetBySKU(ctx, item.SKU)
-	if err != nil {
+	if stdErrors.Is(err, errors.ErrInvalidSKU) {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid SKU")
 		u.sendFailedEvent(ctx, item.UserID, item.SKU, item.Count, "invalid SKU - not registered")
@@ -66,6 +94,21 @@ func (u *cartUseCase) Add(ctx context.Context, item models.CartItem) error {
 		return errors.ErrInvalidSKU
 	}
 
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "stocks unavailable")
+		u.sendFailedEvent(ctx, item.UserID, item.SKU, item.Count, "stock service unavailable")
+		u.logger.Error("stockRepo.GetBySKU failed", log.Error(err))
+		return err
+	}
+
+	if stockItem.Archived {
+		span.SetStatus(codes.Error, "archived SKU")
+		u.sendFailedEvent(ctx, item.UserID, item.SKU, item.Count, "SKU is archived")
+		u.logger.Warn("SKU is archived", log.UInt32("sku", item.SKU))
+		return errors.ErrSKUArchived
+	}
+
 	if item.Count > stockItem.Count {
 		reason := "not enough stock"
 		span.SetStatus(codes.Error, reason)
@@ -100,27 +143,640 @@ func (u *cartUseCase) Add(ctx context.Context, item models.CartItem) error {
 }
 
 func (u *cartUseCase) Delete(ctx context.Context, userID int64, sku uint32) error {
-	return u.repo.Delete(ctx, userID, sku)
+	tracer := otel.Tracer("cart-usecase")
+	ctx, span := tracer.Start(ctx, "Delete")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("user.id", userID),
+		attribute.Int64("item.sku", int64(sku)),
+	)
+
+	count, err := u.repo.Delete(ctx, userID, sku)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "delete failed")
+		return err
+	}
+
+	err = u.producer.SendCartItemRemoved(
+		ctx,
+		strconv.FormatInt(userID, 10),
+		strconv.FormatUint(uint64(sku), 10),
+		int(count),
+	)
+	if err != nil {
+		u.logger.Error("failed to send CartItemRemoved event", log.Error(err))
+		span.RecordError(err)
+	}
+
+	span.SetStatus(codes.Ok, "success")
+	return nil
 }
 
-func (u *cartUseCase) List(ctx context.Context, userID int64) ([]models.CartItem, error) {
+func (u *cartUseCase) List(ctx context.Context, userID int64) (models.Cart, error) {
 	items, err := u.repo.List(ctx, userID)
 	if err != nil {
-		return nil, err
+		return models.Cart{}, err
+	}
+
+	cart := models.Cart{
+		UserID: userID,
+		Items:  items,
+	}
+
+	if len(items) == 0 {
+		return cart, nil
+	}
+
+	skus := make([]uint32, 0, len(items))
+	for _, item := range items {
+		skus = append(skus, item.SKU)
+	}
+
+	stockItems, err := u.stockRepo.GetBySKUs(ctx, skus)
+	if err != nil {
+		return models.Cart{}, err
 	}
 
 	for i := range items {
-		stockItem, err := u.stockRepo.GetBySKU(ctx, items[i].SKU)
-		if err != nil {
-			return nil, err
+		stockItem, ok := stockItems[items[i].SKU]
+		if !ok {
+			u.logger.Warn("cart item not found in stocks", log.UInt32("sku", items[i].SKU))
 		}
+
+		items[i].Name = stockItem.Name
+		items[i].Type = stockItem.Type
 		items[i].Price = stockItem.Price
-		items[i].Count = stockItem.Count
+		items[i].Stock = stockItem.Count
+		items[i].Unfulfillable = !ok || stockItem.Archived || items[i].Count > stockItem.Count
+
+		cart.Subtotal, err = cart.Subtotal.Add(items[i].LineTotal())
+		if err != nil {
+			u.logger.Error("cart mixes currencies", log.Int64("user_id", userID), log.Error(err))
+			return models.Cart{}, err
+		}
+	}
+
+	if err = u.applyPromotions(ctx, &cart); err != nil {
+		return models.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+// applyPromotions re-validates the promo codes applied to the cart against
+// the current prices and fills in the discount and total.
+func (u *cartUseCase) applyPromotions(ctx context.Context, cart *models.Cart) error {
+	promotions, err := u.promoRepo.ListApplied(ctx, cart.UserID)
+	if err != nil {
+		u.logger.Error("promoRepo.ListApplied failed", log.Int64("user_id", cart.UserID), log.Error(err))
+		return err
+	}
+
+	cart.Promotions, cart.Discount, err = promotion.Apply(cart.Items, promotions, cart.Subtotal, time.Now())
+	if err != nil {
+		u.logger.Error("failed to apply promotions", log.Int64("user_id", cart.UserID), log.Error(err))
+		return err
+	}
+
+	for _, applied := range cart.Promotions {
+		if !applied.Applied() {
+			u.logger.Info("promotion no longer applies",
+				log.Int64("user_id", cart.UserID),
+				log.String("code", applied.Promotion.Code),
+				log.String("reason", applied.Reason),
+			)
+		}
+	}
+
+	cart.TotalPrice, err = cart.Subtotal.Sub(cart.Discount)
+
+	return err
+}
+
+func (u *cartUseCase) ApplyPromoCode(ctx context.Context, userID int64, code string) error {
+	tracer := otel.Tracer("cart-usecase")
+	ctx, span := tracer.Start(ctx, "ApplyPromoCode")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("user.id", userID),
+		attribute.String("promo.code", code),
+	)
+
+	promo, err := u.promoRepo.GetByCode(ctx, code)
+	if err != nil {
+		span.SetStatus(codes.Error, "unknown promo code")
+		u.logger.Warn("promoRepo.GetByCode failed", log.String("code", code), log.Error(err))
+		return err
+	}
+
+	cart, err := u.List(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "list cart failed")
+		return err
+	}
+
+	if len(cart.Items) == 0 {
+		span.SetStatus(codes.Error, "empty cart")
+		return errors.ErrEmptyCart
+	}
+
+	// The code must earn a discount on the cart as it is now; whether it
+	// still does is checked again on every list and at checkout.
+	if _, err = promotion.Discount(promo, cart.Items, time.Now()); err != nil {
+		span.SetStatus(codes.Error, "promotion does not apply")
+		u.logger.Warn("promotion does not apply",
+			log.Int64("user_id", userID),
+			log.String("code", code),
+			log.Error(err),
+		)
+		return err
+	}
+
+	if err = u.promoRepo.Apply(ctx, userID, promo.ID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "apply failed")
+		u.logger.Error("promoRepo.Apply failed", log.Int64("user_id", userID), log.String("code", code), log.Error(err))
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "success")
+	return nil
+}
+
+func (u *cartUseCase) RemovePromoCode(ctx context.Context, userID int64, code string) error {
+	return u.promoRepo.Remove(ctx, userID, code)
+}
+
+// applyOrderPromotions discounts the order by the promo codes that still apply
+// at checkout prices. Codes that no longer apply are dropped from the order
+// rather than failing the checkout.
+func (u *cartUseCase) applyOrderPromotions(ctx context.Context, order *models.Order, items []models.CartItem, subtotal money.Money) error {
+	promotions, err := u.promoRepo.ListApplied(ctx, order.UserID)
+	if err != nil {
+		u.logger.Error("promoRepo.ListApplied failed", log.Int64("user_id", order.UserID), log.Error(err))
+		return err
+	}
+
+	applied, discount, err := promotion.Apply(items, promotions, subtotal, time.Now())
+	if err != nil {
+		u.logger.Error("failed to apply promotions", log.Int64("user_id", order.UserID), log.Error(err))
+		return err
+	}
+
+	for _, a := range applied {
+		if !a.Applied() {
+			u.logger.Warn("promotion dropped at checkout",
+				log.Int64("user_id", order.UserID),
+				log.String("code", a.Promotion.Code),
+				log.String("reason", a.Reason),
+			)
+
+			continue
+		}
+
+		order.Promotions = append(order.Promotions, a)
 	}
 
-	return items, nil
+	order.Subtotal = subtotal
+	order.Discount = discount
+	order.TotalPrice, err = subtotal.Sub(discount)
+
+	return err
 }
 
 func (u *cartUseCase) Clear(ctx context.Context, userID int64) error {
-	return u.repo.Clear(ctx, userID)
+	tracer := otel.Tracer("cart-usecase")
+	ctx, span := tracer.Start(ctx, "Clear")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("user.id", userID))
+
+	items, err := u.repo.Clear(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "db error")
+		return err
+	}
+
+	u.sendClearedEvent(ctx, userID, items, models.ClearReasonUser)
+
+	span.SetStatus(codes.Ok, "success")
+	return nil
+}
+
+func (u *cartUseCase) Checkout(ctx context.Context, userID int64) (models.Order, error) {
+	tracer := otel.Tracer("cart-usecase")
+	ctx, span := tracer.Start(ctx, "Checkout")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("user.id", userID))
+
+	items, err := u.repo.List(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "db error")
+		return models.Order{}, err
+	}
+
+	if len(items) == 0 {
+		span.SetStatus(codes.Error, "empty cart")
+		return models.Order{}, errors.ErrEmptyCart
+	}
+
+	order := models.Order{
+		UserID: userID,
+		Status: models.OrderStatusNew,
+		Items:  make([]models.OrderItem, 0, len(items)),
+	}
+
+	var subtotal money.Money
+
+	priced := make([]models.CartItem, 0, len(items))
+
+	// Orders are priced against the current stock, never a cached copy.
+	stockCtx := stockclient.Fresh(ctx)
+
+	for _, item := range items {
+		stockItem, err := u.stockRepo.GetBySKU(stockCtx, item.SKU)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "stock lookup failed")
+			u.logger.Error("stockRepo.GetBySKU failed", log.UInt32("sku", item.SKU), log.Error(err))
+			return models.Order{}, err
+		}
+
+		if stockItem.Archived {
+			span.SetStatus(codes.Error, "archived SKU")
+			u.logger.Warn("SKU is archived", log.UInt32("sku", item.SKU))
+			return models.Order{}, errors.ErrSKUArchived
+		}
+
+		if item.Count > stockItem.Count {
+			span.SetStatus(codes.Error, "not enough stock")
+			u.logger.Warn("not enough stock",
+				log.UInt32("sku", item.SKU),
+				log.Int16("requested", item.Count),
+				log.Int16("available", stockItem.Count),
+			)
+			return models.Order{}, errors.ErrNotEnoughStock
+		}
+
+		order.Items = append(order.Items, models.OrderItem{
+			SKU:   item.SKU,
+			Count: item.Count,
+			Price: stockItem.Price,
+		})
+
+		priced = append(priced, models.CartItem{
+			UserID: userID,
+			SKU:    item.SKU,
+			Count:  item.Count,
+			Price:  stockItem.Price,
+			Type:   stockItem.Type,
+		})
+
+		subtotal, err = subtotal.Add(stockItem.Price.Mul(int64(item.Count)))
+		if err != nil {
+			span.SetStatus(codes.Error, "currency mismatch")
+			u.logger.Warn("cart mixes currencies", log.UInt32("sku", item.SKU), log.Error(err))
+			return models.Order{}, err
+		}
+	}
+
+	if err = u.applyOrderPromotions(ctx, &order, priced, subtotal); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "promotions failed")
+		return models.Order{}, err
+	}
+
+	order.ID, err = u.orderRepo.Create(ctx, order)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "db error")
+		u.logger.Error("orderRepo.Create failed", log.Error(err))
+		return models.Order{}, err
+	}
+
+	err = u.stockRepo.ReduceStock(ctx, order.ID, order.Items)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "reduce stock failed")
+		u.logger.Error("stockRepo.ReduceStock failed", log.Int64("order_id", order.ID), log.Error(err))
+
+		// Promotion uses were counted when the order was created; nobody
+		// received them, so they are given back, even when the reduction
+		// failed because the request was cancelled.
+		if statusErr := u.orderRepo.Fail(context.WithoutCancel(ctx), order.ID); statusErr != nil {
+			u.logger.Error("failed to mark order as failed", log.Int64("order_id", order.ID), log.Error(statusErr))
+		}
+
+		return models.Order{}, err
+	}
+
+	// Stock is written off from here on, so the steps left are logged rather
+	// than returned when they fail: a retried checkout would place a second
+	// order.
+	order.Status = models.OrderStatusCreated
+
+	err = u.orderRepo.UpdateStatus(ctx, order.ID, models.OrderStatusCreated)
+	if err != nil {
+		span.RecordError(err)
+		u.logger.Error("failed to mark order as created", log.Int64("order_id", order.ID), log.Error(err))
+	}
+
+	cleared, err := u.repo.Clear(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		u.logger.Error("failed to clear cart after checkout", log.Int64("order_id", order.ID), log.Error(err))
+	}
+
+	u.sendClearedEvent(ctx, userID, cleared, models.ClearReasonCheckout)
+
+	if err = u.producer.SendOrderCreated(ctx, order); err != nil {
+		span.RecordError(err)
+		u.logger.Error("failed to send OrderCreated event", log.Error(err))
+	}
+
+	span.SetStatus(codes.Ok, "success")
+	return order, nil
+}
+
+// CreateGuestCart issues the token of a new, empty guest cart.
+func (u *cartUseCase) CreateGuestCart(ctx context.Context) (string, error) {
+	token, hash, err := guest.NewToken()
+	if err != nil {
+		return "", err
+	}
+
+	guestID, err := u.guestRepo.Create(ctx, hash)
+	if err != nil {
+		u.logger.Error("guestRepo.Create failed", log.Error(err))
+		return "", err
+	}
+
+	u.logger.Info("guest cart created", log.Int64("guest_id", guestID))
+
+	return token, nil
+}
+
+// MergeCarts folds the guest cart of cartToken into the user's cart. Quantities
+// are summed and capped at the available stock, but the user's own quantity is
+// never reduced. The guest cart is removed, unless lines were added to it
+// during the merge. It fails with ErrGuestCartChanged if a guest line changed
+// during the merge.
+func (u *cartUseCase) MergeCarts(ctx context.Context, userID int64, cartToken string) (models.MergeReport, error) {
+	tracer := otel.Tracer("cart-usecase")
+	ctx, span := tracer.Start(ctx, "MergeCarts")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("user.id", userID))
+
+	guestID, err := u.guestRepo.GuestIDByToken(ctx, guest.Hash(cartToken))
+	if err != nil {
+		span.SetStatus(codes.Error, "unknown guest cart")
+		u.logger.Warn("guestRepo.GuestIDByToken failed", log.Int64("user_id", userID), log.Error(err))
+		return models.MergeReport{}, err
+	}
+
+	guestItems, err := u.repo.List(ctx, models.GuestCartID(guestID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "db error")
+		return models.MergeReport{}, err
+	}
+
+	userItems, err := u.repo.List(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "db error")
+		return models.MergeReport{}, err
+	}
+
+	userCounts := make(map[uint32]int16, len(userItems))
+	for _, item := range userItems {
+		userCounts[item.SKU] = item.Count
+	}
+
+	var stockItems map[uint32]models.StockItem
+
+	if len(guestItems) > 0 {
+		skus := make([]uint32, 0, len(guestItems))
+		for _, item := range guestItems {
+			skus = append(skus, item.SKU)
+		}
+
+		// Merged counts are capped by the current stock, not a cached copy.
+		stockItems, err = u.stockRepo.GetBySKUs(stockclient.Fresh(ctx), skus)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "stocks unavailable")
+			u.logger.Error("stockRepo.GetBySKUs failed", log.Error(err))
+			return models.MergeReport{}, err
+		}
+	}
+
+	report := models.MergeReport{Items: make([]models.MergedItem, 0, len(guestItems))}
+	added := make([]models.CartItem, 0, len(guestItems))
+
+	for _, item := range guestItems {
+		stockItem, ok := stockItems[item.SKU]
+		result := mergeItem(item.Count, userCounts[item.SKU], stockItem, ok)
+		result.SKU = item.SKU
+
+		report.Items = append(report.Items, result)
+
+		if result.Count > result.UserCount {
+			added = append(added, models.CartItem{
+				UserID: userID,
+				SKU:    item.SKU,
+				Count:  result.Count - result.UserCount,
+				Price:  stockItem.Price,
+			})
+		}
+	}
+
+	// Both carts were read outside the transaction: Merge adds to the user's
+	// lines instead of overwriting them and fails if the guest lines changed.
+	if err = u.repo.Merge(ctx, guestID, userID, guestItems, added); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "db error")
+		u.logger.Error("repo.Merge failed", log.Int64("user_id", userID), log.Error(err))
+		return models.MergeReport{}, err
+	}
+
+	for _, result := range report.Items {
+		if added := result.Count - result.UserCount; added > 0 {
+			err = u.producer.SendCartItemAdded(
+				ctx,
+				strconv.FormatInt(userID, 10),
+				strconv.FormatUint(uint64(result.SKU), 10),
+				int(added),
+				"merged",
+			)
+			if err != nil {
+				u.logger.Error("failed to send CartItemAdded event", log.Error(err))
+				span.RecordError(err)
+			}
+		}
+	}
+
+	u.sendClearedEvent(ctx, models.GuestCartID(guestID), guestItems, models.ClearReasonMerged)
+
+	u.logger.Info("guest cart merged",
+		log.Int64("user_id", userID),
+		log.Int64("guest_id", guestID),
+		log.Int("lines", len(report.Items)),
+	)
+
+	span.SetStatus(codes.Ok, "success")
+	return report, nil
+}
+
+// NotifyAbandonedCarts publishes cart_abandoned for every user cart untouched
+// since idleSince, once per period of inactivity. Each cart is claimed before
+// its event is sent, so instances running side by side never announce the
+// same cart twice. A cart whose event fails is released for the next run.
+func (u *cartUseCase) NotifyAbandonedCarts(ctx context.Context, idleSince time.Time) (int, error) {
+	tracer := otel.Tracer("cart-usecase")
+	ctx, span := tracer.Start(ctx, "NotifyAbandonedCarts")
+	defer span.End()
+
+	notified := 0
+
+	for {
+		carts, err := u.repo.ListAbandoned(ctx, idleSince, abandonedBatchSize)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "db error")
+			u.logger.Error("repo.ListAbandoned failed", log.Error(err))
+			return notified, err
+		}
+
+		for _, cart := range carts {
+			claimed, err := u.repo.ClaimAbandoned(ctx, cart)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "db error")
+				u.logger.Error("repo.ClaimAbandoned failed", log.Int64("user_id", cart.UserID), log.Error(err))
+				return notified, err
+			}
+
+			if !claimed {
+				continue
+			}
+
+			if cart.Items, err = u.repo.List(ctx, cart.UserID); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "db error")
+				u.releaseAbandoned(ctx, cart)
+				return notified, err
+			}
+
+			if err = u.producer.SendCartAbandoned(ctx, cart); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "kafka error")
+				u.logger.Error("failed to send CartAbandoned event", log.Int64("user_id", cart.UserID), log.Error(err))
+				u.releaseAbandoned(ctx, cart)
+				return notified, err
+			}
+
+			notified++
+		}
+
+		if len(carts) < abandonedBatchSize {
+			break
+		}
+	}
+
+	span.SetAttributes(attribute.Int("carts.notified", notified))
+	span.SetStatus(codes.Ok, "success")
+
+	return notified, nil
+}
+
+// releaseAbandoned gives up the claim on a cart that was not announced, even
+// when ctx is already cancelled; a failure is only logged, the cart then waits
+// for its next period of inactivity.
+func (u *cartUseCase) releaseAbandoned(ctx context.Context, cart models.AbandonedCart) {
+	if err := u.repo.ReleaseAbandoned(context.WithoutCancel(ctx), cart); err != nil {
+		u.logger.Error("repo.ReleaseAbandoned failed", log.Int64("user_id", cart.UserID), log.Error(err))
+	}
+}
+
+// PurgeIdleCarts deletes every cart, guest carts included, untouched since
+// idleSince, and returns how many were deleted.
+func (u *cartUseCase) PurgeIdleCarts(ctx context.Context, idleSince time.Time) (int64, error) {
+	tracer := otel.Tracer("cart-usecase")
+	ctx, span := tracer.Start(ctx, "PurgeIdleCarts")
+	defer span.End()
+
+	lines, err := u.repo.PurgeIdle(ctx, idleSince)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "db error")
+		u.logger.Error("repo.PurgeIdle failed", log.Error(err))
+		return 0, err
+	}
+
+	carts := make(map[int64][]models.CartItem)
+	order := make([]int64, 0)
+
+	for _, line := range lines {
+		if _, ok := carts[line.UserID]; !ok {
+			order = append(order, line.UserID)
+		}
+
+		carts[line.UserID] = append(carts[line.UserID], line)
+	}
+
+	for _, cartID := range order {
+		u.sendClearedEvent(ctx, cartID, carts[cartID], models.ClearReasonExpired)
+	}
+
+	purged := int64(len(order))
+
+	span.SetAttributes(attribute.Int64("carts.purged", purged))
+	span.SetStatus(codes.Ok, "success")
+
+	return purged, nil
+}
+
+func mergeItem(guestCount, userCount int16, stockItem models.StockItem, found bool) models.MergedItem {
+	result := models.MergedItem{
+		GuestCount: guestCount,
+		UserCount:  userCount,
+		Count:      userCount,
+		Adjustment: models.MergeAdjustmentDropped,
+	}
+
+	switch {
+	case !found:
+		result.Reason = errors.ErrInvalidSKU.Error()
+		return result
+	case stockItem.Archived:
+		result.Reason = errors.ErrSKUArchived.Error()
+		return result
+	}
+
+	wanted := int(guestCount) + int(userCount)
+	count := min(wanted, int(stockItem.Count), math.MaxInt16)
+
+	if count <= int(userCount) {
+		result.Reason = fmt.Sprintf("only %d available", stockItem.Count)
+		return result
+	}
+
+	result.Count = int16(count)
+	result.Adjustment = models.MergeAdjustmentNone
+
+	if count < wanted {
+		result.Adjustment = models.MergeAdjustmentCapped
+		result.Reason = fmt.Sprintf("only %d available", stockItem.Count)
+	}
+
+	return result
 }
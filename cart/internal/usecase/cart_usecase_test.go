@@ -2,13 +2,17 @@ package usecase
 
 import (
 	"cart/internal/errors"
+	"cart/internal/guest"
 	"cart/internal/log/zap"
 	"cart/internal/models"
+	"cart/internal/money"
+	"cart/internal/stockclient"
 	"cart/internal/usecase/mocks"
 	"context"
 	stdErr "errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -49,7 +53,7 @@ func TestCartUseCase_Add(t *testing.T) {
 		{
 			name: "invalid sku error",
 			mockSetup: func(mockStockRepo *mocks.MockStockRepository, mockCartRepo *mocks.MockCartRepository, mockProducer *mocks.MockProducerInterface) {
-				mockStockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(models.StockItem{}, stdErr.New("not found"))
+				mockStockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(models.StockItem{}, errors.ErrInvalidSKU)
 				mockProducer.EXPECT().
 					SendCartItemFailed(gomock.Any(), fmt.Sprint(item.UserID), fmt.Sprint(item.SKU), int(item.Count), "failed", "invalid SKU - not registered").
 					Return(nil)
@@ -57,6 +61,17 @@ func TestCartUseCase_Add(t *testing.T) {
 			wantErr: errors.ErrInvalidSKU,
 		},
 
+		{
+			name: "stocks unavailable",
+			mockSetup: func(mockStockRepo *mocks.MockStockRepository, mockCartRepo *mocks.MockCartRepository, mockProducer *mocks.MockProducerInterface) {
+				mockStockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(models.StockItem{}, fmt.Errorf("get item: %w", errors.ErrStocksUnavailable))
+				mockProducer.EXPECT().
+					SendCartItemFailed(gomock.Any(), fmt.Sprint(item.UserID), fmt.Sprint(item.SKU), int(item.Count), "failed", "stock service unavailable").
+					Return(nil)
+			},
+			wantErr: errors.ErrStocksUnavailable,
+		},
+
 		{
 			name: "not enough stock error",
 			mockSetup: func(mockStockRepo *mocks.MockStockRepository, mockCartRepo *mocks.MockCartRepository, mockProducer *mocks.MockProducerInterface) {
@@ -70,6 +85,19 @@ func TestCartUseCase_Add(t *testing.T) {
 			wantErr: errors.ErrNotEnoughStock,
 		},
 
+		{
+			name: "archived sku error",
+			mockSetup: func(mockStockRepo *mocks.MockStockRepository, mockCartRepo *mocks.MockCartRepository, mockProducer *mocks.MockProducerInterface) {
+				stockItem := models.StockItem{SKU: 100, Count: 10, Archived: true}
+				mockStockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(stockItem, nil)
+				mockProducer.EXPECT().
+					SendCartItemFailed(gomock.Any(), fmt.Sprint(item.UserID), fmt.Sprint(item.SKU), int(item.Count), "failed", "SKU is archived").
+					Return(nil)
+			},
+
+			wantErr: errors.ErrSKUArchived,
+		},
+
 		{
 			name: "repo error on upsert",
 			mockSetup: func(mockStockRepo *mocks.MockStockRepository, mockCartRepo *mocks.MockCartRepository, mockProducer *mocks.MockProducerInterface) {
@@ -100,7 +128,7 @@ func TestCartUseCase_Add(t *testing.T) {
 			}
 			defer cleanup()
 
-			u := NewCartUsecase(mockCartRepo, mockStockRepo, mockProducer, logger)
+			u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mockProducer, logger)
 
 			tt.mockSetup(mockStockRepo, mockCartRepo, mockProducer)
 
@@ -142,7 +170,7 @@ func TestCartUseCase_Delete(t *testing.T) {
 	}
 	defer cleanup()
 
-	u := NewCartUsecase(mockCartRepo, mockStockRepo, mockProducer, logger)
+	u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mockProducer, logger)
 
 	ctx := context.Background()
 	userID := int64(1)
@@ -156,7 +184,16 @@ func TestCartUseCase_Delete(t *testing.T) {
 		{
 			name: "success",
 			mockSetup: func() {
-				mockCartRepo.EXPECT().Delete(ctx, userID, sku).Return(nil)
+				mockCartRepo.EXPECT().Delete(gomock.Any(), userID, sku).Return(int16(2), nil)
+				mockProducer.EXPECT().SendCartItemRemoved(gomock.Any(), "1", "100", 2).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "event error is not returned",
+			mockSetup: func() {
+				mockCartRepo.EXPECT().Delete(gomock.Any(), userID, sku).Return(int16(1), nil)
+				mockProducer.EXPECT().SendCartItemRemoved(gomock.Any(), "1", "100", 1).Return(stdErr.New("kafka error"))
 			},
 			wantErr: nil,
 		},
@@ -164,7 +201,7 @@ func TestCartUseCase_Delete(t *testing.T) {
 
 			name: "repo error",
 			mockSetup: func() {
-				mockCartRepo.EXPECT().Delete(ctx, userID, sku).Return(stdErr.New("db error"))
+				mockCartRepo.EXPECT().Delete(gomock.Any(), userID, sku).Return(int16(0), stdErr.New("db error"))
 			},
 			wantErr: stdErr.New("db error"),
 		},
@@ -201,37 +238,142 @@ func TestCartUseCase_List(t *testing.T) {
 
 	stockItem1 := models.StockItem{
 		SKU:   100,
-		Price: 9.99,
+		Name:  "t-shirt",
+		Type:  "apparel",
+		Price: money.New("RUB", 999),
 		Count: 5,
 	}
 
 	stockItem2 := models.StockItem{
 		SKU:   101,
-		Price: 19.99,
+		Price: money.New("RUB", 1999),
 		Count: 7,
 	}
 
 	tests := []struct {
-		name         string
-		mockSetup    func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository)
-		wantLen      int
-		wantErr      bool
-		wantErrIs    error
-		wantPriceSKU map[uint32]float64
+		name              string
+		mockSetup         func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository)
+		wantLen           int
+		wantErr           bool
+		wantErrIs         error
+		wantErrWraps      error
+		wantPriceSKU      map[uint32]money.Money
+		wantUnfulfillable map[uint32]bool
+		wantCountSKU      map[uint32]int16
+		promotions        []models.Promotion
+		wantApplied       map[string]bool
+		wantDiscount      money.Money
+		wantTotal         money.Money
 	}{
 
 		{
 			name: "success",
 			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
-				cartRepo.EXPECT().List(ctx, userID).Return(cartItems, nil)
-				stockRepo.EXPECT().GetBySKU(ctx, uint32(100)).Return(stockItem1, nil)
-				stockRepo.EXPECT().GetBySKU(ctx, uint32(101)).Return(stockItem2, nil)
+				cartRepo.EXPECT().List(ctx, userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(ctx, []uint32{100, 101}).Return(map[uint32]models.StockItem{
+					100: stockItem1,
+					101: stockItem2,
+				}, nil)
+			},
+			wantLen: 2,
+			wantPriceSKU: map[uint32]money.Money{
+				100: money.New("RUB", 999),
+				101: money.New("RUB", 1999),
+			},
+			wantTotal: money.New("RUB", 7995),
+		},
+		{
+			name: "line exceeding stock is flagged",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
+				cartRepo.EXPECT().List(ctx, userID).Return([]models.CartItem{
+					{UserID: userID, SKU: 100, Count: 6},
+				}, nil)
+				stockRepo.EXPECT().GetBySKUs(ctx, []uint32{100}).Return(map[uint32]models.StockItem{
+					100: stockItem1,
+				}, nil)
+			},
+			wantLen:           1,
+			wantPriceSKU:      map[uint32]money.Money{100: money.New("RUB", 999)},
+			wantUnfulfillable: map[uint32]bool{100: true},
+			wantCountSKU:      map[uint32]int16{100: 6},
+			wantTotal:         money.New("RUB", 5994),
+		},
+		{
+			name: "archived sku is flagged",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
+				archived := stockItem1
+				archived.Archived = true
+
+				cartRepo.EXPECT().List(ctx, userID).Return([]models.CartItem{
+					{UserID: userID, SKU: 100, Count: 1},
+				}, nil)
+				stockRepo.EXPECT().GetBySKUs(ctx, []uint32{100}).Return(map[uint32]models.StockItem{
+					100: archived,
+				}, nil)
+			},
+			wantLen:           1,
+			wantPriceSKU:      map[uint32]money.Money{100: money.New("RUB", 999)},
+			wantUnfulfillable: map[uint32]bool{100: true},
+			wantTotal:         money.New("RUB", 999),
+		},
+		{
+			name: "missing sku is kept without price",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
+				cartRepo.EXPECT().List(ctx, userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(ctx, []uint32{100, 101}).Return(map[uint32]models.StockItem{
+					100: stockItem1,
+				}, nil)
 			},
 			wantLen: 2,
-			wantPriceSKU: map[uint32]float64{
-				100: 9.99,
-				101: 19.99,
+			wantPriceSKU: map[uint32]money.Money{
+				100: money.New("RUB", 999),
 			},
+			wantUnfulfillable: map[uint32]bool{101: true},
+			wantTotal:         money.New("RUB", 1998),
+		},
+		{
+			name: "applied promotions are re-validated",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
+				cartRepo.EXPECT().List(ctx, userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(ctx, []uint32{100, 101}).Return(map[uint32]models.StockItem{
+					100: stockItem1,
+					101: stockItem2,
+				}, nil)
+			},
+			promotions: []models.Promotion{
+				{Code: "TEN", Kind: models.PromotionPercentage, Percent: 10},
+				{Code: "OLD", Kind: models.PromotionPercentage, Percent: 50, EndsAt: time.Now().Add(-time.Hour)},
+			},
+			wantLen: 2,
+			wantPriceSKU: map[uint32]money.Money{
+				100: money.New("RUB", 999),
+				101: money.New("RUB", 1999),
+			},
+			wantApplied:  map[string]bool{"TEN": true, "OLD": false},
+			wantDiscount: money.New("RUB", 800),
+			wantTotal:    money.New("RUB", 7195),
+		},
+		{
+			name: "mixed currencies",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
+				foreign := stockItem2
+				foreign.Price = money.New("USD", 1999)
+
+				cartRepo.EXPECT().List(ctx, userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(ctx, []uint32{100, 101}).Return(map[uint32]models.StockItem{
+					100: stockItem1,
+					101: foreign,
+				}, nil)
+			},
+			wantErr:      true,
+			wantErrWraps: errors.ErrCurrencyMismatch,
+		},
+		{
+			name: "empty cart skips stocks call",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
+				cartRepo.EXPECT().List(ctx, userID).Return([]models.CartItem{}, nil)
+			},
+			wantLen: 0,
 		},
 		{
 
@@ -245,8 +387,8 @@ func TestCartUseCase_List(t *testing.T) {
 		{
 			name: "stock get error",
 			mockSetup: func(cartRepo *mocks.MockCartRepository, stockRepo *mocks.MockStockRepository) {
-				cartRepo.EXPECT().List(ctx, userID).Return(cartItems, nil)
-				stockRepo.EXPECT().GetBySKU(ctx, uint32(100)).Return(models.StockItem{}, stdErr.New("not found"))
+				cartRepo.EXPECT().List(ctx, userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(ctx, []uint32{100, 101}).Return(nil, stdErr.New("unavailable"))
 			},
 			wantErr: true,
 		},
@@ -269,7 +411,10 @@ func TestCartUseCase_List(t *testing.T) {
 			}
 			defer cleanup()
 
-			u := NewCartUsecase(mockCartRepo, mockStockRepo, mockProducer, logger)
+			mockPromoRepo := mocks.NewMockPromotionRepository(ctrl)
+			mockPromoRepo.EXPECT().ListApplied(ctx, userID).Return(tt.promotions, nil).AnyTimes()
+
+			u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mockPromoRepo, mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mockProducer, logger)
 
 			tt.mockSetup(mockCartRepo, mockStockRepo)
 
@@ -277,10 +422,23 @@ func TestCartUseCase_List(t *testing.T) {
 
 			if !tt.wantErr {
 				assert.NoError(t, err)
-				assert.Len(t, result, tt.wantLen)
+				assert.Equal(t, userID, result.UserID)
+				assert.Len(t, result.Items, tt.wantLen)
+				assert.Equal(t, tt.wantTotal, result.TotalPrice)
+				assert.Equal(t, tt.wantDiscount.Cents, result.Discount.Cents)
+				assert.Len(t, result.Promotions, len(tt.wantApplied))
+
+				for _, p := range result.Promotions {
+					assert.Equal(t, tt.wantApplied[p.Promotion.Code], p.Applied(), p.Promotion.Code)
+				}
+
+				for _, r := range result.Items {
+					assert.Equal(t, tt.wantPriceSKU[r.SKU], r.Price)
+					assert.Equal(t, tt.wantUnfulfillable[r.SKU], r.Unfulfillable)
 
-				for _, r := range result {
-					assert.InDelta(t, tt.wantPriceSKU[r.SKU], r.Price, 0.0001)
+					if tt.wantCountSKU != nil {
+						assert.Equal(t, tt.wantCountSKU[r.SKU], r.Count)
+					}
 				}
 			} else {
 				assert.Error(t, err)
@@ -288,6 +446,10 @@ func TestCartUseCase_List(t *testing.T) {
 				if tt.wantErrIs != nil {
 					assert.EqualError(t, err, tt.wantErrIs.Error())
 				}
+
+				if tt.wantErrWraps != nil {
+					assert.ErrorIs(t, err, tt.wantErrWraps)
+				}
 			}
 		})
 	}
@@ -309,7 +471,7 @@ func TestCartUseCase_Clear(t *testing.T) {
 	}
 	defer cleanup()
 
-	u := NewCartUsecase(mockCartRepo, mockStockRepo, mockProducer, logger)
+	u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mockProducer, logger)
 
 	ctx := context.Background()
 	userID := int64(1)
@@ -322,7 +484,17 @@ func TestCartUseCase_Clear(t *testing.T) {
 		{
 			name: "success",
 			mockSetup: func() {
-				mockCartRepo.EXPECT().Clear(ctx, userID).Return(nil)
+				items := []models.CartItem{{UserID: userID, SKU: 100, Count: 2}}
+
+				mockCartRepo.EXPECT().Clear(gomock.Any(), userID).Return(items, nil)
+				mockProducer.EXPECT().SendCartCleared(gomock.Any(), userID, items, models.ClearReasonUser).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "empty cart publishes nothing",
+			mockSetup: func() {
+				mockCartRepo.EXPECT().Clear(gomock.Any(), userID).Return(nil, nil)
 			},
 			wantErr: nil,
 		},
@@ -330,7 +502,7 @@ func TestCartUseCase_Clear(t *testing.T) {
 		{
 			name: "repo error",
 			mockSetup: func() {
-				mockCartRepo.EXPECT().Clear(ctx, userID).Return(stdErr.New("db error"))
+				mockCartRepo.EXPECT().Clear(gomock.Any(), userID).Return(nil, stdErr.New("db error"))
 			},
 			wantErr: stdErr.New("db error"),
 		},
@@ -350,3 +522,750 @@ func TestCartUseCase_Clear(t *testing.T) {
 		})
 	}
 }
+
+func TestCartUseCase_Checkout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := int64(1)
+
+	cartItems := []models.CartItem{
+		{UserID: userID, SKU: 100, Count: 2},
+		{UserID: userID, SKU: 101, Count: 1},
+	}
+
+	orderItems := []models.OrderItem{
+		{SKU: 100, Count: 2, Price: money.New("RUB", 1000)},
+		{SKU: 101, Count: 1, Price: money.New("RUB", 500)},
+	}
+
+	newOrder := models.Order{
+		UserID:     userID,
+		Status:     models.OrderStatusNew,
+		Items:      orderItems,
+		Subtotal:   money.New("RUB", 2500),
+		Discount:   money.New("RUB", 0),
+		TotalPrice: money.New("RUB", 2500),
+	}
+
+	tests := []struct {
+		name       string
+		mockSetup  func(cartRepo *mocks.MockCartRepository, orderRepo *mocks.MockOrderRepository, stockRepo *mocks.MockStockRepository, producer *mocks.MockProducerInterface)
+		wantErr    error
+		wantOrder  int64
+		wantStatus models.OrderStatus
+	}{
+		{
+			name: "success",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, orderRepo *mocks.MockOrderRepository, stockRepo *mocks.MockStockRepository, producer *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(cartItems, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 5, Price: money.New("RUB", 1000)}, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(101)).Return(models.StockItem{SKU: 101, Count: 5, Price: money.New("RUB", 500)}, nil)
+				orderRepo.EXPECT().Create(gomock.Any(), newOrder).Return(int64(42), nil)
+				stockRepo.EXPECT().ReduceStock(gomock.Any(), int64(42), orderItems).Return(nil)
+				orderRepo.EXPECT().UpdateStatus(gomock.Any(), int64(42), models.OrderStatusCreated).Return(nil)
+				cartRepo.EXPECT().Clear(gomock.Any(), userID).Return(cartItems, nil)
+				producer.EXPECT().SendCartCleared(gomock.Any(), userID, cartItems, models.ClearReasonCheckout).Return(nil)
+				producer.EXPECT().SendOrderCreated(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantOrder:  42,
+			wantStatus: models.OrderStatusCreated,
+		},
+		{
+			name: "status update failure after the reduction is not returned",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, orderRepo *mocks.MockOrderRepository, stockRepo *mocks.MockStockRepository, producer *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(cartItems, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 5, Price: money.New("RUB", 1000)}, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(101)).Return(models.StockItem{SKU: 101, Count: 5, Price: money.New("RUB", 500)}, nil)
+				orderRepo.EXPECT().Create(gomock.Any(), newOrder).Return(int64(42), nil)
+				stockRepo.EXPECT().ReduceStock(gomock.Any(), int64(42), orderItems).Return(nil)
+				orderRepo.EXPECT().UpdateStatus(gomock.Any(), int64(42), models.OrderStatusCreated).Return(stdErr.New("db down"))
+				// The cart is still cleared, so a retry finds nothing to order.
+				cartRepo.EXPECT().Clear(gomock.Any(), userID).Return(cartItems, nil)
+				producer.EXPECT().SendCartCleared(gomock.Any(), userID, cartItems, models.ClearReasonCheckout).Return(nil)
+				producer.EXPECT().SendOrderCreated(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantOrder:  42,
+			wantStatus: models.OrderStatusCreated,
+		},
+		{
+			name: "empty cart",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, _ *mocks.MockOrderRepository, _ *mocks.MockStockRepository, _ *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(nil, nil)
+			},
+			wantErr: errors.ErrEmptyCart,
+		},
+		{
+			name: "not enough stock",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, _ *mocks.MockOrderRepository, stockRepo *mocks.MockStockRepository, _ *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(cartItems, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 1, Price: money.New("RUB", 1000)}, nil)
+			},
+			wantErr: errors.ErrNotEnoughStock,
+		},
+		{
+			name: "archived sku",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, _ *mocks.MockOrderRepository, stockRepo *mocks.MockStockRepository, _ *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(cartItems, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 5, Price: money.New("RUB", 1000), Archived: true}, nil)
+			},
+			wantErr: errors.ErrSKUArchived,
+		},
+		{
+			name: "reduce stock failure marks order failed",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, orderRepo *mocks.MockOrderRepository, stockRepo *mocks.MockStockRepository, _ *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(cartItems, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 5, Price: money.New("RUB", 1000)}, nil)
+				stockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(101)).Return(models.StockItem{SKU: 101, Count: 5, Price: money.New("RUB", 500)}, nil)
+				orderRepo.EXPECT().Create(gomock.Any(), newOrder).Return(int64(42), nil)
+				stockRepo.EXPECT().ReduceStock(gomock.Any(), int64(42), orderItems).Return(errors.ErrNotEnoughStock)
+				orderRepo.EXPECT().Fail(gomock.Any(), int64(42)).Return(nil)
+			},
+			wantErr: errors.ErrNotEnoughStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCartRepo := mocks.NewMockCartRepository(ctrl)
+			mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+			mockStockRepo := mocks.NewMockStockRepository(ctrl)
+			mockProducer := mocks.NewMockProducerInterface(ctrl)
+
+			logger, cleanup, err := zap.NewLogger()
+			if err != nil {
+				t.Fatalf("failed to create logger: %v", err)
+			}
+			defer cleanup()
+
+			mockPromoRepo := mocks.NewMockPromotionRepository(ctrl)
+			mockPromoRepo.EXPECT().ListApplied(gomock.Any(), userID).Return(nil, nil).AnyTimes()
+
+			u := NewCartUsecase(mockCartRepo, mockOrderRepo, mockPromoRepo, mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mockProducer, logger)
+
+			tt.mockSetup(mockCartRepo, mockOrderRepo, mockStockRepo, mockProducer)
+
+			order, err := u.Checkout(ctx, userID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOrder, order.ID)
+			assert.Equal(t, tt.wantStatus, order.Status)
+			assert.Equal(t, newOrder.TotalPrice, order.TotalPrice)
+		})
+	}
+}
+
+func TestCartUseCase_Checkout_Promotions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := int64(1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockPromoRepo := mocks.NewMockPromotionRepository(ctrl)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	mockProducer := mocks.NewMockProducerInterface(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	buyTwoGetOne := models.Promotion{ID: 3, Code: "B2G1", Kind: models.PromotionBuyXGetY, BuyCount: 2, FreeCount: 1, SKUType: "apparel"}
+	usedUp := models.Promotion{ID: 4, Code: "GONE", Kind: models.PromotionPercentage, Percent: 10, MaxUses: 1, Uses: 1}
+
+	orderItems := []models.OrderItem{
+		{SKU: 100, Count: 3, Price: money.New("RUB", 1000)},
+	}
+
+	wantOrder := models.Order{
+		UserID: userID,
+		Status: models.OrderStatusNew,
+		Items:  orderItems,
+		Promotions: []models.AppliedPromotion{
+			{Promotion: buyTwoGetOne, Discount: money.New("RUB", 1000)},
+		},
+		Subtotal:   money.New("RUB", 3000),
+		Discount:   money.New("RUB", 1000),
+		TotalPrice: money.New("RUB", 2000),
+	}
+
+	mockCartRepo.EXPECT().List(gomock.Any(), userID).Return([]models.CartItem{{UserID: userID, SKU: 100, Count: 3}}, nil)
+	mockStockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Type: "apparel", Count: 5, Price: money.New("RUB", 1000)}, nil)
+	mockPromoRepo.EXPECT().ListApplied(gomock.Any(), userID).Return([]models.Promotion{buyTwoGetOne, usedUp}, nil)
+	mockOrderRepo.EXPECT().Create(gomock.Any(), wantOrder).Return(int64(42), nil)
+	mockStockRepo.EXPECT().ReduceStock(gomock.Any(), int64(42), orderItems).Return(nil)
+	mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), int64(42), models.OrderStatusCreated).Return(nil)
+	mockCartRepo.EXPECT().Clear(gomock.Any(), userID).Return(nil, nil)
+	mockProducer.EXPECT().SendOrderCreated(gomock.Any(), gomock.Any()).Return(nil)
+
+	u := NewCartUsecase(mockCartRepo, mockOrderRepo, mockPromoRepo, mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mockProducer, logger)
+
+	order, err := u.Checkout(ctx, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, money.New("RUB", 2000), order.TotalPrice)
+	assert.Len(t, order.Promotions, 1, "promotions that no longer apply are dropped")
+}
+
+func TestCartUseCase_Checkout_PromotionsGivenBackOnFailure(t *testing.T) {
+	t.Parallel()
+
+	userID := int64(1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockPromoRepo := mocks.NewMockPromotionRepository(ctrl)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	lastUse := models.Promotion{ID: 5, Code: "LAST", Kind: models.PromotionPercentage, Percent: 10, MaxUses: 1}
+	orderItems := []models.OrderItem{{SKU: 100, Count: 2, Price: money.New("RUB", 1000)}}
+
+	mockCartRepo.EXPECT().List(gomock.Any(), userID).Return([]models.CartItem{{UserID: userID, SKU: 100, Count: 2}}, nil)
+	mockStockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 5, Price: money.New("RUB", 1000)}, nil)
+	mockPromoRepo.EXPECT().ListApplied(gomock.Any(), userID).Return([]models.Promotion{lastUse}, nil)
+	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order models.Order) (int64, error) {
+		assert.Len(t, order.Promotions, 1, "the use is taken with the order")
+		return 42, nil
+	})
+	mockStockRepo.EXPECT().ReduceStock(gomock.Any(), int64(42), orderItems).Return(errors.ErrNotEnoughStock)
+	// Fail, not UpdateStatus, so the use of LAST is given back.
+	mockOrderRepo.EXPECT().Fail(gomock.Any(), int64(42)).Return(nil)
+
+	u := NewCartUsecase(mockCartRepo, mockOrderRepo, mockPromoRepo, mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mocks.NewMockProducerInterface(ctrl), logger)
+
+	_, err = u.Checkout(context.Background(), userID)
+	assert.ErrorIs(t, err, errors.ErrNotEnoughStock)
+}
+
+func TestCartUseCase_Checkout_FailsOrderWhenRequestCancelled(t *testing.T) {
+	t.Parallel()
+
+	userID := int64(1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockPromoRepo := mocks.NewMockPromotionRepository(ctrl)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockCartRepo.EXPECT().List(gomock.Any(), userID).Return([]models.CartItem{{UserID: userID, SKU: 100, Count: 2}}, nil)
+	mockStockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 5, Price: money.New("RUB", 1000)}, nil)
+	mockPromoRepo.EXPECT().ListApplied(gomock.Any(), userID).Return(nil, nil)
+	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(42), nil)
+	mockStockRepo.EXPECT().ReduceStock(gomock.Any(), int64(42), gomock.Any()).DoAndReturn(func(context.Context, int64, []models.OrderItem) error {
+		cancel()
+		return context.Canceled
+	})
+	mockOrderRepo.EXPECT().Fail(gomock.Any(), int64(42)).DoAndReturn(func(ctx context.Context, _ int64) error {
+		assert.NoError(t, ctx.Err(), "the order is failed even though the client went away")
+		return ctx.Err()
+	})
+
+	u := NewCartUsecase(mockCartRepo, mockOrderRepo, mockPromoRepo, mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mocks.NewMockProducerInterface(ctrl), logger)
+
+	_, err = u.Checkout(ctx, userID)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCartUseCase_Checkout_BypassesStockCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := int64(1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartRepository(ctrl)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	cache := stockclient.NewCache(mockStockRepo, stockclient.CacheConfig{Size: 10, TTL: time.Hour, OfflineTTL: time.Hour}, logger, nil)
+
+	mockStockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 5, Price: money.New("RUB", 1000)}, nil)
+
+	_, err = cache.GetBySKU(ctx, 100)
+	assert.NoError(t, err)
+
+	// The cache still holds 5; the stock has since sold out to one.
+	mockCartRepo.EXPECT().List(gomock.Any(), userID).Return([]models.CartItem{{UserID: userID, SKU: 100, Count: 2}}, nil)
+	mockStockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(100)).Return(models.StockItem{SKU: 100, Count: 1, Price: money.New("RUB", 1000)}, nil)
+
+	u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mocks.NewMockGuestCartRepository(ctrl), cache, mocks.NewMockProducerInterface(ctrl), logger)
+
+	_, err = u.Checkout(ctx, userID)
+	assert.ErrorIs(t, err, errors.ErrNotEnoughStock)
+}
+
+func TestCartUseCase_ApplyPromoCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := int64(1)
+
+	percent := models.Promotion{ID: 7, Code: "TEN", Kind: models.PromotionPercentage, Percent: 10}
+	books := models.Promotion{ID: 8, Code: "BOOKS", Kind: models.PromotionPercentage, Percent: 10, SKUType: "books"}
+
+	cartItems := []models.CartItem{{UserID: userID, SKU: 100, Count: 1}}
+	stockItems := map[uint32]models.StockItem{
+		100: {SKU: 100, Type: "apparel", Count: 5, Price: money.New("RUB", 1000)},
+	}
+
+	tests := []struct {
+		name      string
+		code      string
+		mockSetup func(cartRepo *mocks.MockCartRepository, promoRepo *mocks.MockPromotionRepository, stockRepo *mocks.MockStockRepository)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			code: "TEN",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, promoRepo *mocks.MockPromotionRepository, stockRepo *mocks.MockStockRepository) {
+				promoRepo.EXPECT().GetByCode(gomock.Any(), "TEN").Return(percent, nil)
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(gomock.Any(), []uint32{100}).Return(stockItems, nil)
+				promoRepo.EXPECT().ListApplied(gomock.Any(), userID).Return(nil, nil)
+				promoRepo.EXPECT().Apply(gomock.Any(), userID, int64(7)).Return(nil)
+			},
+		},
+		{
+			name: "unknown code",
+			code: "NOPE",
+			mockSetup: func(_ *mocks.MockCartRepository, promoRepo *mocks.MockPromotionRepository, _ *mocks.MockStockRepository) {
+				promoRepo.EXPECT().GetByCode(gomock.Any(), "NOPE").Return(models.Promotion{}, errors.ErrPromotionNotFound)
+			},
+			wantErr: errors.ErrPromotionNotFound,
+		},
+		{
+			name: "empty cart",
+			code: "TEN",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, promoRepo *mocks.MockPromotionRepository, _ *mocks.MockStockRepository) {
+				promoRepo.EXPECT().GetByCode(gomock.Any(), "TEN").Return(percent, nil)
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(nil, nil)
+			},
+			wantErr: errors.ErrEmptyCart,
+		},
+		{
+			name: "promotion does not apply to the cart",
+			code: "BOOKS",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, promoRepo *mocks.MockPromotionRepository, stockRepo *mocks.MockStockRepository) {
+				promoRepo.EXPECT().GetByCode(gomock.Any(), "BOOKS").Return(books, nil)
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(gomock.Any(), []uint32{100}).Return(stockItems, nil)
+				promoRepo.EXPECT().ListApplied(gomock.Any(), userID).Return(nil, nil)
+			},
+			wantErr: errors.ErrPromotionNotApplicable,
+		},
+		{
+			name: "already applied",
+			code: "TEN",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, promoRepo *mocks.MockPromotionRepository, stockRepo *mocks.MockStockRepository) {
+				promoRepo.EXPECT().GetByCode(gomock.Any(), "TEN").Return(percent, nil)
+				cartRepo.EXPECT().List(gomock.Any(), userID).Return(append([]models.CartItem(nil), cartItems...), nil)
+				stockRepo.EXPECT().GetBySKUs(gomock.Any(), []uint32{100}).Return(stockItems, nil)
+				promoRepo.EXPECT().ListApplied(gomock.Any(), userID).Return([]models.Promotion{percent}, nil)
+				promoRepo.EXPECT().Apply(gomock.Any(), userID, int64(7)).Return(errors.ErrPromotionAlreadyApplied)
+			},
+			wantErr: errors.ErrPromotionAlreadyApplied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCartRepo := mocks.NewMockCartRepository(ctrl)
+			mockPromoRepo := mocks.NewMockPromotionRepository(ctrl)
+			mockStockRepo := mocks.NewMockStockRepository(ctrl)
+
+			logger, cleanup, err := zap.NewLogger()
+			if err != nil {
+				t.Fatalf("failed to create logger: %v", err)
+			}
+			defer cleanup()
+
+			u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mockPromoRepo, mocks.NewMockGuestCartRepository(ctrl), mockStockRepo, mocks.NewMockProducerInterface(ctrl), logger)
+
+			tt.mockSetup(mockCartRepo, mockPromoRepo, mockStockRepo)
+
+			err = u.ApplyPromoCode(ctx, userID, tt.code)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCartUseCase_MergeCarts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := int64(1)
+	guestID := int64(3)
+	price := money.New("RUB", 1000)
+
+	tests := []struct {
+		name       string
+		guestItems []models.CartItem
+		userItems  []models.CartItem
+		stockItems map[uint32]models.StockItem
+		// wantAdded are the units each SKU adds to the user's cart.
+		wantAdded  []models.CartItem
+		wantReport []models.MergedItem
+		// wantEvents is the count published as added per SKU of the user's cart.
+		wantEvents map[string]int
+	}{
+		{
+			name:       "quantities are summed",
+			guestItems: []models.CartItem{{SKU: 100, Count: 2}, {SKU: 101, Count: 1}},
+			userItems:  []models.CartItem{{SKU: 100, Count: 1}},
+			stockItems: map[uint32]models.StockItem{
+				100: {SKU: 100, Count: 10, Price: price},
+				101: {SKU: 101, Count: 10, Price: price},
+			},
+			wantAdded: []models.CartItem{
+				{UserID: userID, SKU: 100, Count: 2, Price: price},
+				{UserID: userID, SKU: 101, Count: 1, Price: price},
+			},
+			wantReport: []models.MergedItem{
+				{SKU: 100, GuestCount: 2, UserCount: 1, Count: 3, Adjustment: models.MergeAdjustmentNone},
+				{SKU: 101, GuestCount: 1, Count: 1, Adjustment: models.MergeAdjustmentNone},
+			},
+			wantEvents: map[string]int{"100": 2, "101": 1},
+		},
+		{
+			name:       "capped at the available stock",
+			guestItems: []models.CartItem{{SKU: 100, Count: 4}},
+			userItems:  []models.CartItem{{SKU: 100, Count: 2}},
+			stockItems: map[uint32]models.StockItem{100: {SKU: 100, Count: 5, Price: price}},
+			wantAdded:  []models.CartItem{{UserID: userID, SKU: 100, Count: 3, Price: price}},
+			wantReport: []models.MergedItem{
+				{SKU: 100, GuestCount: 4, UserCount: 2, Count: 5, Adjustment: models.MergeAdjustmentCapped, Reason: "only 5 available"},
+			},
+			wantEvents: map[string]int{"100": 3},
+		},
+		{
+			name:       "the user's quantity is never reduced",
+			guestItems: []models.CartItem{{SKU: 100, Count: 1}},
+			userItems:  []models.CartItem{{SKU: 100, Count: 3}},
+			stockItems: map[uint32]models.StockItem{100: {SKU: 100, Count: 2, Price: price}},
+			wantAdded:  []models.CartItem{},
+			wantReport: []models.MergedItem{
+				{SKU: 100, GuestCount: 1, UserCount: 3, Count: 3, Adjustment: models.MergeAdjustmentDropped, Reason: "only 2 available"},
+			},
+		},
+		{
+			name:       "unknown and archived skus are dropped",
+			guestItems: []models.CartItem{{SKU: 100, Count: 1}, {SKU: 101, Count: 1}},
+			stockItems: map[uint32]models.StockItem{101: {SKU: 101, Count: 5, Archived: true}},
+			wantAdded:  []models.CartItem{},
+			wantReport: []models.MergedItem{
+				{SKU: 100, GuestCount: 1, Adjustment: models.MergeAdjustmentDropped, Reason: errors.ErrInvalidSKU.Error()},
+				{SKU: 101, GuestCount: 1, Adjustment: models.MergeAdjustmentDropped, Reason: errors.ErrSKUArchived.Error()},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCartRepo := mocks.NewMockCartRepository(ctrl)
+			mockGuestRepo := mocks.NewMockGuestCartRepository(ctrl)
+			mockStockRepo := mocks.NewMockStockRepository(ctrl)
+			mockProducer := mocks.NewMockProducerInterface(ctrl)
+
+			logger, cleanup, err := zap.NewLogger()
+			if err != nil {
+				t.Fatalf("failed to create logger: %v", err)
+			}
+			defer cleanup()
+
+			u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mockGuestRepo, mockStockRepo, mockProducer, logger)
+
+			mockGuestRepo.EXPECT().GuestIDByToken(gomock.Any(), guest.Hash("token")).Return(guestID, nil)
+			mockCartRepo.EXPECT().List(gomock.Any(), models.GuestCartID(guestID)).Return(tt.guestItems, nil)
+			mockCartRepo.EXPECT().List(gomock.Any(), userID).Return(tt.userItems, nil)
+			mockStockRepo.EXPECT().GetBySKUs(gomock.Any(), gomock.Any()).Return(tt.stockItems, nil)
+			mockCartRepo.EXPECT().Merge(gomock.Any(), guestID, userID, tt.guestItems, tt.wantAdded).Return(nil)
+			mockProducer.EXPECT().
+				SendCartCleared(gomock.Any(), models.GuestCartID(guestID), tt.guestItems, models.ClearReasonMerged).
+				Return(nil)
+
+			for sku, count := range tt.wantEvents {
+				mockProducer.EXPECT().SendCartItemAdded(gomock.Any(), "1", sku, count, "merged").Return(nil)
+			}
+
+			report, err := u.MergeCarts(ctx, userID, "token")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, tt.wantReport, report.Items)
+		})
+	}
+}
+
+func TestCartUseCase_MergeCarts_UnknownToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGuestRepo := mocks.NewMockGuestCartRepository(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	u := NewCartUsecase(mocks.NewMockCartRepository(ctrl), mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mockGuestRepo, mocks.NewMockStockRepository(ctrl), mocks.NewMockProducerInterface(ctrl), logger)
+
+	mockGuestRepo.EXPECT().GuestIDByToken(gomock.Any(), gomock.Any()).Return(int64(0), errors.ErrGuestCartNotFound)
+
+	_, err = u.MergeCarts(context.Background(), 1, "stolen")
+	assert.ErrorIs(t, err, errors.ErrGuestCartNotFound)
+}
+
+func TestCartUseCase_MergeCarts_GuestCartChanged(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartRepository(ctrl)
+	mockGuestRepo := mocks.NewMockGuestCartRepository(ctrl)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mockGuestRepo, mockStockRepo, mocks.NewMockProducerInterface(ctrl), logger)
+
+	guestItems := []models.CartItem{{SKU: 100, Count: 1}}
+
+	mockGuestRepo.EXPECT().GuestIDByToken(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+	mockCartRepo.EXPECT().List(gomock.Any(), models.GuestCartID(3)).Return(guestItems, nil)
+	mockCartRepo.EXPECT().List(gomock.Any(), int64(1)).Return(nil, nil)
+	mockStockRepo.EXPECT().GetBySKUs(gomock.Any(), gomock.Any()).
+		Return(map[uint32]models.StockItem{100: {SKU: 100, Count: 5}}, nil)
+	mockCartRepo.EXPECT().Merge(gomock.Any(), int64(3), int64(1), guestItems, gomock.Any()).
+		Return(errors.ErrGuestCartChanged)
+
+	// No events: nothing was merged.
+	_, err = u.MergeCarts(context.Background(), 1, "token")
+	assert.ErrorIs(t, err, errors.ErrGuestCartChanged)
+}
+
+func TestCartUseCase_CreateGuestCart(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGuestRepo := mocks.NewMockGuestCartRepository(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	u := NewCartUsecase(mocks.NewMockCartRepository(ctrl), mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mockGuestRepo, mocks.NewMockStockRepository(ctrl), mocks.NewMockProducerInterface(ctrl), logger)
+
+	var stored []byte
+
+	mockGuestRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tokenHash []byte) (int64, error) {
+		stored = tokenHash
+		return 3, nil
+	})
+
+	token, err := u.CreateGuestCart(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.NotEmpty(t, token)
+	assert.Equal(t, guest.Hash(token), stored, "only the token hash is stored")
+}
+
+func TestCartUseCase_NotifyAbandonedCarts(t *testing.T) {
+	t.Parallel()
+
+	idleSince := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cart := models.AbandonedCart{UserID: 1, LastActivityAt: idleSince.Add(-time.Hour)}
+	items := []models.CartItem{{UserID: 1, SKU: 100, Count: 2}}
+
+	withItems := cart
+	withItems.Items = items
+
+	tests := []struct {
+		name         string
+		mockSetup    func(cartRepo *mocks.MockCartRepository, producer *mocks.MockProducerInterface)
+		wantNotified int
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, producer *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().ListAbandoned(gomock.Any(), idleSince, abandonedBatchSize).Return([]models.AbandonedCart{cart}, nil)
+				cartRepo.EXPECT().ClaimAbandoned(gomock.Any(), cart).Return(true, nil)
+				cartRepo.EXPECT().List(gomock.Any(), int64(1)).Return(items, nil)
+				producer.EXPECT().SendCartAbandoned(gomock.Any(), withItems).Return(nil)
+			},
+			wantNotified: 1,
+		},
+		{
+			name: "a cart claimed by another instance is skipped",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, _ *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().ListAbandoned(gomock.Any(), idleSince, abandonedBatchSize).Return([]models.AbandonedCart{cart}, nil)
+				cartRepo.EXPECT().ClaimAbandoned(gomock.Any(), cart).Return(false, nil)
+			},
+		},
+		{
+			name: "claim fails",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, _ *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().ListAbandoned(gomock.Any(), idleSince, abandonedBatchSize).Return([]models.AbandonedCart{cart}, nil)
+				cartRepo.EXPECT().ClaimAbandoned(gomock.Any(), cart).Return(false, stdErr.New("db down"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing abandoned",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, _ *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().ListAbandoned(gomock.Any(), idleSince, abandonedBatchSize).Return(nil, nil)
+			},
+		},
+		{
+			name: "a cart whose event fails is released",
+			mockSetup: func(cartRepo *mocks.MockCartRepository, producer *mocks.MockProducerInterface) {
+				cartRepo.EXPECT().ListAbandoned(gomock.Any(), idleSince, abandonedBatchSize).Return([]models.AbandonedCart{cart}, nil)
+				cartRepo.EXPECT().ClaimAbandoned(gomock.Any(), cart).Return(true, nil)
+				cartRepo.EXPECT().List(gomock.Any(), int64(1)).Return(items, nil)
+				producer.EXPECT().SendCartAbandoned(gomock.Any(), withItems).Return(stdErr.New("kafka down"))
+				cartRepo.EXPECT().ReleaseAbandoned(gomock.Any(), withItems).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCartRepo := mocks.NewMockCartRepository(ctrl)
+			mockProducer := mocks.NewMockProducerInterface(ctrl)
+
+			logger, cleanup, err := zap.NewLogger()
+			if err != nil {
+				t.Fatalf("failed to create logger: %v", err)
+			}
+			defer cleanup()
+
+			u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mocks.NewMockGuestCartRepository(ctrl), mocks.NewMockStockRepository(ctrl), mockProducer, logger)
+
+			tt.mockSetup(mockCartRepo, mockProducer)
+
+			notified, err := u.NotifyAbandonedCarts(context.Background(), idleSince)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantNotified, notified)
+		})
+	}
+}
+
+func TestCartUseCase_PurgeIdleCarts(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartRepository(ctrl)
+	mockProducer := mocks.NewMockProducerInterface(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	u := NewCartUsecase(mockCartRepo, mocks.NewMockOrderRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), mocks.NewMockGuestCartRepository(ctrl), mocks.NewMockStockRepository(ctrl), mockProducer, logger)
+
+	idleSince := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	userLines := []models.CartItem{{UserID: 1, SKU: 100, Count: 2}, {UserID: 1, SKU: 101, Count: 1}}
+	guestLines := []models.CartItem{{UserID: models.GuestCartID(3), SKU: 100, Count: 1}}
+
+	mockCartRepo.EXPECT().PurgeIdle(gomock.Any(), idleSince).
+		Return([]models.CartItem{userLines[0], guestLines[0], userLines[1]}, nil)
+	mockProducer.EXPECT().SendCartCleared(gomock.Any(), int64(1), userLines, models.ClearReasonExpired).Return(nil)
+	mockProducer.EXPECT().
+		SendCartCleared(gomock.Any(), models.GuestCartID(3), guestLines, models.ClearReasonExpired).
+		Return(stdErr.New("kafka down"))
+
+	purged, err := u.PurgeIdleCarts(context.Background(), idleSince)
+	assert.NoError(t, err, "the carts are gone whether or not their event was sent")
+	assert.Equal(t, int64(2), purged)
+}
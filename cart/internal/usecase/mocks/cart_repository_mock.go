@@ -8,6 +8,7 @@ import (
 	models "cart/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -49,12 +50,28 @@ func (mr *MockCartRepositoryMockRecorder) Add(ctx, item interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCartRepository)(nil).Add), ctx, item)
 }
 
+// ClaimAbandoned mocks base method.
+func (m *MockCartRepository) ClaimAbandoned(ctx context.Context, cart models.AbandonedCart) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAbandoned", ctx, cart)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAbandoned indicates an expected call of ClaimAbandoned.
+func (mr *MockCartRepositoryMockRecorder) ClaimAbandoned(ctx, cart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAbandoned", reflect.TypeOf((*MockCartRepository)(nil).ClaimAbandoned), ctx, cart)
+}
+
 // Clear mocks base method.
-func (m *MockCartRepository) Clear(ctx context.Context, userID int64) error {
+func (m *MockCartRepository) Clear(ctx context.Context, userID int64) ([]models.CartItem, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Clear", ctx, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]models.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Clear indicates an expected call of Clear.
@@ -64,11 +81,12 @@ func (mr *MockCartRepositoryMockRecorder) Clear(ctx, userID interface{}) *gomock
 }
 
 // Delete mocks base method.
-func (m *MockCartRepository) Delete(ctx context.Context, userID int64, sku uint32) error {
+func (m *MockCartRepository) Delete(ctx context.Context, userID int64, sku uint32) (int16, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, userID, sku)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int16)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
@@ -92,6 +110,64 @@ func (mr *MockCartRepositoryMockRecorder) List(ctx, userID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCartRepository)(nil).List), ctx, userID)
 }
 
+// ListAbandoned mocks base method.
+func (m *MockCartRepository) ListAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]models.AbandonedCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbandoned", ctx, idleSince, limit)
+	ret0, _ := ret[0].([]models.AbandonedCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbandoned indicates an expected call of ListAbandoned.
+func (mr *MockCartRepositoryMockRecorder) ListAbandoned(ctx, idleSince, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbandoned", reflect.TypeOf((*MockCartRepository)(nil).ListAbandoned), ctx, idleSince, limit)
+}
+
+// Merge mocks base method.
+func (m *MockCartRepository) Merge(ctx context.Context, guestID, userID int64, guestItems, added []models.CartItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, guestID, userID, guestItems, added)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockCartRepositoryMockRecorder) Merge(ctx, guestID, userID, guestItems, added interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockCartRepository)(nil).Merge), ctx, guestID, userID, guestItems, added)
+}
+
+// PurgeIdle mocks base method.
+func (m *MockCartRepository) PurgeIdle(ctx context.Context, idleSince time.Time) ([]models.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdle", ctx, idleSince)
+	ret0, _ := ret[0].([]models.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdle indicates an expected call of PurgeIdle.
+func (mr *MockCartRepositoryMockRecorder) PurgeIdle(ctx, idleSince interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdle", reflect.TypeOf((*MockCartRepository)(nil).PurgeIdle), ctx, idleSince)
+}
+
+// ReleaseAbandoned mocks base method.
+func (m *MockCartRepository) ReleaseAbandoned(ctx context.Context, cart models.AbandonedCart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseAbandoned", ctx, cart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseAbandoned indicates an expected call of ReleaseAbandoned.
+func (mr *MockCartRepositoryMockRecorder) ReleaseAbandoned(ctx, cart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseAbandoned", reflect.TypeOf((*MockCartRepository)(nil).ReleaseAbandoned), ctx, cart)
+}
+
 // Upsert mocks base method.
 func (m *MockCartRepository) Upsert(ctx context.Context, item models.CartItem) error {
 	m.ctrl.T.Helper()
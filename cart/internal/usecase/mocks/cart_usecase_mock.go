@@ -8,6 +8,7 @@ import (
 	models "cart/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -49,6 +50,35 @@ func (mr *MockCartUseCaseMockRecorder) Add(ctx, item interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCartUseCase)(nil).Add), ctx, item)
 }
 
+// ApplyPromoCode mocks base method.
+func (m *MockCartUseCase) ApplyPromoCode(ctx context.Context, userID int64, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPromoCode", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyPromoCode indicates an expected call of ApplyPromoCode.
+func (mr *MockCartUseCaseMockRecorder) ApplyPromoCode(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromoCode", reflect.TypeOf((*MockCartUseCase)(nil).ApplyPromoCode), ctx, userID, code)
+}
+
+// Checkout mocks base method.
+func (m *MockCartUseCase) Checkout(ctx context.Context, userID int64) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, userID)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockCartUseCaseMockRecorder) Checkout(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCartUseCase)(nil).Checkout), ctx, userID)
+}
+
 // Clear mocks base method.
 func (m *MockCartUseCase) Clear(ctx context.Context, userID int64) error {
 	m.ctrl.T.Helper()
@@ -63,6 +93,21 @@ func (mr *MockCartUseCaseMockRecorder) Clear(ctx, userID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCartUseCase)(nil).Clear), ctx, userID)
 }
 
+// CreateGuestCart mocks base method.
+func (m *MockCartUseCase) CreateGuestCart(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestCart", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestCart indicates an expected call of CreateGuestCart.
+func (mr *MockCartUseCaseMockRecorder) CreateGuestCart(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestCart", reflect.TypeOf((*MockCartUseCase)(nil).CreateGuestCart), ctx)
+}
+
 // Delete mocks base method.
 func (m *MockCartUseCase) Delete(ctx context.Context, userID int64, sku uint32) error {
 	m.ctrl.T.Helper()
@@ -78,10 +123,10 @@ func (mr *MockCartUseCaseMockRecorder) Delete(ctx, userID, sku interface{}) *gom
 }
 
 // List mocks base method.
-func (m *MockCartUseCase) List(ctx context.Context, userID int64) ([]models.CartItem, error) {
+func (m *MockCartUseCase) List(ctx context.Context, userID int64) (models.Cart, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", ctx, userID)
-	ret0, _ := ret[0].([]models.CartItem)
+	ret0, _ := ret[0].(models.Cart)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -91,3 +136,62 @@ func (mr *MockCartUseCaseMockRecorder) List(ctx, userID interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCartUseCase)(nil).List), ctx, userID)
 }
+
+// MergeCarts mocks base method.
+func (m *MockCartUseCase) MergeCarts(ctx context.Context, userID int64, cartToken string) (models.MergeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCarts", ctx, userID, cartToken)
+	ret0, _ := ret[0].(models.MergeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeCarts indicates an expected call of MergeCarts.
+func (mr *MockCartUseCaseMockRecorder) MergeCarts(ctx, userID, cartToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCarts", reflect.TypeOf((*MockCartUseCase)(nil).MergeCarts), ctx, userID, cartToken)
+}
+
+// NotifyAbandonedCarts mocks base method.
+func (m *MockCartUseCase) NotifyAbandonedCarts(ctx context.Context, idleSince time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyAbandonedCarts", ctx, idleSince)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotifyAbandonedCarts indicates an expected call of NotifyAbandonedCarts.
+func (mr *MockCartUseCaseMockRecorder) NotifyAbandonedCarts(ctx, idleSince interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyAbandonedCarts", reflect.TypeOf((*MockCartUseCase)(nil).NotifyAbandonedCarts), ctx, idleSince)
+}
+
+// PurgeIdleCarts mocks base method.
+func (m *MockCartUseCase) PurgeIdleCarts(ctx context.Context, idleSince time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdleCarts", ctx, idleSince)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdleCarts indicates an expected call of PurgeIdleCarts.
+func (mr *MockCartUseCaseMockRecorder) PurgeIdleCarts(ctx, idleSince interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdleCarts", reflect.TypeOf((*MockCartUseCase)(nil).PurgeIdleCarts), ctx, idleSince)
+}
+
+// RemovePromoCode mocks base method.
+func (m *MockCartUseCase) RemovePromoCode(ctx context.Context, userID int64, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePromoCode", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePromoCode indicates an expected call of RemovePromoCode.
+func (mr *MockCartUseCaseMockRecorder) RemovePromoCode(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePromoCode", reflect.TypeOf((*MockCartUseCase)(nil).RemovePromoCode), ctx, userID, code)
+}
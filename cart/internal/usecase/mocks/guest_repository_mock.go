@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/guest_repo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGuestCartRepository is a mock of GuestCartRepository interface.
+type MockGuestCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestCartRepositoryMockRecorder
+}
+
+// MockGuestCartRepositoryMockRecorder is the mock recorder for MockGuestCartRepository.
+type MockGuestCartRepositoryMockRecorder struct {
+	mock *MockGuestCartRepository
+}
+
+// NewMockGuestCartRepository creates a new mock instance.
+func NewMockGuestCartRepository(ctrl *gomock.Controller) *MockGuestCartRepository {
+	mock := &MockGuestCartRepository{ctrl: ctrl}
+	mock.recorder = &MockGuestCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestCartRepository) EXPECT() *MockGuestCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGuestCartRepository) Create(ctx context.Context, tokenHash []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tokenHash)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGuestCartRepositoryMockRecorder) Create(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGuestCartRepository)(nil).Create), ctx, tokenHash)
+}
+
+// GuestIDByToken mocks base method.
+func (m *MockGuestCartRepository) GuestIDByToken(ctx context.Context, tokenHash []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GuestIDByToken", ctx, tokenHash)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GuestIDByToken indicates an expected call of GuestIDByToken.
+func (mr *MockGuestCartRepositoryMockRecorder) GuestIDByToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GuestIDByToken", reflect.TypeOf((*MockGuestCartRepository)(nil).GuestIDByToken), ctx, tokenHash)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	models "cart/internal/models"
 	context "context"
 	reflect "reflect"
 
@@ -48,6 +49,34 @@ func (mr *MockProducerInterfaceMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockProducerInterface)(nil).Close))
 }
 
+// SendCartAbandoned mocks base method.
+func (m *MockProducerInterface) SendCartAbandoned(ctx context.Context, cart models.AbandonedCart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCartAbandoned", ctx, cart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCartAbandoned indicates an expected call of SendCartAbandoned.
+func (mr *MockProducerInterfaceMockRecorder) SendCartAbandoned(ctx, cart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCartAbandoned", reflect.TypeOf((*MockProducerInterface)(nil).SendCartAbandoned), ctx, cart)
+}
+
+// SendCartCleared mocks base method.
+func (m *MockProducerInterface) SendCartCleared(ctx context.Context, cartID int64, items []models.CartItem, reason models.ClearReason) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCartCleared", ctx, cartID, items, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCartCleared indicates an expected call of SendCartCleared.
+func (mr *MockProducerInterfaceMockRecorder) SendCartCleared(ctx, cartID, items, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCartCleared", reflect.TypeOf((*MockProducerInterface)(nil).SendCartCleared), ctx, cartID, items, reason)
+}
+
 // SendCartItemAdded mocks base method.
 func (m *MockProducerInterface) SendCartItemAdded(ctx context.Context, cartId, sku string, count int, status string) error {
 	m.ctrl.T.Helper()
@@ -75,3 +104,31 @@ func (mr *MockProducerInterfaceMockRecorder) SendCartItemFailed(ctx, cartId, sku
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCartItemFailed", reflect.TypeOf((*MockProducerInterface)(nil).SendCartItemFailed), ctx, cartId, sku, count, status, reason)
 }
+
+// SendCartItemRemoved mocks base method.
+func (m *MockProducerInterface) SendCartItemRemoved(ctx context.Context, cartId, sku string, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCartItemRemoved", ctx, cartId, sku, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCartItemRemoved indicates an expected call of SendCartItemRemoved.
+func (mr *MockProducerInterfaceMockRecorder) SendCartItemRemoved(ctx, cartId, sku, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCartItemRemoved", reflect.TypeOf((*MockProducerInterface)(nil).SendCartItemRemoved), ctx, cartId, sku, count)
+}
+
+// SendOrderCreated mocks base method.
+func (m *MockProducerInterface) SendOrderCreated(ctx context.Context, order models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendOrderCreated", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendOrderCreated indicates an expected call of SendOrderCreated.
+func (mr *MockProducerInterfaceMockRecorder) SendOrderCreated(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderCreated", reflect.TypeOf((*MockProducerInterface)(nil).SendOrderCreated), ctx, order)
+}
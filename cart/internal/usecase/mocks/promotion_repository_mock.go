@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/promotion_repo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "cart/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockPromotionRepository) Apply(ctx context.Context, userID, promotionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, userID, promotionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockPromotionRepositoryMockRecorder) Apply(ctx, userID, promotionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockPromotionRepository)(nil).Apply), ctx, userID, promotionID)
+}
+
+// GetByCode mocks base method.
+func (m *MockPromotionRepository) GetByCode(ctx context.Context, code string) (models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockPromotionRepositoryMockRecorder) GetByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockPromotionRepository)(nil).GetByCode), ctx, code)
+}
+
+// ListApplied mocks base method.
+func (m *MockPromotionRepository) ListApplied(ctx context.Context, userID int64) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplied", ctx, userID)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplied indicates an expected call of ListApplied.
+func (mr *MockPromotionRepositoryMockRecorder) ListApplied(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplied", reflect.TypeOf((*MockPromotionRepository)(nil).ListApplied), ctx, userID)
+}
+
+// Remove mocks base method.
+func (m *MockPromotionRepository) Remove(ctx context.Context, userID int64, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockPromotionRepositoryMockRecorder) Remove(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockPromotionRepository)(nil).Remove), ctx, userID, code)
+}
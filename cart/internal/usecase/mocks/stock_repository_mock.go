@@ -49,3 +49,32 @@ func (mr *MockStockRepositoryMockRecorder) GetBySKU(ctx, sku interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockStockRepository)(nil).GetBySKU), ctx, sku)
 }
+
+// GetBySKUs mocks base method.
+func (m *MockStockRepository) GetBySKUs(ctx context.Context, skus []uint32) (map[uint32]models.StockItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKUs", ctx, skus)
+	ret0, _ := ret[0].(map[uint32]models.StockItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKUs indicates an expected call of GetBySKUs.
+func (mr *MockStockRepositoryMockRecorder) GetBySKUs(ctx, skus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKUs", reflect.TypeOf((*MockStockRepository)(nil).GetBySKUs), ctx, skus)
+}
+
+// ReduceStock mocks base method.
+func (m *MockStockRepository) ReduceStock(ctx context.Context, orderID int64, items []models.OrderItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReduceStock", ctx, orderID, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReduceStock indicates an expected call of ReduceStock.
+func (mr *MockStockRepositoryMockRecorder) ReduceStock(ctx, orderID, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceStock", reflect.TypeOf((*MockStockRepository)(nil).ReduceStock), ctx, orderID, items)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"cart/internal/models"
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=usecase.go -destination=mocks/cart_usecase_mock.go -package=mocks
@@ -10,6 +11,13 @@ import (
 type CartUseCase interface {
 	Add(ctx context.Context, item models.CartItem) error
 	Delete(ctx context.Context, userID int64, sku uint32) error
-	List(ctx context.Context, userID int64) ([]models.CartItem, error)
+	List(ctx context.Context, userID int64) (models.Cart, error)
 	Clear(ctx context.Context, userID int64) error
+	Checkout(ctx context.Context, userID int64) (models.Order, error)
+	ApplyPromoCode(ctx context.Context, userID int64, code string) error
+	RemovePromoCode(ctx context.Context, userID int64, code string) error
+	CreateGuestCart(ctx context.Context) (string, error)
+	MergeCarts(ctx context.Context, userID int64, cartToken string) (models.MergeReport, error)
+	NotifyAbandonedCarts(ctx context.Context, idleSince time.Time) (int, error)
+	PurgeIdleCarts(ctx context.Context, idleSince time.Time) (int64, error)
 }
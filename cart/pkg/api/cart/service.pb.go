@@ -7,6 +7,7 @@
 package cartpb
 
 import (
+	money "cart/pkg/api/money"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -22,6 +23,60 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MergeAdjustment int32
+
+const (
+	MergeAdjustment_MERGE_ADJUSTMENT_UNSPECIFIED MergeAdjustment = 0
+	MergeAdjustment_MERGE_ADJUSTMENT_NONE        MergeAdjustment = 1
+	// Less than the summed quantity was kept, for lack of stock.
+	MergeAdjustment_MERGE_ADJUSTMENT_CAPPED MergeAdjustment = 2
+	// Nothing of the guest line was kept.
+	MergeAdjustment_MERGE_ADJUSTMENT_DROPPED MergeAdjustment = 3
+)
+
+// Enum value maps for MergeAdjustment.
+var (
+	MergeAdjustment_name = map[int32]string{
+		0: "MERGE_ADJUSTMENT_UNSPECIFIED",
+		1: "MERGE_ADJUSTMENT_NONE",
+		2: "MERGE_ADJUSTMENT_CAPPED",
+		3: "MERGE_ADJUSTMENT_DROPPED",
+	}
+	MergeAdjustment_value = map[string]int32{
+		"MERGE_ADJUSTMENT_UNSPECIFIED": 0,
+		"MERGE_ADJUSTMENT_NONE":        1,
+		"MERGE_ADJUSTMENT_CAPPED":      2,
+		"MERGE_ADJUSTMENT_DROPPED":     3,
+	}
+)
+
+func (x MergeAdjustment) Enum() *MergeAdjustment {
+	p := new(MergeAdjustment)
+	*p = x
+	return p
+}
+
+func (x MergeAdjustment) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergeAdjustment) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[0].Descriptor()
+}
+
+func (MergeAdjustment) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[0]
+}
+
+func (x MergeAdjustment) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergeAdjustment.Descriptor instead.
+func (MergeAdjustment) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{0}
+}
+
 type AddItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -267,9 +322,21 @@ func (x *CartResponse) GetMessage() string {
 }
 
 type CartItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
-	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sku       string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count     int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Available int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	Name      string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	// Deprecated: use unit_price and line_amount.
+	//
+	// Deprecated: Marked as deprecated in service.proto.
+	Price float32 `protobuf:"fixed32,6,opt,name=price,proto3" json:"price,omitempty"`
+	// Deprecated: Marked as deprecated in service.proto.
+	LineTotal     float32      `protobuf:"fixed32,7,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	Unfulfillable bool         `protobuf:"varint,8,opt,name=unfulfillable,proto3" json:"unfulfillable,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,9,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	LineAmount    *money.Money `protobuf:"bytes,10,opt,name=line_amount,json=lineAmount,proto3" json:"line_amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -318,17 +385,162 @@ func (x *CartItem) GetCount() int32 {
 	return 0
 }
 
-type ListCartResponse struct {
+func (x *CartItem) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *CartItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CartItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in service.proto.
+func (x *CartItem) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in service.proto.
+func (x *CartItem) GetLineTotal() float32 {
+	if x != nil {
+		return x.LineTotal
+	}
+	return 0
+}
+
+func (x *CartItem) GetUnfulfillable() bool {
+	if x != nil {
+		return x.Unfulfillable
+	}
+	return false
+}
+
+func (x *CartItem) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *CartItem) GetLineAmount() *money.Money {
+	if x != nil {
+		return x.LineAmount
+	}
+	return nil
+}
+
+// CartDiscount is a promo code applied to the cart. A code that no longer
+// applies is kept with applied = false and the reason.
+type CartDiscount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        *money.Money           `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Applied       bool                   `protobuf:"varint,4,opt,name=applied,proto3" json:"applied,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartDiscount) Reset() {
+	*x = CartDiscount{}
+	mi := &file_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartDiscount) ProtoMessage() {}
+
+func (x *CartDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartDiscount.ProtoReflect.Descriptor instead.
+func (*CartDiscount) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CartDiscount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CartDiscount) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CartDiscount) GetAmount() *money.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *CartDiscount) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *CartDiscount) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListCartResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Deprecated: use total.
+	//
+	// Deprecated: Marked as deprecated in service.proto.
+	TotalPrice float32 `protobuf:"fixed32,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	// subtotal - discount_total
+	Total         *money.Money    `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	Subtotal      *money.Money    `protobuf:"bytes,5,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discounts     []*CartDiscount `protobuf:"bytes,6,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	DiscountTotal *money.Money    `protobuf:"bytes,7,opt,name=discount_total,json=discountTotal,proto3" json:"discount_total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListCartResponse) Reset() {
 	*x = ListCartResponse{}
-	mi := &file_service_proto_msgTypes[6]
+	mi := &file_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -340,7 +552,7 @@ func (x *ListCartResponse) String() string {
 func (*ListCartResponse) ProtoMessage() {}
 
 func (x *ListCartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[6]
+	mi := &file_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +565,7 @@ func (x *ListCartResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCartResponse.ProtoReflect.Descriptor instead.
 func (*ListCartResponse) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{6}
+	return file_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListCartResponse) GetUserId() string {
@@ -370,37 +582,721 @@ func (x *ListCartResponse) GetItems() []*CartItem {
 	return nil
 }
 
-var File_service_proto protoreflect.FileDescriptor
+// Deprecated: Marked as deprecated in service.proto.
+func (x *ListCartResponse) GetTotalPrice() float32 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
 
-const file_service_proto_rawDesc = "" +
-	"\n" +
-	"\rservice.proto\x12\x04cart\x1a\x1cgoogle/api/annotations.proto\"Q\n" +
-	"\x0eAddItemRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
-	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count\">\n" +
-	"\x11DeleteItemRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
-	"\x03sku\x18\x02 \x01(\tR\x03sku\"+\n" +
-	"\x10ClearCartRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"*\n" +
-	"\x0fListCartRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"(\n" +
-	"\fCartResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"2\n" +
-	"\bCartItem\x12\x10\n" +
-	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x05R\x05count\"Q\n" +
-	"\x10ListCartResponse\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
-	"\x05items\x18\x02 \x03(\v2\x0e.cart.CartItemR\x05items2\xd3\x02\n" +
-	"\vCartService\x12N\n" +
-	"\aAddItem\x12\x14.cart.AddItemRequest\x1a\x12.cart.CartResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/cart/item/add\x12T\n" +
-	"\n" +
-	"DeleteItem\x12\x17.cart.DeleteItemRequest\x1a\x12.cart.CartResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/cart/item/delete\x12O\n" +
-	"\tClearCart\x12\x16.cart.ClearCartRequest\x1a\x12.cart.CartResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/cart/clear\x12M\n" +
-	"\bListCart\x12\x15.cart.ListCartRequest\x1a\x16.cart.ListCartResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
-	"/cart/listB\x10Z\x0epkg/api/cartpbb\x06proto3"
+func (x *ListCartResponse) GetTotal() *money.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *ListCartResponse) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *ListCartResponse) GetDiscounts() []*CartDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *ListCartResponse) GetDiscountTotal() *money.Money {
+	if x != nil {
+		return x.DiscountTotal
+	}
+	return nil
+}
+
+type CheckoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckoutRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type OrderItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Deprecated: use unit_price.
+	//
+	// Deprecated: Marked as deprecated in service.proto.
+	Price         float32      `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OrderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in service.proto.
+func (x *OrderItem) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderItem) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+type CheckoutResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items   []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// Deprecated: use total.
+	//
+	// Deprecated: Marked as deprecated in service.proto.
+	TotalPrice    float32         `protobuf:"fixed32,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Status        string          `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Total         *money.Money    `protobuf:"bytes,6,opt,name=total,proto3" json:"total,omitempty"`
+	Subtotal      *money.Money    `protobuf:"bytes,7,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discounts     []*CartDiscount `protobuf:"bytes,8,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	DiscountTotal *money.Money    `protobuf:"bytes,9,opt,name=discount_total,json=discountTotal,proto3" json:"discount_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CheckoutResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CheckoutResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckoutResponse) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in service.proto.
+func (x *CheckoutResponse) GetTotalPrice() float32 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *CheckoutResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CheckoutResponse) GetTotal() *money.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *CheckoutResponse) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *CheckoutResponse) GetDiscounts() []*CartDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *CheckoutResponse) GetDiscountTotal() *money.Money {
+	if x != nil {
+		return x.DiscountTotal
+	}
+	return nil
+}
+
+type ApplyPromoCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyPromoCodeRequest) Reset() {
+	*x = ApplyPromoCodeRequest{}
+	mi := &file_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPromoCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPromoCodeRequest) ProtoMessage() {}
+
+func (x *ApplyPromoCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPromoCodeRequest.ProtoReflect.Descriptor instead.
+func (*ApplyPromoCodeRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApplyPromoCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RemovePromoCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePromoCodeRequest) Reset() {
+	*x = RemovePromoCodeRequest{}
+	mi := &file_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePromoCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePromoCodeRequest) ProtoMessage() {}
+
+func (x *RemovePromoCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePromoCodeRequest.ProtoReflect.Descriptor instead.
+func (*RemovePromoCodeRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemovePromoCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type CreateGuestCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGuestCartRequest) Reset() {
+	*x = CreateGuestCartRequest{}
+	mi := &file_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGuestCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGuestCartRequest) ProtoMessage() {}
+
+func (x *CreateGuestCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGuestCartRequest.ProtoReflect.Descriptor instead.
+func (*CreateGuestCartRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{13}
+}
+
+type CreateGuestCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartToken     string                 `protobuf:"bytes,1,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGuestCartResponse) Reset() {
+	*x = CreateGuestCartResponse{}
+	mi := &file_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGuestCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGuestCartResponse) ProtoMessage() {}
+
+func (x *CreateGuestCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGuestCartResponse.ProtoReflect.Descriptor instead.
+func (*CreateGuestCartResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateGuestCartResponse) GetCartToken() string {
+	if x != nil {
+		return x.CartToken
+	}
+	return ""
+}
+
+type MergeCartsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartToken     string                 `protobuf:"bytes,1,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCartsRequest) Reset() {
+	*x = MergeCartsRequest{}
+	mi := &file_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCartsRequest) ProtoMessage() {}
+
+func (x *MergeCartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCartsRequest.ProtoReflect.Descriptor instead.
+func (*MergeCartsRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MergeCartsRequest) GetCartToken() string {
+	if x != nil {
+		return x.CartToken
+	}
+	return ""
+}
+
+type MergedItem struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Sku        string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	GuestCount int32                  `protobuf:"varint,2,opt,name=guest_count,json=guestCount,proto3" json:"guest_count,omitempty"`
+	UserCount  int32                  `protobuf:"varint,3,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
+	// The quantity in the user's cart after the merge.
+	Count         int32           `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Adjustment    MergeAdjustment `protobuf:"varint,5,opt,name=adjustment,proto3,enum=cart.MergeAdjustment" json:"adjustment,omitempty"`
+	Reason        string          `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergedItem) Reset() {
+	*x = MergedItem{}
+	mi := &file_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergedItem) ProtoMessage() {}
+
+func (x *MergedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergedItem.ProtoReflect.Descriptor instead.
+func (*MergedItem) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MergedItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *MergedItem) GetGuestCount() int32 {
+	if x != nil {
+		return x.GuestCount
+	}
+	return 0
+}
+
+func (x *MergedItem) GetUserCount() int32 {
+	if x != nil {
+		return x.UserCount
+	}
+	return 0
+}
+
+func (x *MergedItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *MergedItem) GetAdjustment() MergeAdjustment {
+	if x != nil {
+		return x.Adjustment
+	}
+	return MergeAdjustment_MERGE_ADJUSTMENT_UNSPECIFIED
+}
+
+func (x *MergedItem) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type MergeCartsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*MergedItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCartsResponse) Reset() {
+	*x = MergeCartsResponse{}
+	mi := &file_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCartsResponse) ProtoMessage() {}
+
+func (x *MergeCartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCartsResponse.ProtoReflect.Descriptor instead.
+func (*MergeCartsResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MergeCartsResponse) GetItems() []*MergedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_service_proto protoreflect.FileDescriptor
+
+const file_service_proto_rawDesc = "" +
+	"\n" +
+	"\rservice.proto\x12\x04cart\x1a\x1cgoogle/api/annotations.proto\x1a\x11money/money.proto\"Q\n" +
+	"\x0eAddItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\">\n" +
+	"\x11DeleteItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\"+\n" +
+	"\x10ClearCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"*\n" +
+	"\x0fListCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"(\n" +
+	"\fCartResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xb7\x02\n" +
+	"\bCartItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x18\n" +
+	"\x05price\x18\x06 \x01(\x02B\x02\x18\x01R\x05price\x12!\n" +
+	"\n" +
+	"line_total\x18\a \x01(\x02B\x02\x18\x01R\tlineTotal\x12$\n" +
+	"\runfulfillable\x18\b \x01(\bR\runfulfillable\x12+\n" +
+	"\n" +
+	"unit_price\x18\t \x01(\v2\f.money.MoneyR\tunitPrice\x12-\n" +
+	"\vline_amount\x18\n" +
+	" \x01(\v2\f.money.MoneyR\n" +
+	"lineAmount\"\x9c\x01\n" +
+	"\fCartDiscount\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12$\n" +
+	"\x06amount\x18\x03 \x01(\v2\f.money.MoneyR\x06amount\x12\x18\n" +
+	"\aapplied\x18\x04 \x01(\bR\aapplied\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xab\x02\n" +
+	"\x10ListCartResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x05items\x18\x02 \x03(\v2\x0e.cart.CartItemR\x05items\x12#\n" +
+	"\vtotal_price\x18\x03 \x01(\x02B\x02\x18\x01R\n" +
+	"totalPrice\x12\"\n" +
+	"\x05total\x18\x04 \x01(\v2\f.money.MoneyR\x05total\x12(\n" +
+	"\bsubtotal\x18\x05 \x01(\v2\f.money.MoneyR\bsubtotal\x120\n" +
+	"\tdiscounts\x18\x06 \x03(\v2\x12.cart.CartDiscountR\tdiscounts\x123\n" +
+	"\x0ediscount_total\x18\a \x01(\v2\f.money.MoneyR\rdiscountTotal\"*\n" +
+	"\x0fCheckoutRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"z\n" +
+	"\tOrderItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x02B\x02\x18\x01R\x05price\x12+\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\f.money.MoneyR\tunitPrice\"\xdf\x02\n" +
+	"\x10CheckoutResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
+	"\x05items\x18\x03 \x03(\v2\x0f.cart.OrderItemR\x05items\x12#\n" +
+	"\vtotal_price\x18\x04 \x01(\x02B\x02\x18\x01R\n" +
+	"totalPrice\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\"\n" +
+	"\x05total\x18\x06 \x01(\v2\f.money.MoneyR\x05total\x12(\n" +
+	"\bsubtotal\x18\a \x01(\v2\f.money.MoneyR\bsubtotal\x120\n" +
+	"\tdiscounts\x18\b \x03(\v2\x12.cart.CartDiscountR\tdiscounts\x123\n" +
+	"\x0ediscount_total\x18\t \x01(\v2\f.money.MoneyR\rdiscountTotal\"+\n" +
+	"\x15ApplyPromoCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\",\n" +
+	"\x16RemovePromoCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x18\n" +
+	"\x16CreateGuestCartRequest\"8\n" +
+	"\x17CreateGuestCartResponse\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x01 \x01(\tR\tcartToken\"2\n" +
+	"\x11MergeCartsRequest\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x01 \x01(\tR\tcartToken\"\xc3\x01\n" +
+	"\n" +
+	"MergedItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1f\n" +
+	"\vguest_count\x18\x02 \x01(\x05R\n" +
+	"guestCount\x12\x1d\n" +
+	"\n" +
+	"user_count\x18\x03 \x01(\x05R\tuserCount\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x125\n" +
+	"\n" +
+	"adjustment\x18\x05 \x01(\x0e2\x15.cart.MergeAdjustmentR\n" +
+	"adjustment\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"<\n" +
+	"\x12MergeCartsResponse\x12&\n" +
+	"\x05items\x18\x01 \x03(\v2\x10.cart.MergedItemR\x05items*\x89\x01\n" +
+	"\x0fMergeAdjustment\x12 \n" +
+	"\x1cMERGE_ADJUSTMENT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15MERGE_ADJUSTMENT_NONE\x10\x01\x12\x1b\n" +
+	"\x17MERGE_ADJUSTMENT_CAPPED\x10\x02\x12\x1c\n" +
+	"\x18MERGE_ADJUSTMENT_DROPPED\x10\x032\xaf\x06\n" +
+	"\vCartService\x12N\n" +
+	"\aAddItem\x12\x14.cart.AddItemRequest\x1a\x12.cart.CartResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/cart/item/add\x12T\n" +
+	"\n" +
+	"DeleteItem\x12\x17.cart.DeleteItemRequest\x1a\x12.cart.CartResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/cart/item/delete\x12O\n" +
+	"\tClearCart\x12\x16.cart.ClearCartRequest\x1a\x12.cart.CartResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/cart/clear\x12M\n" +
+	"\bListCart\x12\x15.cart.ListCartRequest\x1a\x16.cart.ListCartResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/cart/list\x12T\n" +
+	"\bCheckout\x12\x15.cart.CheckoutRequest\x1a\x16.cart.CheckoutResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/cart/checkout\x12_\n" +
+	"\x0eApplyPromoCode\x12\x1b.cart.ApplyPromoCodeRequest\x1a\x12.cart.CartResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/cart/promo/apply\x12b\n" +
+	"\x0fRemovePromoCode\x12\x1c.cart.RemovePromoCodeRequest\x1a\x12.cart.CartResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/cart/promo/remove\x12f\n" +
+	"\x0fCreateGuestCart\x12\x1c.cart.CreateGuestCartRequest\x1a\x1d.cart.CreateGuestCartResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/cart/guest\x12W\n" +
+	"\n" +
+	"MergeCarts\x12\x17.cart.MergeCartsRequest\x1a\x18.cart.MergeCartsResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/cart/mergeB\x10Z\x0epkg/api/cartpbb\x06proto3"
 
 var (
 	file_service_proto_rawDescOnce sync.Once
@@ -414,31 +1310,70 @@ func file_service_proto_rawDescGZIP() []byte {
 	return file_service_proto_rawDescData
 }
 
-var file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_service_proto_goTypes = []any{
-	(*AddItemRequest)(nil),    // 0: cart.AddItemRequest
-	(*DeleteItemRequest)(nil), // 1: cart.DeleteItemRequest
-	(*ClearCartRequest)(nil),  // 2: cart.ClearCartRequest
-	(*ListCartRequest)(nil),   // 3: cart.ListCartRequest
-	(*CartResponse)(nil),      // 4: cart.CartResponse
-	(*CartItem)(nil),          // 5: cart.CartItem
-	(*ListCartResponse)(nil),  // 6: cart.ListCartResponse
+	(MergeAdjustment)(0),            // 0: cart.MergeAdjustment
+	(*AddItemRequest)(nil),          // 1: cart.AddItemRequest
+	(*DeleteItemRequest)(nil),       // 2: cart.DeleteItemRequest
+	(*ClearCartRequest)(nil),        // 3: cart.ClearCartRequest
+	(*ListCartRequest)(nil),         // 4: cart.ListCartRequest
+	(*CartResponse)(nil),            // 5: cart.CartResponse
+	(*CartItem)(nil),                // 6: cart.CartItem
+	(*CartDiscount)(nil),            // 7: cart.CartDiscount
+	(*ListCartResponse)(nil),        // 8: cart.ListCartResponse
+	(*CheckoutRequest)(nil),         // 9: cart.CheckoutRequest
+	(*OrderItem)(nil),               // 10: cart.OrderItem
+	(*CheckoutResponse)(nil),        // 11: cart.CheckoutResponse
+	(*ApplyPromoCodeRequest)(nil),   // 12: cart.ApplyPromoCodeRequest
+	(*RemovePromoCodeRequest)(nil),  // 13: cart.RemovePromoCodeRequest
+	(*CreateGuestCartRequest)(nil),  // 14: cart.CreateGuestCartRequest
+	(*CreateGuestCartResponse)(nil), // 15: cart.CreateGuestCartResponse
+	(*MergeCartsRequest)(nil),       // 16: cart.MergeCartsRequest
+	(*MergedItem)(nil),              // 17: cart.MergedItem
+	(*MergeCartsResponse)(nil),      // 18: cart.MergeCartsResponse
+	(*money.Money)(nil),             // 19: money.Money
 }
 var file_service_proto_depIdxs = []int32{
-	5, // 0: cart.ListCartResponse.items:type_name -> cart.CartItem
-	0, // 1: cart.CartService.AddItem:input_type -> cart.AddItemRequest
-	1, // 2: cart.CartService.DeleteItem:input_type -> cart.DeleteItemRequest
-	2, // 3: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
-	3, // 4: cart.CartService.ListCart:input_type -> cart.ListCartRequest
-	4, // 5: cart.CartService.AddItem:output_type -> cart.CartResponse
-	4, // 6: cart.CartService.DeleteItem:output_type -> cart.CartResponse
-	4, // 7: cart.CartService.ClearCart:output_type -> cart.CartResponse
-	6, // 8: cart.CartService.ListCart:output_type -> cart.ListCartResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	19, // 0: cart.CartItem.unit_price:type_name -> money.Money
+	19, // 1: cart.CartItem.line_amount:type_name -> money.Money
+	19, // 2: cart.CartDiscount.amount:type_name -> money.Money
+	6,  // 3: cart.ListCartResponse.items:type_name -> cart.CartItem
+	19, // 4: cart.ListCartResponse.total:type_name -> money.Money
+	19, // 5: cart.ListCartResponse.subtotal:type_name -> money.Money
+	7,  // 6: cart.ListCartResponse.discounts:type_name -> cart.CartDiscount
+	19, // 7: cart.ListCartResponse.discount_total:type_name -> money.Money
+	19, // 8: cart.OrderItem.unit_price:type_name -> money.Money
+	10, // 9: cart.CheckoutResponse.items:type_name -> cart.OrderItem
+	19, // 10: cart.CheckoutResponse.total:type_name -> money.Money
+	19, // 11: cart.CheckoutResponse.subtotal:type_name -> money.Money
+	7,  // 12: cart.CheckoutResponse.discounts:type_name -> cart.CartDiscount
+	19, // 13: cart.CheckoutResponse.discount_total:type_name -> money.Money
+	0,  // 14: cart.MergedItem.adjustment:type_name -> cart.MergeAdjustment
+	17, // 15: cart.MergeCartsResponse.items:type_name -> cart.MergedItem
+	1,  // 16: cart.CartService.AddItem:input_type -> cart.AddItemRequest
+	2,  // 17: cart.CartService.DeleteItem:input_type -> cart.DeleteItemRequest
+	3,  // 18: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
+	4,  // 19: cart.CartService.ListCart:input_type -> cart.ListCartRequest
+	9,  // 20: cart.CartService.Checkout:input_type -> cart.CheckoutRequest
+	12, // 21: cart.CartService.ApplyPromoCode:input_type -> cart.ApplyPromoCodeRequest
+	13, // 22: cart.CartService.RemovePromoCode:input_type -> cart.RemovePromoCodeRequest
+	14, // 23: cart.CartService.CreateGuestCart:input_type -> cart.CreateGuestCartRequest
+	16, // 24: cart.CartService.MergeCarts:input_type -> cart.MergeCartsRequest
+	5,  // 25: cart.CartService.AddItem:output_type -> cart.CartResponse
+	5,  // 26: cart.CartService.DeleteItem:output_type -> cart.CartResponse
+	5,  // 27: cart.CartService.ClearCart:output_type -> cart.CartResponse
+	8,  // 28: cart.CartService.ListCart:output_type -> cart.ListCartResponse
+	11, // 29: cart.CartService.Checkout:output_type -> cart.CheckoutResponse
+	5,  // 30: cart.CartService.ApplyPromoCode:output_type -> cart.CartResponse
+	5,  // 31: cart.CartService.RemovePromoCode:output_type -> cart.CartResponse
+	15, // 32: cart.CartService.CreateGuestCart:output_type -> cart.CreateGuestCartResponse
+	18, // 33: cart.CartService.MergeCarts:output_type -> cart.MergeCartsResponse
+	25, // [25:34] is the sub-list for method output_type
+	16, // [16:25] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_service_proto_init() }
@@ -451,13 +1386,14 @@ func file_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_proto_rawDesc), len(file_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_service_proto_goTypes,
 		DependencyIndexes: file_service_proto_depIdxs,
+		EnumInfos:         file_service_proto_enumTypes,
 		MessageInfos:      file_service_proto_msgTypes,
 	}.Build()
 	File_service_proto = out.File
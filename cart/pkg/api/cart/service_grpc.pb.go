@@ -19,10 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CartService_AddItem_FullMethodName    = "/cart.CartService/AddItem"
-	CartService_DeleteItem_FullMethodName = "/cart.CartService/DeleteItem"
-	CartService_ClearCart_FullMethodName  = "/cart.CartService/ClearCart"
-	CartService_ListCart_FullMethodName   = "/cart.CartService/ListCart"
+	CartService_AddItem_FullMethodName         = "/cart.CartService/AddItem"
+	CartService_DeleteItem_FullMethodName      = "/cart.CartService/DeleteItem"
+	CartService_ClearCart_FullMethodName       = "/cart.CartService/ClearCart"
+	CartService_ListCart_FullMethodName        = "/cart.CartService/ListCart"
+	CartService_Checkout_FullMethodName        = "/cart.CartService/Checkout"
+	CartService_ApplyPromoCode_FullMethodName  = "/cart.CartService/ApplyPromoCode"
+	CartService_RemovePromoCode_FullMethodName = "/cart.CartService/RemovePromoCode"
+	CartService_CreateGuestCart_FullMethodName = "/cart.CartService/CreateGuestCart"
+	CartService_MergeCarts_FullMethodName      = "/cart.CartService/MergeCarts"
 )
 
 // CartServiceClient is the client API for CartService service.
@@ -33,6 +38,13 @@ type CartServiceClient interface {
 	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*CartResponse, error)
 	ClearCart(ctx context.Context, in *ClearCartRequest, opts ...grpc.CallOption) (*CartResponse, error)
 	ListCart(ctx context.Context, in *ListCartRequest, opts ...grpc.CallOption) (*ListCartResponse, error)
+	Checkout(ctx context.Context, in *CheckoutRequest, opts ...grpc.CallOption) (*CheckoutResponse, error)
+	ApplyPromoCode(ctx context.Context, in *ApplyPromoCodeRequest, opts ...grpc.CallOption) (*CartResponse, error)
+	RemovePromoCode(ctx context.Context, in *RemovePromoCodeRequest, opts ...grpc.CallOption) (*CartResponse, error)
+	// CreateGuestCart needs no authentication. The returned token is sent in
+	// the X-Cart-Token header to use the guest cart.
+	CreateGuestCart(ctx context.Context, in *CreateGuestCartRequest, opts ...grpc.CallOption) (*CreateGuestCartResponse, error)
+	MergeCarts(ctx context.Context, in *MergeCartsRequest, opts ...grpc.CallOption) (*MergeCartsResponse, error)
 }
 
 type cartServiceClient struct {
@@ -83,6 +95,56 @@ func (c *cartServiceClient) ListCart(ctx context.Context, in *ListCartRequest, o
 	return out, nil
 }
 
+func (c *cartServiceClient) Checkout(ctx context.Context, in *CheckoutRequest, opts ...grpc.CallOption) (*CheckoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckoutResponse)
+	err := c.cc.Invoke(ctx, CartService_Checkout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) ApplyPromoCode(ctx context.Context, in *ApplyPromoCodeRequest, opts ...grpc.CallOption) (*CartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CartResponse)
+	err := c.cc.Invoke(ctx, CartService_ApplyPromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) RemovePromoCode(ctx context.Context, in *RemovePromoCodeRequest, opts ...grpc.CallOption) (*CartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CartResponse)
+	err := c.cc.Invoke(ctx, CartService_RemovePromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) CreateGuestCart(ctx context.Context, in *CreateGuestCartRequest, opts ...grpc.CallOption) (*CreateGuestCartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGuestCartResponse)
+	err := c.cc.Invoke(ctx, CartService_CreateGuestCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) MergeCarts(ctx context.Context, in *MergeCartsRequest, opts ...grpc.CallOption) (*MergeCartsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCartsResponse)
+	err := c.cc.Invoke(ctx, CartService_MergeCarts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CartServiceServer is the server API for CartService service.
 // All implementations must embed UnimplementedCartServiceServer
 // for forward compatibility.
@@ -91,6 +153,13 @@ type CartServiceServer interface {
 	DeleteItem(context.Context, *DeleteItemRequest) (*CartResponse, error)
 	ClearCart(context.Context, *ClearCartRequest) (*CartResponse, error)
 	ListCart(context.Context, *ListCartRequest) (*ListCartResponse, error)
+	Checkout(context.Context, *CheckoutRequest) (*CheckoutResponse, error)
+	ApplyPromoCode(context.Context, *ApplyPromoCodeRequest) (*CartResponse, error)
+	RemovePromoCode(context.Context, *RemovePromoCodeRequest) (*CartResponse, error)
+	// CreateGuestCart needs no authentication. The returned token is sent in
+	// the X-Cart-Token header to use the guest cart.
+	CreateGuestCart(context.Context, *CreateGuestCartRequest) (*CreateGuestCartResponse, error)
+	MergeCarts(context.Context, *MergeCartsRequest) (*MergeCartsResponse, error)
 	mustEmbedUnimplementedCartServiceServer()
 }
 
@@ -113,6 +182,21 @@ func (UnimplementedCartServiceServer) ClearCart(context.Context, *ClearCartReque
 func (UnimplementedCartServiceServer) ListCart(context.Context, *ListCartRequest) (*ListCartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCart not implemented")
 }
+func (UnimplementedCartServiceServer) Checkout(context.Context, *CheckoutRequest) (*CheckoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkout not implemented")
+}
+func (UnimplementedCartServiceServer) ApplyPromoCode(context.Context, *ApplyPromoCodeRequest) (*CartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyPromoCode not implemented")
+}
+func (UnimplementedCartServiceServer) RemovePromoCode(context.Context, *RemovePromoCodeRequest) (*CartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePromoCode not implemented")
+}
+func (UnimplementedCartServiceServer) CreateGuestCart(context.Context, *CreateGuestCartRequest) (*CreateGuestCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGuestCart not implemented")
+}
+func (UnimplementedCartServiceServer) MergeCarts(context.Context, *MergeCartsRequest) (*MergeCartsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCarts not implemented")
+}
 func (UnimplementedCartServiceServer) mustEmbedUnimplementedCartServiceServer() {}
 func (UnimplementedCartServiceServer) testEmbeddedByValue()                     {}
 
@@ -206,6 +290,96 @@ func _CartService_ListCart_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartService_Checkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Checkout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_Checkout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Checkout(ctx, req.(*CheckoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_ApplyPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).ApplyPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_ApplyPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).ApplyPromoCode(ctx, req.(*ApplyPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_RemovePromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).RemovePromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_RemovePromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).RemovePromoCode(ctx, req.(*RemovePromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_CreateGuestCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGuestCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).CreateGuestCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_CreateGuestCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).CreateGuestCart(ctx, req.(*CreateGuestCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_MergeCarts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).MergeCarts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_MergeCarts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).MergeCarts(ctx, req.(*MergeCartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CartService_ServiceDesc is the grpc.ServiceDesc for CartService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +403,26 @@ var CartService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCart",
 			Handler:    _CartService_ListCart_Handler,
 		},
+		{
+			MethodName: "Checkout",
+			Handler:    _CartService_Checkout_Handler,
+		},
+		{
+			MethodName: "ApplyPromoCode",
+			Handler:    _CartService_ApplyPromoCode_Handler,
+		},
+		{
+			MethodName: "RemovePromoCode",
+			Handler:    _CartService_RemovePromoCode_Handler,
+		},
+		{
+			MethodName: "CreateGuestCart",
+			Handler:    _CartService_CreateGuestCart_Handler,
+		},
+		{
+			MethodName: "MergeCarts",
+			Handler:    _CartService_MergeCarts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
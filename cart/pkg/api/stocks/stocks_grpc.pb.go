@@ -20,10 +20,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StockService_AddItem_FullMethodName        = "/stock.StockService/AddItem"
-	StockService_DeleteItem_FullMethodName     = "/stock.StockService/DeleteItem"
-	StockService_GetItem_FullMethodName        = "/stock.StockService/GetItem"
-	StockService_ListByLocation_FullMethodName = "/stock.StockService/ListByLocation"
+	StockService_AddItem_FullMethodName            = "/stock.StockService/AddItem"
+	StockService_DeleteItem_FullMethodName         = "/stock.StockService/DeleteItem"
+	StockService_GetItem_FullMethodName            = "/stock.StockService/GetItem"
+	StockService_GetItems_FullMethodName           = "/stock.StockService/GetItems"
+	StockService_ListByLocation_FullMethodName     = "/stock.StockService/ListByLocation"
+	StockService_ReduceStock_FullMethodName        = "/stock.StockService/ReduceStock"
+	StockService_ListMovements_FullMethodName      = "/stock.StockService/ListMovements"
+	StockService_ReserveItems_FullMethodName       = "/stock.StockService/ReserveItems"
+	StockService_ReleaseReservation_FullMethodName = "/stock.StockService/ReleaseReservation"
+	StockService_CommitReservation_FullMethodName  = "/stock.StockService/CommitReservation"
+	StockService_CreateSKU_FullMethodName          = "/stock.StockService/CreateSKU"
+	StockService_UpdateSKU_FullMethodName          = "/stock.StockService/UpdateSKU"
+	StockService_ArchiveSKU_FullMethodName         = "/stock.StockService/ArchiveSKU"
+	StockService_GetSKU_FullMethodName             = "/stock.StockService/GetSKU"
+	StockService_ListSKUs_FullMethodName           = "/stock.StockService/ListSKUs"
 )
 
 // StockServiceClient is the client API for StockService service.
@@ -33,7 +44,19 @@ type StockServiceClient interface {
 	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*StockResponse, error)
 	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*StockResponse, error)
 	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*StockItem, error)
+	GetItems(ctx context.Context, in *GetItemsRequest, opts ...grpc.CallOption) (*GetItemsResponse, error)
 	ListByLocation(ctx context.Context, in *ListByLocationRequest, opts ...grpc.CallOption) (*ListByLocationResponse, error)
+	ReduceStock(ctx context.Context, in *ReduceStockRequest, opts ...grpc.CallOption) (*StockResponse, error)
+	ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error)
+	ReserveItems(ctx context.Context, in *ReserveItemsRequest, opts ...grpc.CallOption) (*Reservation, error)
+	ReleaseReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StockResponse, error)
+	CommitReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StockResponse, error)
+	// SKU catalog. Create, update and archive are admin only.
+	CreateSKU(ctx context.Context, in *CreateSKURequest, opts ...grpc.CallOption) (*SKU, error)
+	UpdateSKU(ctx context.Context, in *UpdateSKURequest, opts ...grpc.CallOption) (*SKU, error)
+	ArchiveSKU(ctx context.Context, in *ArchiveSKURequest, opts ...grpc.CallOption) (*SKU, error)
+	GetSKU(ctx context.Context, in *GetSKURequest, opts ...grpc.CallOption) (*SKU, error)
+	ListSKUs(ctx context.Context, in *ListSKUsRequest, opts ...grpc.CallOption) (*ListSKUsResponse, error)
 }
 
 type stockServiceClient struct {
@@ -74,6 +97,16 @@ func (c *stockServiceClient) GetItem(ctx context.Context, in *GetItemRequest, op
 	return out, nil
 }
 
+func (c *stockServiceClient) GetItems(ctx context.Context, in *GetItemsRequest, opts ...grpc.CallOption) (*GetItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItemsResponse)
+	err := c.cc.Invoke(ctx, StockService_GetItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *stockServiceClient) ListByLocation(ctx context.Context, in *ListByLocationRequest, opts ...grpc.CallOption) (*ListByLocationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListByLocationResponse)
@@ -84,6 +117,106 @@ func (c *stockServiceClient) ListByLocation(ctx context.Context, in *ListByLocat
 	return out, nil
 }
 
+func (c *stockServiceClient) ReduceStock(ctx context.Context, in *ReduceStockRequest, opts ...grpc.CallOption) (*StockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockResponse)
+	err := c.cc.Invoke(ctx, StockService_ReduceStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMovementsResponse)
+	err := c.cc.Invoke(ctx, StockService_ListMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ReserveItems(ctx context.Context, in *ReserveItemsRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, StockService_ReserveItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ReleaseReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockResponse)
+	err := c.cc.Invoke(ctx, StockService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) CommitReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockResponse)
+	err := c.cc.Invoke(ctx, StockService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) CreateSKU(ctx context.Context, in *CreateSKURequest, opts ...grpc.CallOption) (*SKU, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SKU)
+	err := c.cc.Invoke(ctx, StockService_CreateSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) UpdateSKU(ctx context.Context, in *UpdateSKURequest, opts ...grpc.CallOption) (*SKU, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SKU)
+	err := c.cc.Invoke(ctx, StockService_UpdateSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ArchiveSKU(ctx context.Context, in *ArchiveSKURequest, opts ...grpc.CallOption) (*SKU, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SKU)
+	err := c.cc.Invoke(ctx, StockService_ArchiveSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) GetSKU(ctx context.Context, in *GetSKURequest, opts ...grpc.CallOption) (*SKU, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SKU)
+	err := c.cc.Invoke(ctx, StockService_GetSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ListSKUs(ctx context.Context, in *ListSKUsRequest, opts ...grpc.CallOption) (*ListSKUsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSKUsResponse)
+	err := c.cc.Invoke(ctx, StockService_ListSKUs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StockServiceServer is the server API for StockService service.
 // All implementations must embed UnimplementedStockServiceServer
 // for forward compatibility.
@@ -91,7 +224,19 @@ type StockServiceServer interface {
 	AddItem(context.Context, *AddItemRequest) (*StockResponse, error)
 	DeleteItem(context.Context, *DeleteItemRequest) (*StockResponse, error)
 	GetItem(context.Context, *GetItemRequest) (*StockItem, error)
+	GetItems(context.Context, *GetItemsRequest) (*GetItemsResponse, error)
 	ListByLocation(context.Context, *ListByLocationRequest) (*ListByLocationResponse, error)
+	ReduceStock(context.Context, *ReduceStockRequest) (*StockResponse, error)
+	ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error)
+	ReserveItems(context.Context, *ReserveItemsRequest) (*Reservation, error)
+	ReleaseReservation(context.Context, *ReservationRequest) (*StockResponse, error)
+	CommitReservation(context.Context, *ReservationRequest) (*StockResponse, error)
+	// SKU catalog. Create, update and archive are admin only.
+	CreateSKU(context.Context, *CreateSKURequest) (*SKU, error)
+	UpdateSKU(context.Context, *UpdateSKURequest) (*SKU, error)
+	ArchiveSKU(context.Context, *ArchiveSKURequest) (*SKU, error)
+	GetSKU(context.Context, *GetSKURequest) (*SKU, error)
+	ListSKUs(context.Context, *ListSKUsRequest) (*ListSKUsResponse, error)
 	mustEmbedUnimplementedStockServiceServer()
 }
 
@@ -111,9 +256,42 @@ func (UnimplementedStockServiceServer) DeleteItem(context.Context, *DeleteItemRe
 func (UnimplementedStockServiceServer) GetItem(context.Context, *GetItemRequest) (*StockItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
 }
+func (UnimplementedStockServiceServer) GetItems(context.Context, *GetItemsRequest) (*GetItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItems not implemented")
+}
 func (UnimplementedStockServiceServer) ListByLocation(context.Context, *ListByLocationRequest) (*ListByLocationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListByLocation not implemented")
 }
+func (UnimplementedStockServiceServer) ReduceStock(context.Context, *ReduceStockRequest) (*StockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReduceStock not implemented")
+}
+func (UnimplementedStockServiceServer) ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMovements not implemented")
+}
+func (UnimplementedStockServiceServer) ReserveItems(context.Context, *ReserveItemsRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveItems not implemented")
+}
+func (UnimplementedStockServiceServer) ReleaseReservation(context.Context, *ReservationRequest) (*StockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedStockServiceServer) CommitReservation(context.Context, *ReservationRequest) (*StockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedStockServiceServer) CreateSKU(context.Context, *CreateSKURequest) (*SKU, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSKU not implemented")
+}
+func (UnimplementedStockServiceServer) UpdateSKU(context.Context, *UpdateSKURequest) (*SKU, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSKU not implemented")
+}
+func (UnimplementedStockServiceServer) ArchiveSKU(context.Context, *ArchiveSKURequest) (*SKU, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveSKU not implemented")
+}
+func (UnimplementedStockServiceServer) GetSKU(context.Context, *GetSKURequest) (*SKU, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSKU not implemented")
+}
+func (UnimplementedStockServiceServer) ListSKUs(context.Context, *ListSKUsRequest) (*ListSKUsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSKUs not implemented")
+}
 func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
 func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
 
@@ -189,6 +367,24 @@ func _StockService_GetItem_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_GetItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetItems(ctx, req.(*GetItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StockService_ListByLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListByLocationRequest)
 	if err := dec(in); err != nil {
@@ -207,6 +403,186 @@ func _StockService_ListByLocation_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_ReduceStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReduceStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReduceStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReduceStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReduceStock(ctx, req.(*ReduceStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ListMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ListMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ListMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ListMovements(ctx, req.(*ListMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ReserveItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReserveItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReserveItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReserveItems(ctx, req.(*ReserveItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReleaseReservation(ctx, req.(*ReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).CommitReservation(ctx, req.(*ReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_CreateSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).CreateSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_CreateSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).CreateSKU(ctx, req.(*CreateSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_UpdateSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).UpdateSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_UpdateSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).UpdateSKU(ctx, req.(*UpdateSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ArchiveSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ArchiveSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ArchiveSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ArchiveSKU(ctx, req.(*ArchiveSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_GetSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetSKU(ctx, req.(*GetSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ListSKUs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSKUsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ListSKUs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ListSKUs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ListSKUs(ctx, req.(*ListSKUsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -226,10 +602,54 @@ var StockService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetItem",
 			Handler:    _StockService_GetItem_Handler,
 		},
+		{
+			MethodName: "GetItems",
+			Handler:    _StockService_GetItems_Handler,
+		},
 		{
 			MethodName: "ListByLocation",
 			Handler:    _StockService_ListByLocation_Handler,
 		},
+		{
+			MethodName: "ReduceStock",
+			Handler:    _StockService_ReduceStock_Handler,
+		},
+		{
+			MethodName: "ListMovements",
+			Handler:    _StockService_ListMovements_Handler,
+		},
+		{
+			MethodName: "ReserveItems",
+			Handler:    _StockService_ReserveItems_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _StockService_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _StockService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "CreateSKU",
+			Handler:    _StockService_CreateSKU_Handler,
+		},
+		{
+			MethodName: "UpdateSKU",
+			Handler:    _StockService_UpdateSKU_Handler,
+		},
+		{
+			MethodName: "ArchiveSKU",
+			Handler:    _StockService_ArchiveSKU_Handler,
+		},
+		{
+			MethodName: "GetSKU",
+			Handler:    _StockService_GetSKU_Handler,
+		},
+		{
+			MethodName: "ListSKUs",
+			Handler:    _StockService_ListSKUs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stocks/stocks.proto",
@@ -61,6 +61,7 @@ func TestIntegration_AddItem(t *testing.T) {
 
 			req := httptest.NewRequest(tt.method, "/cart/item/add", bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			authorize(t, req)
 
 			rec := httptest.NewRecorder()
 			server.ServeHTTP(rec, req)
@@ -124,6 +125,7 @@ func TestIntegration_ClearCart(t *testing.T) {
 
 			req := httptest.NewRequest(tt.method, "/cart/clear", bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			authorize(t, req)
 
 			rec := httptest.NewRecorder()
 			server.ServeHTTP(rec, req)
@@ -185,6 +187,7 @@ func TestIntegration_DeleteItem(t *testing.T) {
 				}
 				reqAdd := httptest.NewRequest(http.MethodPost, "/cart/item/add", bytes.NewReader(bodyAdd))
 				reqAdd.Header.Set("Content-Type", "application/json")
+				authorize(t, reqAdd)
 				recAdd := httptest.NewRecorder()
 				server.ServeHTTP(recAdd, reqAdd)
 
@@ -209,6 +212,7 @@ func TestIntegration_DeleteItem(t *testing.T) {
 			}
 			reqDel := httptest.NewRequest(tt.method, "/cart/item/delete", bytes.NewReader(body))
 			reqDel.Header.Set("Content-Type", "application/json")
+			authorize(t, reqDel)
 			recDel := httptest.NewRecorder()
 			server.ServeHTTP(recDel, reqDel)
 
@@ -239,10 +243,10 @@ func TestIntegration_ListItems(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name:           "missing user_id",
+			name:           "user_id comes from the token",
 			method:         http.MethodPost,
 			payload:        map[string]interface{}{},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "method not allowed",
@@ -271,6 +275,7 @@ func TestIntegration_ListItems(t *testing.T) {
 
 			req := httptest.NewRequest(tt.method, "/cart/list", bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			authorize(t, req)
 			rec := httptest.NewRecorder()
 			server.ServeHTTP(rec, req)
 
@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	_ "github.com/lib/pq"
 )
 
@@ -77,3 +79,21 @@ func setupServer(t *testing.T, db *sql.DB) http.Handler {
 
 	return handler
 }
+
+// authorize signs a short-lived token for user 1 with the HMAC secret from
+// the test environment.
+func authorize(t *testing.T, req *http.Request) {
+	cfg := mustLoadConfig(t)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   "1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.AuthHMACSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+}
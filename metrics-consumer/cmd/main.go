@@ -12,6 +12,7 @@ import (
 	"github.com/ayshaat/metrics-consumer/internal/log"
 	"github.com/ayshaat/metrics-consumer/internal/log/zap"
 	"github.com/ayshaat/metrics-consumer/internal/metrics"
+	"github.com/ayshaat/metrics-consumer/internal/processor"
 	"github.com/ayshaat/metrics-consumer/internal/trace"
 
 	"github.com/Shopify/sarama"
@@ -49,7 +50,27 @@ func main() {
 
 	metricsInstance := metrics.RegisterMetrics()
 	metrics.StartMetricsServer(":9095")
-	consumer := kafka.NewConsumer(logger, metricsInstance)
+
+	dlq, err := kafka.NewDeadLetterProducer(cfg.KafkaBrokers, cfg.DLQTopic, logger)
+	if err != nil {
+		logger.Error("Error creating DLQ producer", log.Error(err))
+		os.Exit(1)
+	}
+
+	defer func() {
+		if err := dlq.Close(); err != nil {
+			logger.Error("Error closing DLQ producer", log.Error(err))
+		}
+	}()
+
+	retry := kafka.RetryPolicy{
+		MaxAttempts:    cfg.RetryMaxAttempts,
+		InitialBackoff: cfg.RetryInitialBackoff,
+		MaxBackoff:     cfg.RetryMaxBackoff,
+	}
+	handler := processor.New(metrics.RegisterBusinessMetrics(), logger).Handle
+
+	consumer := kafka.NewConsumer(logger, metricsInstance, handler, retry, dlq)
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -73,7 +94,7 @@ func main() {
 			if ctx.Err() != nil {
 				return
 			}
-			consumer = kafka.NewConsumer(logger, metricsInstance)
+			consumer = kafka.NewConsumer(logger, metricsInstance, handler, retry, dlq)
 		}
 	}()
 
@@ -0,0 +1,128 @@
+// Command redrive moves messages from the dead-letter topic back to the topic
+// they came from. Run it once the cause of the failures has been fixed:
+//
+//	go run ./cmd/redrive -limit 100
+//
+// It stops after -limit messages, or once no message arrived for -idle.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ayshaat/metrics-consumer/internal/config"
+	"github.com/ayshaat/metrics-consumer/internal/kafka"
+	"github.com/ayshaat/metrics-consumer/internal/log"
+	"github.com/ayshaat/metrics-consumer/internal/log/zap"
+
+	"github.com/Shopify/sarama"
+)
+
+const idleCheckInterval = time.Second
+
+func main() {
+	envFile := flag.String("env", ".env.docker", "env file")
+	group := flag.String("group", "metrics-consumer-redrive", "consumer group used to track redriven messages")
+	limit := flag.Int("limit", 0, "maximum number of messages to redrive, 0 for all")
+	idle := flag.Duration("idle", 30*time.Second, "stop after this long without messages")
+	flag.Parse()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer cleanup()
+
+	if err := run(*envFile, *group, *limit, *idle, logger); err != nil {
+		logger.Error("Redrive failed", log.Error(err))
+		cleanup()
+		os.Exit(1)
+	}
+}
+
+func run(envFile, group string, limit int, idle time.Duration, logger log.Logger) error {
+	cfg, err := config.Load(envFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	redriver, err := kafka.NewRedriver(cfg.KafkaBrokers, cfg.Topic, limit, logger)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := redriver.Close(); err != nil {
+			logger.Error("Error closing redrive producer", log.Error(err))
+		}
+	}()
+
+	configSarama := sarama.NewConfig()
+	configSarama.Version = sarama.V2_8_0_0
+	configSarama.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	client, err := sarama.NewConsumerGroup(cfg.KafkaBrokers, group, configSarama)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	defer func() {
+		if err := client.Close(); err != nil {
+			logger.Error("Error closing client", log.Error(err))
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		for {
+			if err := client.Consume(ctx, []string{cfg.DLQTopic}, redriver); err != nil {
+				errCh <- err
+				return
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	logger.Info("Redriving DLQ",
+		log.String("dlq_topic", cfg.DLQTopic),
+		log.String("group", group),
+		log.Int("limit", limit),
+	)
+
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-redriver.Done():
+			logger.Info("Redrive limit reached", log.Int64("redriven", redriver.Count()))
+			return nil
+		case sig := <-sigterm:
+			logger.Info("Redrive interrupted", log.String("signal", sig.String()), log.Int64("redriven", redriver.Count()))
+			return nil
+		case err := <-errCh:
+			return err
+		case <-ticker.C:
+			if redriver.IdleFor() >= idle {
+				logger.Info("DLQ drained", log.Int64("redriven", redriver.Count()))
+				return nil
+			}
+		}
+	}
+}
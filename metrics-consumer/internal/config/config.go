@@ -3,7 +3,15 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 200 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
 )
 
 type Config struct {
@@ -11,6 +19,11 @@ type Config struct {
 	ConsumerGroup  string
 	Topic          string
 	JaegerEndpoint string
+
+	DLQTopic            string
+	RetryMaxAttempts    int
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
 }
 
 func Load(envFile string) (*Config, error) {
@@ -34,6 +47,35 @@ func Load(envFile string) (*Config, error) {
 		jaegerEndpoint = "http://localhost:14268/api/traces" // or log an error if it's required
 	}
 
+	dlqTopic := os.Getenv("DLQ_TOPIC")
+	if dlqTopic == "" {
+		dlqTopic = topic + ".dlq"
+	}
+
+	maxAttempts := defaultRetryMaxAttempts
+	if v := os.Getenv("RETRY_MAX_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid RETRY_MAX_ATTEMPTS %q", v)
+		}
+
+		maxAttempts = n
+	}
+
+	initialBackoff, err := durationEnv("RETRY_INITIAL_BACKOFF", defaultRetryInitialBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	maxBackoff, err := durationEnv("RETRY_MAX_BACKOFF", defaultRetryMaxBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxBackoff < initialBackoff {
+		return nil, fmt.Errorf("RETRY_MAX_BACKOFF must not be less than RETRY_INITIAL_BACKOFF")
+	}
+
 	brokers := []string{}
 	brokers = append(brokers, splitAndTrim(brokersEnv, ",")...)
 
@@ -42,9 +84,28 @@ func Load(envFile string) (*Config, error) {
 		ConsumerGroup:  consumerGroup,
 		Topic:          topic,
 		JaegerEndpoint: jaegerEndpoint,
+
+		DLQTopic:            dlqTopic,
+		RetryMaxAttempts:    maxAttempts,
+		RetryInitialBackoff: initialBackoff,
+		RetryMaxBackoff:     maxBackoff,
 	}, nil
 }
 
+func durationEnv(name string, def time.Duration) (time.Duration, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, v)
+	}
+
+	return d, nil
+}
+
 func splitAndTrim(s, sep string) []string {
 	parts := strings.Split(s, sep)
 	for i := range parts {
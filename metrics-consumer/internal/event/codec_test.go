@@ -0,0 +1,175 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	eventspb "github.com/ayshaat/metrics-consumer/pkg/api/events"
+	moneypb "github.com/ayshaat/metrics-consumer/pkg/api/money"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUnmarshal_Legacy(t *testing.T) {
+	t.Parallel()
+
+	publishedAt := timestamppb.New(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		data    string
+		want    *eventspb.Envelope
+		wantErr bool
+	}{
+		{
+			name: "baseline stock_changed",
+			data: `{"type":"stock_changed","service":"stocks-service","timestamp":"2026-10-17T12:00:00Z",` +
+				`"payload":{"sku":"1001","count":5,"price":10.5}}`,
+			want: &eventspb.Envelope{
+				Type:          TypeStockChanged,
+				SchemaVersion: 1,
+				Source:        "stocks-service",
+				OccurredAt:    publishedAt,
+				Payload: &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{
+					Sku:   "1001",
+					Count: 5,
+					Price: 10.5,
+				}},
+			},
+		},
+		{
+			name: "baseline sku_created",
+			data: `{"type":"sku_created","service":"stocks-service","timestamp":"2026-10-17T12:00:00Z",` +
+				`"payload":{"sku":"1001","price":10.5,"count":5}}`,
+			want: &eventspb.Envelope{
+				Type:          TypeSKUCreated,
+				SchemaVersion: 1,
+				Source:        "stocks-service",
+				OccurredAt:    publishedAt,
+				Payload: &eventspb.Envelope_SkuCreated{SkuCreated: &eventspb.SKUCreated{
+					Sku:   "1001",
+					Price: 10.5,
+					Count: 5,
+				}},
+			},
+		},
+		{
+			name: "baseline cart_item_added",
+			data: `{"type":"cart_item_added","service":"cart-service","timestamp":"2026-10-17T12:00:00Z",` +
+				`"payload":{"cartId":"7","sku":"1001","count":2,"status":"added"}}`,
+			want: &eventspb.Envelope{
+				Type:          TypeCartItemAdded,
+				SchemaVersion: 1,
+				Source:        "cart-service",
+				OccurredAt:    publishedAt,
+				Payload: &eventspb.Envelope_CartItemAdded{CartItemAdded: &eventspb.CartItemAdded{
+					CartId: "7",
+					Sku:    "1001",
+					Count:  2,
+					Status: "added",
+				}},
+			},
+		},
+		{
+			name: "baseline cart_item_failed",
+			data: `{"type":"cart_item_failed","service":"cart-service","timestamp":"2026-10-17T12:00:00Z",` +
+				`"payload":{"cartId":"7","sku":"1001","count":2,"status":"failed","reason":"not enough stock"}}`,
+			want: &eventspb.Envelope{
+				Type:          TypeCartItemFailed,
+				SchemaVersion: 1,
+				Source:        "cart-service",
+				OccurredAt:    publishedAt,
+				Payload: &eventspb.Envelope_CartItemFailed{CartItemFailed: &eventspb.CartItemFailed{
+					CartId: "7",
+					Sku:    "1001",
+					Count:  2,
+					Status: "failed",
+					Reason: "not enough stock",
+				}},
+			},
+		},
+		{
+			name: "versioned with unit price and unknown fields",
+			data: `{"type":"stock_changed","version":1,"service":"stocks-service","timestamp":"2026-10-17T12:00:00Z",` +
+				`"payload":{"sku":"1001","count":5,"unitPrice":{"currencyCode":"RUB","units":10,"nanos":500000000},"extra":true}}`,
+			want: &eventspb.Envelope{
+				Type:          TypeStockChanged,
+				SchemaVersion: 1,
+				Source:        "stocks-service",
+				OccurredAt:    publishedAt,
+				Payload: &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{
+					Sku:       "1001",
+					Count:     5,
+					UnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 10, Nanos: 500000000},
+				}},
+			},
+		},
+		{
+			name: "unparsable timestamp is dropped",
+			data: `{"type":"stock_changed","service":"stocks-service","timestamp":"yesterday","payload":{"sku":"1001","count":5}}`,
+			want: &eventspb.Envelope{
+				Type:          TypeStockChanged,
+				SchemaVersion: 1,
+				Source:        "stocks-service",
+				Payload:       &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{Sku: "1001", Count: 5}},
+			},
+		},
+		{
+			name: "unknown type",
+			data: `{"type":"sku_renamed","service":"stocks-service","payload":{"sku":"1001"}}`,
+			want: &eventspb.Envelope{Type: "sku_renamed", SchemaVersion: 1, Source: "stocks-service"},
+		},
+		{
+			name:    "invalid payload",
+			data:    `{"type":"stock_changed","payload":{"sku":"1001","count":"five"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			data:    `stock_changed`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Unmarshal("", []byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(tt.want, got), "got %v", got)
+		})
+	}
+}
+
+func TestUnmarshal_ContentTypes(t *testing.T) {
+	t.Parallel()
+
+	env := &eventspb.Envelope{
+		Type:          TypeStockChanged,
+		SchemaVersion: 1,
+		Payload:       &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{Sku: "1001", Count: 5}},
+	}
+
+	protobuf, err := proto.Marshal(env)
+	assert.NoError(t, err)
+
+	_, err = Unmarshal(ContentTypeProtobuf, protobuf)
+	assert.NoError(t, err)
+
+	_, err = Unmarshal(ContentTypeJSON+"; charset=utf-8", []byte(`{"type":"stock_changed","schemaVersion":1,"stockChanged":{"sku":"1001"}}`))
+	assert.NoError(t, err)
+
+	_, err = Unmarshal("text/plain", protobuf)
+	assert.Error(t, err)
+
+	_, err = Unmarshal(ContentTypeJSON, []byte(`{"type":"cart_cleared","stockChanged":{"sku":"1001"}}`))
+	assert.Error(t, err, "type and payload disagree")
+}
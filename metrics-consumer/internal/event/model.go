@@ -1,8 +1,26 @@
 package event
 
-type KafkaMessage struct {
-	Type      string      `json:"type"`
-	Service   string      `json:"service"`
-	Timestamp string      `json:"timestamp"`
-	Payload   interface{} `json:"payload"`
+// Event types are the names of the payload fields of eventspb.Envelope.
+const (
+	TypeCartItemAdded   = "cart_item_added"
+	TypeCartItemFailed  = "cart_item_failed"
+	TypeCartItemRemoved = "cart_item_removed"
+	TypeCartCleared     = "cart_cleared"
+	TypeSKUCreated      = "sku_created"
+	TypeStockChanged    = "stock_changed"
+	TypeStockDeleted    = "stock_deleted"
+	TypePriceChanged    = "price_changed"
+)
+
+// SchemaVersions holds the newest payload schema version the consumer can
+// read for every event type it handles.
+var SchemaVersions = map[string]int32{
+	TypeCartItemAdded:   1,
+	TypeCartItemFailed:  1,
+	TypeCartItemRemoved: 1,
+	TypeCartCleared:     1,
+	TypeSKUCreated:      1,
+	TypeStockChanged:    1,
+	TypeStockDeleted:    1,
+	TypePriceChanged:    1,
 }
@@ -2,32 +2,43 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"github.com/ayshaat/metrics-consumer/internal/event"
 	"github.com/ayshaat/metrics-consumer/internal/log"
 	"github.com/ayshaat/metrics-consumer/internal/metrics"
+	eventspb "github.com/ayshaat/metrics-consumer/pkg/api/events"
 
 	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// Handler processes a decoded event. Errors are retried according to the
+// consumer's RetryPolicy unless wrapped with Permanent.
+type Handler func(ctx context.Context, evt *eventspb.Envelope) error
+
 type Consumer struct {
 	Ready   chan bool
 	Logger  log.Logger
 	Tracer  trace.Tracer
 	Metrics *metrics.Metrics
+	Handler Handler
+	Retry   RetryPolicy
+	DLQ     DeadLetterSink
 }
 
-func NewConsumer(logger log.Logger, m *metrics.Metrics) *Consumer {
+func NewConsumer(logger log.Logger, m *metrics.Metrics, handler Handler, retry RetryPolicy, dlq DeadLetterSink) *Consumer {
 	return &Consumer{
 		Ready:   make(chan bool),
 		Logger:  logger,
 		Tracer:  otel.Tracer("metrics-consumer"),
 		Metrics: m,
+		Handler: handler,
+		Retry:   retry,
+		DLQ:     dlq,
 	}
 }
 
@@ -40,56 +51,128 @@ func (c *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
 	return nil
 }
 
+// ConsumeClaim only marks a message once it was handled or parked in the DLQ.
+// If the DLQ is unavailable the claim stops, so the message is redelivered
+// after the rebalance instead of being lost.
 func (c *Consumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	ctx := context.Background()
-
 	for msg := range claim.Messages() {
-		start := time.Now()
-		_, span := c.Tracer.Start(ctx, "ConsumeKafkaMessage")
+		if err := c.consume(sess.Context(), msg); err != nil {
+			return err
+		}
+
+		sess.MarkMessage(msg, "")
+	}
+
+	return nil
+}
+
+func (c *Consumer) consume(sessCtx context.Context, msg *sarama.ConsumerMessage) error {
+	start := time.Now()
+
+	headers := headerCarrier(msg.Headers)
+	evt, decodeErr := event.Unmarshal(headers.Get(event.HeaderContentType), msg.Value)
+
+	// Continue the producer's trace when the message carries one.
+	ctx := extractTrace(sessCtx, headers, evt)
+	ctx, span := c.Tracer.Start(ctx, "ConsumeKafkaMessage", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("kafka.topic", msg.Topic),
+		attribute.Int64("kafka.offset", msg.Offset),
+		attribute.Int("kafka.partition", int(msg.Partition)),
+	)
+
+	c.Logger.Info("Kafka message received",
+		log.String("topic", msg.Topic),
+		log.Int32("partition", msg.Partition),
+		log.Int64("offset", msg.Offset),
+	)
+
+	if evt != nil {
 		span.SetAttributes(
-			attribute.String("kafka.topic", msg.Topic),
-			attribute.Int64("kafka.offset", msg.Offset),
-			attribute.Int("kafka.partition", int(msg.Partition)),
+			attribute.String("event.type", evt.GetType()),
+			attribute.String("event.id", evt.GetEventId()),
 		)
+	}
 
-		c.Logger.Info("Kafka message received",
-			log.String("topic", msg.Topic),
-			log.Int32("partition", msg.Partition),
-			log.Int64("offset", msg.Offset),
-		)
+	attempts, err := c.process(ctx, evt, decodeErr)
 
-		var event event.KafkaMessage
+	duration := time.Since(start).Seconds()
+	if c.Metrics != nil {
+		c.Metrics.ConsumedMessages.WithLabelValues(msg.Topic).Inc()
+		c.Metrics.ConsumeDuration.WithLabelValues(msg.Topic).Observe(duration)
+		if err != nil {
+			c.Metrics.ConsumeErrors.WithLabelValues(msg.Topic).Inc()
+		}
+	}
 
-		err := json.Unmarshal(msg.Value, &event)
+	if err == nil {
+		return nil
+	}
 
-		duration := time.Since(start).Seconds()
-		if c.Metrics != nil {
-			c.Metrics.RequestsTotal.WithLabelValues("kafka_consumer_consume", "HTTP").Inc()
-			c.Metrics.RequestDuration.WithLabelValues("kafka_consumer_consume", "HTTP").Observe(duration)
-			if err != nil {
-				c.Metrics.RequestErrors.WithLabelValues("kafka_consumer_consume", "HTTP").Inc()
-			}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	// Shutting down: leave the message unmarked so it is consumed again.
+	if sessCtx.Err() != nil {
+		return sessCtx.Err()
+	}
+
+	return c.deadLetter(msg, err, attempts)
+}
+
+func (c *Consumer) process(ctx context.Context, evt *eventspb.Envelope, decodeErr error) (int, error) {
+	if decodeErr != nil {
+		c.Logger.Error("Failed to unmarshal Kafka message", log.Error(decodeErr))
+		return 1, Permanent(decodeErr)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.Handler(ctx, evt)
+		if err == nil || IsPermanent(err) || attempt >= c.Retry.MaxAttempts {
+			return attempt, err
 		}
 
-		if err != nil {
-			c.Logger.Error("Failed to unmarshal Kafka message", log.Error(err))
-			span.RecordError(err)
-			span.End()
-			sess.MarkMessage(msg, "")
+		backoff := c.Retry.Backoff(attempt)
+
+		c.Logger.Warn("Failed to handle event, retrying",
+			log.String("event_type", evt.GetType()),
+			log.Int("attempt", attempt),
+			log.Duration("backoff", backoff),
+			log.Error(err),
+		)
 
-			continue
+		if c.Metrics != nil {
+			c.Metrics.ConsumerRetries.Inc()
 		}
 
-		eventBytes, err := json.MarshalIndent(event, "", "  ")
-		if err != nil {
-			c.Logger.Error("Failed to pretty-print Kafka message", log.Error(err))
-			span.RecordError(err)
-		} else {
-			c.Logger.Info("Consumed event", log.String("event", string(eventBytes)))
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(backoff):
 		}
+	}
+}
 
-		sess.MarkMessage(msg, "")
-		span.End()
+func (c *Consumer) deadLetter(msg *sarama.ConsumerMessage, cause error, attempts int) error {
+	reason := "retries_exhausted"
+	if IsPermanent(cause) {
+		reason = "permanent"
+	}
+
+	if err := c.DLQ.Send(msg, cause, attempts); err != nil {
+		c.Logger.Error("Failed to move message to DLQ", log.Error(err))
+
+		if c.Metrics != nil {
+			c.Metrics.DLQPublishErrors.Inc()
+		}
+
+		return err
+	}
+
+	if c.Metrics != nil {
+		c.Metrics.DLQMessages.WithLabelValues(reason).Inc()
 	}
 
 	return nil
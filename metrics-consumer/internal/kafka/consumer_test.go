@@ -0,0 +1,222 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ayshaat/metrics-consumer/internal/event"
+	"github.com/ayshaat/metrics-consumer/internal/log/zap"
+	eventspb "github.com/ayshaat/metrics-consumer/pkg/api/events"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var errTransient = errors.New("database is down")
+
+// deadLetters records the messages a consumer parks.
+type deadLetters struct {
+	msgs     []*sarama.ConsumerMessage
+	causes   []error
+	attempts []int
+	err      error
+}
+
+func (d *deadLetters) Send(msg *sarama.ConsumerMessage, cause error, attempts int) error {
+	if d.err != nil {
+		return d.err
+	}
+
+	d.msgs = append(d.msgs, msg)
+	d.causes = append(d.causes, cause)
+	d.attempts = append(d.attempts, attempts)
+
+	return nil
+}
+
+// failingHandler fails its first calls with the given errors, then succeeds.
+func failingHandler(calls *int, errs ...error) Handler {
+	return func(context.Context, *eventspb.Envelope) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+
+		return nil
+	}
+}
+
+func newTestConsumer(t *testing.T, handler Handler, dlq DeadLetterSink) *Consumer {
+	t.Helper()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	retry := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	return NewConsumer(logger, nil, handler, retry, dlq)
+}
+
+func TestConsumer_Process(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		errs         []error
+		decodeErr    error
+		wantCalls    int
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "success", wantCalls: 1, wantAttempts: 1},
+		{
+			name:         "transient errors are retried",
+			errs:         []error{errTransient, errTransient},
+			wantCalls:    3,
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries are bounded",
+			errs:         []error{errTransient, errTransient, errTransient, errTransient},
+			wantCalls:    3,
+			wantAttempts: 3,
+			wantErr:      errTransient,
+		},
+		{
+			name:         "permanent errors are not retried",
+			errs:         []error{Permanent(errTransient)},
+			wantCalls:    1,
+			wantAttempts: 1,
+			wantErr:      errTransient,
+		},
+		{
+			name:         "undecodable messages are permanent",
+			decodeErr:    errors.New("invalid envelope"),
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			c := newTestConsumer(t, failingHandler(&calls, tt.errs...), &deadLetters{})
+
+			attempts, err := c.process(context.Background(), &eventspb.Envelope{Type: event.TypeStockChanged}, tt.decodeErr)
+
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantAttempts, attempts)
+
+			switch {
+			case tt.decodeErr != nil:
+				assert.True(t, IsPermanent(err))
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsumer_Process_Canceled(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	c := newTestConsumer(t, failingHandler(&calls, errTransient, errTransient), &deadLetters{})
+	c.Retry.InitialBackoff = time.Hour
+	c.Retry.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts, err := c.process(ctx, &eventspb.Envelope{}, nil)
+
+	assert.ErrorIs(t, err, context.Canceled, "shutdown does not wait out the backoff")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestConsumer_Consume_DeadLetters(t *testing.T) {
+	t.Parallel()
+
+	valid, err := proto.Marshal(&eventspb.Envelope{
+		Type:          event.TypeStockChanged,
+		SchemaVersion: 1,
+		Payload:       &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{Sku: "1001", Count: 1}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	protobuf := []*sarama.RecordHeader{{Key: []byte(event.HeaderContentType), Value: []byte(event.ContentTypeProtobuf)}}
+
+	tests := []struct {
+		name         string
+		value        []byte
+		errs         []error
+		dlqErr       error
+		wantParked   bool
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "handled", value: valid},
+		{
+			name:         "retries exhausted",
+			value:        valid,
+			errs:         []error{errTransient, errTransient, errTransient},
+			wantParked:   true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "undecodable",
+			value:        []byte("not protobuf"),
+			wantParked:   true,
+			wantAttempts: 1,
+		},
+		{
+			name:    "DLQ unavailable stops the claim",
+			value:   valid,
+			errs:    []error{Permanent(errTransient)},
+			dlqErr:  errors.New("broker down"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			dlq := &deadLetters{err: tt.dlqErr}
+			c := newTestConsumer(t, failingHandler(&calls, tt.errs...), dlq)
+
+			msg := &sarama.ConsumerMessage{Topic: "metrics", Headers: protobuf, Value: tt.value}
+			err := c.consume(context.Background(), msg)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			if !tt.wantParked {
+				assert.Empty(t, dlq.msgs)
+				return
+			}
+
+			if assert.Len(t, dlq.msgs, 1) {
+				assert.Same(t, msg, dlq.msgs[0])
+				assert.Equal(t, tt.wantAttempts, dlq.attempts[0])
+				assert.Error(t, dlq.causes[0])
+			}
+		})
+	}
+}
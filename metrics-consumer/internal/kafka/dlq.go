@@ -0,0 +1,111 @@
+package kafka
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayshaat/metrics-consumer/internal/log"
+
+	"github.com/Shopify/sarama"
+)
+
+// Headers added to dead-lettered messages. Everything else, including the
+// trace context, is copied from the original message.
+const (
+	dlqHeaderPrefix            = "x-dlq-"
+	dlqHeaderOriginalTopic     = dlqHeaderPrefix + "original-topic"
+	dlqHeaderOriginalPartition = dlqHeaderPrefix + "original-partition"
+	dlqHeaderOriginalOffset    = dlqHeaderPrefix + "original-offset"
+	dlqHeaderError             = dlqHeaderPrefix + "error"
+	dlqHeaderAttempts          = dlqHeaderPrefix + "attempts"
+	dlqHeaderFailedAt          = dlqHeaderPrefix + "failed-at"
+)
+
+type DeadLetterSink interface {
+	Send(msg *sarama.ConsumerMessage, cause error, attempts int) error
+}
+
+type DeadLetterProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+	logger   log.Logger
+}
+
+func NewDeadLetterProducer(brokers []string, topic string, logger log.Logger) (*DeadLetterProducer, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DLQ producer: %w", err)
+	}
+
+	return &DeadLetterProducer{
+		producer: producer,
+		topic:    topic,
+		logger:   logger,
+	}, nil
+}
+
+func (p *DeadLetterProducer) Send(msg *sarama.ConsumerMessage, cause error, attempts int) error {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+6)
+	for _, h := range msg.Headers {
+		if h == nil || strings.HasPrefix(string(h.Key), dlqHeaderPrefix) {
+			continue
+		}
+
+		headers = append(headers, *h)
+	}
+
+	headers = append(headers,
+		stringHeader(dlqHeaderOriginalTopic, msg.Topic),
+		stringHeader(dlqHeaderOriginalPartition, strconv.FormatInt(int64(msg.Partition), 10)),
+		stringHeader(dlqHeaderOriginalOffset, strconv.FormatInt(msg.Offset, 10)),
+		stringHeader(dlqHeaderError, cause.Error()),
+		stringHeader(dlqHeaderAttempts, strconv.Itoa(attempts)),
+		stringHeader(dlqHeaderFailedAt, time.Now().UTC().Format(time.RFC3339)),
+	)
+
+	_, offset, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   p.topic,
+		Key:     keyEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send message to DLQ: %w", err)
+	}
+
+	p.logger.Warn("Message moved to DLQ",
+		log.String("dlq_topic", p.topic),
+		log.Int64("dlq_offset", offset),
+		log.String("topic", msg.Topic),
+		log.Int32("partition", msg.Partition),
+		log.Int64("offset", msg.Offset),
+		log.Int("attempts", attempts),
+		log.Error(cause),
+	)
+
+	return nil
+}
+
+func (p *DeadLetterProducer) Close() error {
+	return p.producer.Close()
+}
+
+func stringHeader(key, value string) sarama.RecordHeader {
+	return sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+}
+
+// keyEncoder keeps a missing key missing: an empty key is hashed like any
+// other, so every keyless message would land on the same partition.
+func keyEncoder(key []byte) sarama.Encoder {
+	if key == nil {
+		return nil
+	}
+
+	return sarama.ByteEncoder(key)
+}
@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ayshaat/metrics-consumer/internal/log/zap"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingProducer keeps the messages it was asked to send.
+type recordingProducer struct {
+	sarama.SyncProducer
+
+	sent []*sarama.ProducerMessage
+}
+
+func (p *recordingProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.sent = append(p.sent, msg)
+	return msg.Partition, int64(len(p.sent)), nil
+}
+
+func headerMap(headers []sarama.RecordHeader) map[string]string {
+	m := make(map[string]string, len(headers))
+	for _, h := range headers {
+		m[string(h.Key)] = string(h.Value)
+	}
+
+	return m
+}
+
+func TestDeadLetterProducer_Send(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		wantKey sarama.Encoder
+	}{
+		{name: "keyed", key: []byte("1001"), wantKey: sarama.ByteEncoder("1001")},
+		{name: "without a key", key: nil, wantKey: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			producer := &recordingProducer{}
+			dlq := &DeadLetterProducer{producer: producer, topic: "metrics.dlq", logger: logger}
+
+			msg := &sarama.ConsumerMessage{
+				Topic:     "metrics",
+				Partition: 2,
+				Offset:    42,
+				Key:       tt.key,
+				Value:     []byte("payload"),
+				Headers: []*sarama.RecordHeader{
+					{Key: []byte("traceparent"), Value: []byte("00-abc-def-01")},
+					// Left by an earlier trip through the DLQ.
+					{Key: []byte(dlqHeaderError), Value: []byte("old error")},
+				},
+			}
+
+			before := time.Now().UTC().Truncate(time.Second)
+
+			if err := dlq.Send(msg, errors.New("invalid SKU"), 3); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !assert.Len(t, producer.sent, 1) {
+				return
+			}
+
+			sent := producer.sent[0]
+			assert.Equal(t, "metrics.dlq", sent.Topic)
+			assert.Equal(t, tt.wantKey, sent.Key)
+			assert.Equal(t, sarama.ByteEncoder("payload"), sent.Value)
+
+			headers := headerMap(sent.Headers)
+			assert.Len(t, sent.Headers, 7, "one copy of each header")
+			assert.Equal(t, "00-abc-def-01", headers["traceparent"])
+			assert.Equal(t, "metrics", headers[dlqHeaderOriginalTopic])
+			assert.Equal(t, "2", headers[dlqHeaderOriginalPartition])
+			assert.Equal(t, "42", headers[dlqHeaderOriginalOffset])
+			assert.Equal(t, "invalid SKU", headers[dlqHeaderError])
+			assert.Equal(t, "3", headers[dlqHeaderAttempts])
+
+			failedAt, err := time.Parse(time.RFC3339, headers[dlqHeaderFailedAt])
+			if assert.NoError(t, err) {
+				assert.False(t, failedAt.Before(before))
+			}
+		})
+	}
+}
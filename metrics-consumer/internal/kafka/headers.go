@@ -0,0 +1,53 @@
+package kafka
+
+import (
+	"context"
+
+	eventspb "github.com/ayshaat/metrics-consumer/pkg/api/events"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier lets the OpenTelemetry propagator read trace context from
+// Kafka record headers.
+type headerCarrier []*sarama.RecordHeader
+
+func (c headerCarrier) Get(key string) string {
+	for _, h := range c {
+		if h != nil && string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set is a no-op: consumed headers are read-only.
+func (c headerCarrier) Set(string, string) {}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for _, h := range c {
+		if h != nil {
+			keys = append(keys, string(h.Key))
+		}
+	}
+
+	return keys
+}
+
+// extractTrace continues the publisher's trace from the record headers, or
+// from the envelope when the headers carry none.
+func extractTrace(ctx context.Context, headers headerCarrier, evt *eventspb.Envelope) context.Context {
+	propagator := otel.GetTextMapPropagator()
+
+	extracted := propagator.Extract(ctx, headers)
+	if trace.SpanContextFromContext(extracted).IsValid() || evt == nil {
+		return extracted
+	}
+
+	return propagator.Extract(ctx, propagation.MapCarrier(evt.GetTraceContext()))
+}
@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ayshaat/metrics-consumer/internal/log"
+
+	"github.com/Shopify/sarama"
+)
+
+// Redriver moves messages from the dead-letter topic back to the topic and
+// partition they originally came from, dropping the DLQ headers.
+type Redriver struct {
+	producer     sarama.SyncProducer
+	defaultTopic string
+	limit        int
+	logger       log.Logger
+
+	count        atomic.Int64
+	lastActivity atomic.Int64
+	done         chan struct{}
+	doneOnce     sync.Once
+}
+
+// NewRedriver creates a Redriver. Messages without an original topic header
+// go to defaultTopic. A limit of 0 means no limit.
+func NewRedriver(brokers []string, defaultTopic string, limit int, logger log.Logger) (*Redriver, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	config.Producer.Partitioner = sarama.NewManualPartitioner
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redrive producer: %w", err)
+	}
+
+	r := &Redriver{
+		producer:     producer,
+		defaultTopic: defaultTopic,
+		limit:        limit,
+		logger:       logger,
+		done:         make(chan struct{}),
+	}
+	r.lastActivity.Store(time.Now().UnixNano())
+
+	return r, nil
+}
+
+func (r *Redriver) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (r *Redriver) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (r *Redriver) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case <-sess.Context().Done():
+			return nil
+		case <-r.done:
+			return nil
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			if err := r.redrive(msg); err != nil {
+				return err
+			}
+
+			sess.MarkMessage(msg, "")
+			r.lastActivity.Store(time.Now().UnixNano())
+
+			if n := r.count.Add(1); r.limit > 0 && n >= int64(r.limit) {
+				r.doneOnce.Do(func() { close(r.done) })
+			}
+		}
+	}
+}
+
+func (r *Redriver) redrive(msg *sarama.ConsumerMessage) error {
+	topic := r.defaultTopic
+	partition := int32(0)
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
+
+	for _, h := range msg.Headers {
+		if h == nil {
+			continue
+		}
+
+		switch key := string(h.Key); {
+		case key == dlqHeaderOriginalTopic:
+			topic = string(h.Value)
+		case key == dlqHeaderOriginalPartition:
+			p, err := strconv.ParseInt(string(h.Value), 10, 32)
+			if err == nil {
+				partition = int32(p)
+			}
+		case strings.HasPrefix(key, dlqHeaderPrefix):
+		default:
+			headers = append(headers, *h)
+		}
+	}
+
+	_, offset, err := r.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:     topic,
+		Partition: partition,
+		Key:       keyEncoder(msg.Key),
+		Value:     sarama.ByteEncoder(msg.Value),
+		Headers:   headers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to redrive message %d: %w", msg.Offset, err)
+	}
+
+	r.logger.Info("Message redriven",
+		log.Int64("dlq_offset", msg.Offset),
+		log.String("topic", topic),
+		log.Int32("partition", partition),
+		log.Int64("offset", offset),
+	)
+
+	return nil
+}
+
+// Done is closed once the limit has been reached.
+func (r *Redriver) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *Redriver) Count() int64 {
+	return r.count.Load()
+}
+
+// IdleFor reports how long ago the last message was redriven.
+func (r *Redriver) IdleFor() time.Duration {
+	return time.Since(time.Unix(0, r.lastActivity.Load()))
+}
+
+func (r *Redriver) Close() error {
+	return r.producer.Close()
+}
@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/ayshaat/metrics-consumer/internal/log/zap"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedriver_Redrive(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	header := func(key, value string) *sarama.RecordHeader {
+		return &sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+	}
+
+	tests := []struct {
+		name          string
+		msg           *sarama.ConsumerMessage
+		wantTopic     string
+		wantPartition int32
+		wantKey       sarama.Encoder
+	}{
+		{
+			name: "back to where it came from",
+			msg: &sarama.ConsumerMessage{
+				Key:   []byte("1001"),
+				Value: []byte("payload"),
+				Headers: []*sarama.RecordHeader{
+					header("traceparent", "00-abc-def-01"),
+					header(dlqHeaderOriginalTopic, "stock-events"),
+					header(dlqHeaderOriginalPartition, "2"),
+					header(dlqHeaderOriginalOffset, "42"),
+					header(dlqHeaderError, "invalid SKU"),
+					header(dlqHeaderAttempts, "3"),
+					header(dlqHeaderFailedAt, "2026-10-17T10:00:00Z"),
+				},
+			},
+			wantTopic:     "stock-events",
+			wantPartition: 2,
+			wantKey:       sarama.ByteEncoder("1001"),
+		},
+		{
+			name: "without origin headers",
+			msg: &sarama.ConsumerMessage{
+				Value: []byte("payload"),
+				Headers: []*sarama.RecordHeader{
+					header("traceparent", "00-abc-def-01"),
+					header(dlqHeaderError, "invalid SKU"),
+				},
+			},
+			wantTopic:     "metrics",
+			wantPartition: 0,
+			wantKey:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			producer := &recordingProducer{}
+			r := &Redriver{producer: producer, defaultTopic: "metrics", logger: logger}
+
+			if err := r.redrive(tt.msg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !assert.Len(t, producer.sent, 1) {
+				return
+			}
+
+			sent := producer.sent[0]
+			assert.Equal(t, tt.wantTopic, sent.Topic)
+			assert.Equal(t, tt.wantPartition, sent.Partition)
+			assert.Equal(t, tt.wantKey, sent.Key)
+			assert.Equal(t, sarama.ByteEncoder("payload"), sent.Value)
+			assert.Equal(t, map[string]string{"traceparent": "00-abc-def-01"}, headerMap(sent.Headers), "DLQ headers are dropped")
+		})
+	}
+}
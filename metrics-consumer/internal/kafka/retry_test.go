@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 200 * time.Millisecond},
+		{attempt: 4, want: 800 * time.Millisecond},
+		{attempt: 5, want: time.Second},
+		{attempt: 60, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policy.Backoff(tt.attempt), "attempt %d", tt.attempt)
+	}
+
+	capped := RetryPolicy{InitialBackoff: 2 * time.Second, MaxBackoff: time.Second}
+	assert.Equal(t, time.Second, capped.Backoff(1), "never above MaxBackoff")
+}
+
+func TestIsPermanent(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("bad payload")
+
+	assert.False(t, IsPermanent(err))
+	assert.False(t, IsPermanent(nil))
+	assert.True(t, IsPermanent(Permanent(err)))
+	assert.True(t, IsPermanent(fmt.Errorf("handle: %w", Permanent(err))))
+	assert.ErrorIs(t, Permanent(err), err)
+}
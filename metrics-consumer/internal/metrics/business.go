@@ -0,0 +1,91 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// BusinessMetrics are derived from the domain events themselves rather than
+// from the act of consuming them.
+type BusinessMetrics struct {
+	EventsConsumed   *prometheus.CounterVec
+	CartItemAdds     *prometheus.CounterVec
+	CartItemUnits    *prometheus.CounterVec
+	CartItemFailures *prometheus.CounterVec
+	CartItemRemovals *prometheus.CounterVec
+	CartsCleared     *prometheus.CounterVec
+	StockCount       *prometheus.GaugeVec
+	StockPrice       *prometheus.GaugeVec
+}
+
+func RegisterBusinessMetrics() *BusinessMetrics {
+	m := NewBusinessMetrics()
+
+	prometheus.MustRegister(
+		m.EventsConsumed,
+		m.CartItemAdds, m.CartItemUnits, m.CartItemFailures,
+		m.CartItemRemovals, m.CartsCleared,
+		m.StockCount, m.StockPrice,
+	)
+
+	return m
+}
+
+// NewBusinessMetrics creates the metrics without registering them.
+func NewBusinessMetrics() *BusinessMetrics {
+	return &BusinessMetrics{
+		EventsConsumed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "events_consumed_total",
+				Help: "Total number of domain events consumed, by event type",
+			},
+			[]string{"type"},
+		),
+		CartItemAdds: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cart_item_adds_total",
+				Help: "Total number of successful add-to-cart operations per SKU",
+			},
+			[]string{"sku"},
+		),
+		CartItemUnits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cart_item_added_units_total",
+				Help: "Total number of units added to carts per SKU",
+			},
+			[]string{"sku"},
+		),
+		CartItemFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cart_item_failures_total",
+				Help: "Total number of failed add-to-cart operations by reason",
+			},
+			[]string{"reason"},
+		),
+		CartItemRemovals: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cart_item_removed_units_total",
+				Help: "Total number of units removed from carts per SKU",
+			},
+			[]string{"sku"},
+		),
+		CartsCleared: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "carts_cleared_total",
+				Help: "Total number of carts emptied by reason",
+			},
+			[]string{"reason"},
+		),
+		StockCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "stock_count",
+				Help: "Current stock count per SKU, across all locations",
+			},
+			[]string{"sku"},
+		),
+		StockPrice: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "stock_price",
+				Help: "Current price per SKU",
+			},
+			[]string{"sku"},
+		),
+	}
+}
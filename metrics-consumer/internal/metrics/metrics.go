@@ -13,6 +13,13 @@ type Metrics struct {
 	RequestsTotal   *prometheus.CounterVec
 	RequestDuration *prometheus.HistogramVec
 	RequestErrors   *prometheus.CounterVec
+
+	ConsumedMessages *prometheus.CounterVec
+	ConsumeDuration  *prometheus.HistogramVec
+	ConsumeErrors    *prometheus.CounterVec
+	ConsumerRetries  prometheus.Counter
+	DLQMessages      *prometheus.CounterVec
+	DLQPublishErrors prometheus.Counter
 }
 
 func StartMetricsServer(addr string) {
@@ -49,9 +56,54 @@ func RegisterMetrics() *Metrics {
 			},
 			[]string{"path", "method"},
 		),
+		ConsumedMessages: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kafka_consumer_messages_total",
+				Help: "Total number of Kafka messages consumed",
+			},
+			[]string{"topic"},
+		),
+		ConsumeDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "kafka_consumer_message_duration_seconds",
+				Help:    "Time spent handling a Kafka message, retries included",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"topic"},
+		),
+		ConsumeErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kafka_consumer_message_errors_total",
+				Help: "Total number of Kafka messages that could not be handled",
+			},
+			[]string{"topic"},
+		),
+		ConsumerRetries: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "kafka_consumer_retries_total",
+				Help: "Total number of event handling retries",
+			},
+		),
+		DLQMessages: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kafka_consumer_dlq_messages_total",
+				Help: "Total number of messages moved to the dead-letter topic",
+			},
+			[]string{"reason"},
+		),
+		DLQPublishErrors: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "kafka_consumer_dlq_publish_errors_total",
+				Help: "Total number of failed writes to the dead-letter topic",
+			},
+		),
 	}
 
-	prometheus.MustRegister(m.RequestsTotal, m.RequestDuration, m.RequestErrors)
+	prometheus.MustRegister(
+		m.RequestsTotal, m.RequestDuration, m.RequestErrors,
+		m.ConsumedMessages, m.ConsumeDuration, m.ConsumeErrors,
+		m.ConsumerRetries, m.DLQMessages, m.DLQPublishErrors,
+	)
 
 	return m
 }
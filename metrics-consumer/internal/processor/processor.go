@@ -0,0 +1,213 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ayshaat/metrics-consumer/internal/event"
+	"github.com/ayshaat/metrics-consumer/internal/kafka"
+	"github.com/ayshaat/metrics-consumer/internal/log"
+	"github.com/ayshaat/metrics-consumer/internal/metrics"
+	eventspb "github.com/ayshaat/metrics-consumer/pkg/api/events"
+	moneypb "github.com/ayshaat/metrics-consumer/pkg/api/money"
+)
+
+// Processor turns domain events into business metrics. Its Handle method is
+// a kafka.Handler.
+type Processor struct {
+	metrics *metrics.BusinessMetrics
+	logger  log.Logger
+}
+
+func New(m *metrics.BusinessMetrics, logger log.Logger) *Processor {
+	return &Processor{
+		metrics: m,
+		logger:  logger,
+	}
+}
+
+func (p *Processor) Handle(_ context.Context, evt *eventspb.Envelope) error {
+	p.logger.Info("Consumed event",
+		log.String("type", evt.GetType()),
+		log.String("event_id", evt.GetEventId()),
+		log.String("source", evt.GetSource()),
+		log.Time("occurred_at", evt.GetOccurredAt().AsTime()),
+	)
+
+	if err := checkVersion(evt); err != nil {
+		return err
+	}
+
+	var err error
+
+	switch payload := evt.GetPayload().(type) {
+	case *eventspb.Envelope_CartItemAdded:
+		err = p.cartItemAdded(payload.CartItemAdded)
+	case *eventspb.Envelope_CartItemFailed:
+		p.cartItemFailed(payload.CartItemFailed)
+	case *eventspb.Envelope_CartItemRemoved:
+		err = p.cartItemRemoved(payload.CartItemRemoved)
+	case *eventspb.Envelope_CartCleared:
+		err = p.cartCleared(payload.CartCleared)
+	case *eventspb.Envelope_SkuCreated:
+		err = p.skuCreated(payload.SkuCreated)
+	case *eventspb.Envelope_StockChanged:
+		err = p.stockChanged(payload.StockChanged)
+	case *eventspb.Envelope_StockDeleted:
+		err = p.stockDeleted(payload.StockDeleted)
+	case *eventspb.Envelope_PriceChanged:
+		err = p.priceChanged(payload.PriceChanged)
+	default:
+		// An event this consumer has metrics for must carry its payload; it
+		// will not gain one on the next attempt.
+		if _, ok := event.SchemaVersions[evt.GetType()]; ok {
+			return kafka.Permanent(fmt.Errorf("%s event without a payload", evt.GetType()))
+		}
+
+		p.logger.Debug("No metrics for event type", log.String("type", evt.GetType()))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.metrics.EventsConsumed.WithLabelValues(evt.GetType()).Inc()
+
+	return nil
+}
+
+func (p *Processor) cartItemAdded(payload *eventspb.CartItemAdded) error {
+	if err := validateSKU(event.TypeCartItemAdded, payload.GetSku()); err != nil {
+		return err
+	}
+
+	p.metrics.CartItemAdds.WithLabelValues(payload.GetSku()).Inc()
+	p.metrics.CartItemUnits.WithLabelValues(payload.GetSku()).Add(float64(payload.GetCount()))
+
+	return nil
+}
+
+func (p *Processor) cartItemFailed(payload *eventspb.CartItemFailed) {
+	reason := payload.GetReason()
+	if reason == "" {
+		reason = "unknown"
+	}
+
+	p.metrics.CartItemFailures.WithLabelValues(reason).Inc()
+}
+
+func (p *Processor) cartItemRemoved(payload *eventspb.CartItemRemoved) error {
+	if err := validateSKU(event.TypeCartItemRemoved, payload.GetSku()); err != nil {
+		return err
+	}
+
+	p.metrics.CartItemRemovals.WithLabelValues(payload.GetSku()).Add(float64(payload.GetCount()))
+
+	return nil
+}
+
+func (p *Processor) cartCleared(payload *eventspb.CartCleared) error {
+	for _, item := range payload.GetItems() {
+		if err := validateSKU(event.TypeCartCleared, item.GetSku()); err != nil {
+			return err
+		}
+	}
+
+	reason := payload.GetReason()
+	if reason == "" {
+		reason = "unknown"
+	}
+
+	p.metrics.CartsCleared.WithLabelValues(reason).Inc()
+
+	for _, item := range payload.GetItems() {
+		p.metrics.CartItemRemovals.WithLabelValues(item.GetSku()).Add(float64(item.GetCount()))
+	}
+
+	return nil
+}
+
+func (p *Processor) skuCreated(payload *eventspb.SKUCreated) error {
+	if err := validateSKU(event.TypeSKUCreated, payload.GetSku()); err != nil {
+		return err
+	}
+
+	p.metrics.StockCount.WithLabelValues(payload.GetSku()).Set(float64(payload.GetCount()))
+	p.metrics.StockPrice.WithLabelValues(payload.GetSku()).Set(price(payload.GetUnitPrice(), payload.GetPrice()))
+
+	return nil
+}
+
+func (p *Processor) stockChanged(payload *eventspb.StockChanged) error {
+	if err := validateSKU(event.TypeStockChanged, payload.GetSku()); err != nil {
+		return err
+	}
+
+	p.metrics.StockCount.WithLabelValues(payload.GetSku()).Set(float64(payload.GetCount()))
+	p.metrics.StockPrice.WithLabelValues(payload.GetSku()).Set(price(payload.GetUnitPrice(), payload.GetPrice()))
+
+	return nil
+}
+
+// stockDeleted drops the SKU's series once none of it is left anywhere.
+func (p *Processor) stockDeleted(payload *eventspb.StockDeleted) error {
+	if err := validateSKU(event.TypeStockDeleted, payload.GetSku()); err != nil {
+		return err
+	}
+
+	if payload.GetRemaining() > 0 {
+		p.metrics.StockCount.WithLabelValues(payload.GetSku()).Set(float64(payload.GetRemaining()))
+		return nil
+	}
+
+	p.metrics.StockCount.DeleteLabelValues(payload.GetSku())
+	p.metrics.StockPrice.DeleteLabelValues(payload.GetSku())
+
+	return nil
+}
+
+func (p *Processor) priceChanged(payload *eventspb.PriceChanged) error {
+	if err := validateSKU(event.TypePriceChanged, payload.GetSku()); err != nil {
+		return err
+	}
+
+	p.metrics.StockPrice.WithLabelValues(payload.GetSku()).Set(amount(payload.GetNewPrice()))
+
+	return nil
+}
+
+// price prefers the exact unit price and falls back to the legacy float,
+// which is all events published before prices became Money carry.
+func price(unitPrice *moneypb.Money, legacy float64) float64 {
+	if unitPrice == nil {
+		return legacy
+	}
+
+	return amount(unitPrice)
+}
+
+// amount is units + nanos / 10^9; the currency is not part of the metric.
+func amount(m *moneypb.Money) float64 {
+	return float64(m.GetUnits()) + float64(m.GetNanos())/1e9
+}
+
+// checkVersion fails permanently on payloads newer than the consumer can
+// read; they stay in the DLQ until a consumer that knows them redrives them.
+func checkVersion(evt *eventspb.Envelope) error {
+	supported, ok := event.SchemaVersions[evt.GetType()]
+	if !ok || evt.GetSchemaVersion() <= supported {
+		return nil
+	}
+
+	return kafka.Permanent(fmt.Errorf("unsupported %s schema version %d, newest known is %d", evt.GetType(), evt.GetSchemaVersion(), supported))
+}
+
+// validateSKU keeps malformed SKUs out of the label space.
+func validateSKU(eventType, sku string) error {
+	if _, err := strconv.ParseUint(sku, 10, 32); err != nil {
+		return kafka.Permanent(fmt.Errorf("invalid SKU %q in %s event", sku, eventType))
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package processor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ayshaat/metrics-consumer/internal/kafka"
+	"github.com/ayshaat/metrics-consumer/internal/log/zap"
+	"github.com/ayshaat/metrics-consumer/internal/metrics"
+	"github.com/ayshaat/metrics-consumer/internal/processor"
+	eventspb "github.com/ayshaat/metrics-consumer/pkg/api/events"
+	moneypb "github.com/ayshaat/metrics-consumer/pkg/api/money"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func envelope(eventType string, payload interface{}) *eventspb.Envelope {
+	env := &eventspb.Envelope{Type: eventType, SchemaVersion: 1}
+
+	switch p := payload.(type) {
+	case *eventspb.CartItemAdded:
+		env.Payload = &eventspb.Envelope_CartItemAdded{CartItemAdded: p}
+	case *eventspb.CartItemFailed:
+		env.Payload = &eventspb.Envelope_CartItemFailed{CartItemFailed: p}
+	case *eventspb.CartItemRemoved:
+		env.Payload = &eventspb.Envelope_CartItemRemoved{CartItemRemoved: p}
+	case *eventspb.CartCleared:
+		env.Payload = &eventspb.Envelope_CartCleared{CartCleared: p}
+	case *eventspb.SKUCreated:
+		env.Payload = &eventspb.Envelope_SkuCreated{SkuCreated: p}
+	case *eventspb.StockChanged:
+		env.Payload = &eventspb.Envelope_StockChanged{StockChanged: p}
+	case *eventspb.StockDeleted:
+		env.Payload = &eventspb.Envelope_StockDeleted{StockDeleted: p}
+	case *eventspb.PriceChanged:
+		env.Payload = &eventspb.Envelope_PriceChanged{PriceChanged: p}
+	}
+
+	return env
+}
+
+func rub(units int64, nanos int32) *moneypb.Money {
+	return &moneypb.Money{CurrencyCode: "RUB", Units: units, Nanos: nanos}
+}
+
+func TestProcessor_Handle(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	tests := []struct {
+		name   string
+		events []*eventspb.Envelope
+		check  func(t *testing.T, m *metrics.BusinessMetrics)
+	}{
+		{
+			name: "cart_item_added",
+			events: []*eventspb.Envelope{
+				envelope("cart_item_added", &eventspb.CartItemAdded{CartId: "1", Sku: "1001", Count: 2}),
+				envelope("cart_item_added", &eventspb.CartItemAdded{CartId: "2", Sku: "1001", Count: 3}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 2.0, testutil.ToFloat64(m.CartItemAdds.WithLabelValues("1001")))
+				assert.Equal(t, 5.0, testutil.ToFloat64(m.CartItemUnits.WithLabelValues("1001")))
+				assert.Equal(t, 2.0, testutil.ToFloat64(m.EventsConsumed.WithLabelValues("cart_item_added")))
+			},
+		},
+		{
+			name: "cart_item_failed",
+			events: []*eventspb.Envelope{
+				envelope("cart_item_failed", &eventspb.CartItemFailed{Sku: "1001", Reason: "not enough stock"}),
+				envelope("cart_item_failed", &eventspb.CartItemFailed{Sku: "1001"}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 1.0, testutil.ToFloat64(m.CartItemFailures.WithLabelValues("not enough stock")))
+				assert.Equal(t, 1.0, testutil.ToFloat64(m.CartItemFailures.WithLabelValues("unknown")))
+			},
+		},
+		{
+			name: "cart_item_removed and cart_cleared",
+			events: []*eventspb.Envelope{
+				envelope("cart_item_removed", &eventspb.CartItemRemoved{Sku: "1001", Count: 3}),
+				envelope("cart_cleared", &eventspb.CartCleared{
+					Items:  []*eventspb.CartLine{{Sku: "1001", Count: 2}, {Sku: "1002", Count: 1}},
+					Reason: "checkout",
+				}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 5.0, testutil.ToFloat64(m.CartItemRemovals.WithLabelValues("1001")))
+				assert.Equal(t, 1.0, testutil.ToFloat64(m.CartItemRemovals.WithLabelValues("1002")))
+				assert.Equal(t, 1.0, testutil.ToFloat64(m.CartsCleared.WithLabelValues("checkout")))
+			},
+		},
+		{
+			name: "sku_created then stock_changed",
+			events: []*eventspb.Envelope{
+				envelope("sku_created", &eventspb.SKUCreated{Sku: "1001", Count: 5, UnitPrice: rub(10, 500_000_000)}),
+				envelope("stock_changed", &eventspb.StockChanged{Sku: "1001", Count: 20, UnitPrice: rub(12, 0)}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 20.0, testutil.ToFloat64(m.StockCount.WithLabelValues("1001")), "the SKU-wide count of the latest event")
+				assert.Equal(t, 12.0, testutil.ToFloat64(m.StockPrice.WithLabelValues("1001")))
+			},
+		},
+		{
+			name: "legacy float price",
+			events: []*eventspb.Envelope{
+				envelope("stock_changed", &eventspb.StockChanged{Sku: "1001", Count: 3, Price: 9.5}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 9.5, testutil.ToFloat64(m.StockPrice.WithLabelValues("1001")))
+			},
+		},
+		{
+			name: "price_changed",
+			events: []*eventspb.Envelope{
+				envelope("price_changed", &eventspb.PriceChanged{Sku: "1001", Location: "loc1", OldPrice: rub(10, 0), NewPrice: rub(11, 250_000_000)}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 11.25, testutil.ToFloat64(m.StockPrice.WithLabelValues("1001")))
+			},
+		},
+		{
+			name: "stock_deleted with stock left elsewhere",
+			events: []*eventspb.Envelope{
+				envelope("stock_changed", &eventspb.StockChanged{Sku: "1001", Count: 7, UnitPrice: rub(10, 0)}),
+				envelope("stock_deleted", &eventspb.StockDeleted{Sku: "1001", Location: "loc1", Count: 3, Remaining: 4}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 4.0, testutil.ToFloat64(m.StockCount.WithLabelValues("1001")))
+			},
+		},
+		{
+			name: "stock_deleted drops the last of a SKU",
+			events: []*eventspb.Envelope{
+				envelope("stock_changed", &eventspb.StockChanged{Sku: "1001", Count: 3, UnitPrice: rub(10, 0)}),
+				envelope("stock_deleted", &eventspb.StockDeleted{Sku: "1001", Location: "loc1", Count: 3}),
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 0, testutil.CollectAndCount(m.StockCount))
+				assert.Equal(t, 0, testutil.CollectAndCount(m.StockPrice))
+			},
+		},
+		{
+			name: "event without metrics is only counted",
+			events: []*eventspb.Envelope{
+				{Type: "order_created", SchemaVersion: 1},
+			},
+			check: func(t *testing.T, m *metrics.BusinessMetrics) {
+				assert.Equal(t, 1.0, testutil.ToFloat64(m.EventsConsumed.WithLabelValues("order_created")))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := metrics.NewBusinessMetrics()
+			p := processor.New(m, logger)
+
+			for _, evt := range tt.events {
+				if err := p.Handle(context.Background(), evt); err != nil {
+					t.Fatalf("unexpected error on %s: %v", evt.GetType(), err)
+				}
+			}
+
+			tt.check(t, m)
+		})
+	}
+}
+
+func TestProcessor_Handle_Permanent(t *testing.T) {
+	t.Parallel()
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	newer := envelope("stock_changed", &eventspb.StockChanged{Sku: "1001", Count: 1})
+	newer.SchemaVersion = 2
+
+	tests := []struct {
+		name string
+		evt  *eventspb.Envelope
+	}{
+		{name: "invalid SKU", evt: envelope("cart_item_added", &eventspb.CartItemAdded{Sku: "t-shirt", Count: 1})},
+		{name: "invalid SKU in a cleared cart", evt: envelope("cart_cleared", &eventspb.CartCleared{Items: []*eventspb.CartLine{{Sku: "-1"}}})},
+		{name: "newer schema version", evt: newer},
+		{name: "known type without payload", evt: &eventspb.Envelope{Type: "stock_changed", SchemaVersion: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := metrics.NewBusinessMetrics()
+			err := processor.New(m, logger).Handle(context.Background(), tt.evt)
+
+			assert.True(t, kafka.IsPermanent(err), "got %v", err)
+			assert.Equal(t, 0, testutil.CollectAndCount(m.EventsConsumed), "failed events are not counted")
+		})
+	}
+}
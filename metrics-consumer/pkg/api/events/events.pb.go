@@ -0,0 +1,1648 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: events/events.proto
+
+package eventspb
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	money "github.com/ayshaat/metrics-consumer/pkg/api/money"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Envelope wraps every event published to Kafka. The content-type header of
+// the record says how it is encoded: application/x-protobuf for the binary
+// form, application/json for the protobuf JSON mapping.
+type Envelope struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique per event; consumers may use it to drop redeliveries.
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// The name of the payload field that is set, e.g. "cart_item_added".
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Schema version of the payload. It is bumped whenever a payload changes
+	// in a way consumers cannot ignore: a field removed, renamed or given a
+	// new meaning.
+	SchemaVersion int32 `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Name of the publishing service, e.g. "cart-service".
+	Source     string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// W3C trace context (traceparent, tracestate, baggage) of the publisher.
+	// The same keys are also sent as record headers.
+	TraceContext map[string]string `protobuf:"bytes,6,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Field names double as event types, so a field may only be added, never
+	// renamed.
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Envelope_CartItemAdded
+	//	*Envelope_CartItemFailed
+	//	*Envelope_CartItemRemoved
+	//	*Envelope_CartCleared
+	//	*Envelope_OrderCreated
+	//	*Envelope_CartAbandoned
+	//	*Envelope_SkuCreated
+	//	*Envelope_StockChanged
+	//	*Envelope_StockDeleted
+	//	*Envelope_PriceChanged
+	//	*Envelope_ReservationExpired
+	//	*Envelope_CatalogSkuCreated
+	//	*Envelope_CatalogSkuUpdated
+	//	*Envelope_CatalogSkuArchived
+	Payload       isEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_events_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Envelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Envelope) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Envelope) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Envelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
+func (x *Envelope) GetPayload() isEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetCartItemAdded() *CartItemAdded {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CartItemAdded); ok {
+			return x.CartItemAdded
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCartItemFailed() *CartItemFailed {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CartItemFailed); ok {
+			return x.CartItemFailed
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCartItemRemoved() *CartItemRemoved {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CartItemRemoved); ok {
+			return x.CartItemRemoved
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCartCleared() *CartCleared {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CartCleared); ok {
+			return x.CartCleared
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetOrderCreated() *OrderCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_OrderCreated); ok {
+			return x.OrderCreated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCartAbandoned() *CartAbandoned {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CartAbandoned); ok {
+			return x.CartAbandoned
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetSkuCreated() *SKUCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_SkuCreated); ok {
+			return x.SkuCreated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetStockChanged() *StockChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_StockChanged); ok {
+			return x.StockChanged
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetStockDeleted() *StockDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_StockDeleted); ok {
+			return x.StockDeleted
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPriceChanged() *PriceChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PriceChanged); ok {
+			return x.PriceChanged
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetReservationExpired() *ReservationExpired {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_ReservationExpired); ok {
+			return x.ReservationExpired
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCatalogSkuCreated() *CatalogSKU {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CatalogSkuCreated); ok {
+			return x.CatalogSkuCreated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCatalogSkuUpdated() *CatalogSKU {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CatalogSkuUpdated); ok {
+			return x.CatalogSkuUpdated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCatalogSkuArchived() *CatalogSKU {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CatalogSkuArchived); ok {
+			return x.CatalogSkuArchived
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_CartItemAdded struct {
+	CartItemAdded *CartItemAdded `protobuf:"bytes,10,opt,name=cart_item_added,json=cartItemAdded,proto3,oneof"`
+}
+
+type Envelope_CartItemFailed struct {
+	CartItemFailed *CartItemFailed `protobuf:"bytes,11,opt,name=cart_item_failed,json=cartItemFailed,proto3,oneof"`
+}
+
+type Envelope_CartItemRemoved struct {
+	CartItemRemoved *CartItemRemoved `protobuf:"bytes,12,opt,name=cart_item_removed,json=cartItemRemoved,proto3,oneof"`
+}
+
+type Envelope_CartCleared struct {
+	CartCleared *CartCleared `protobuf:"bytes,13,opt,name=cart_cleared,json=cartCleared,proto3,oneof"`
+}
+
+type Envelope_OrderCreated struct {
+	OrderCreated *OrderCreated `protobuf:"bytes,14,opt,name=order_created,json=orderCreated,proto3,oneof"`
+}
+
+type Envelope_CartAbandoned struct {
+	CartAbandoned *CartAbandoned `protobuf:"bytes,15,opt,name=cart_abandoned,json=cartAbandoned,proto3,oneof"`
+}
+
+type Envelope_SkuCreated struct {
+	SkuCreated *SKUCreated `protobuf:"bytes,30,opt,name=sku_created,json=skuCreated,proto3,oneof"`
+}
+
+type Envelope_StockChanged struct {
+	StockChanged *StockChanged `protobuf:"bytes,31,opt,name=stock_changed,json=stockChanged,proto3,oneof"`
+}
+
+type Envelope_StockDeleted struct {
+	StockDeleted *StockDeleted `protobuf:"bytes,32,opt,name=stock_deleted,json=stockDeleted,proto3,oneof"`
+}
+
+type Envelope_PriceChanged struct {
+	PriceChanged *PriceChanged `protobuf:"bytes,33,opt,name=price_changed,json=priceChanged,proto3,oneof"`
+}
+
+type Envelope_ReservationExpired struct {
+	ReservationExpired *ReservationExpired `protobuf:"bytes,34,opt,name=reservation_expired,json=reservationExpired,proto3,oneof"`
+}
+
+type Envelope_CatalogSkuCreated struct {
+	CatalogSkuCreated *CatalogSKU `protobuf:"bytes,35,opt,name=catalog_sku_created,json=catalogSkuCreated,proto3,oneof"`
+}
+
+type Envelope_CatalogSkuUpdated struct {
+	CatalogSkuUpdated *CatalogSKU `protobuf:"bytes,36,opt,name=catalog_sku_updated,json=catalogSkuUpdated,proto3,oneof"`
+}
+
+type Envelope_CatalogSkuArchived struct {
+	CatalogSkuArchived *CatalogSKU `protobuf:"bytes,37,opt,name=catalog_sku_archived,json=catalogSkuArchived,proto3,oneof"`
+}
+
+func (*Envelope_CartItemAdded) isEnvelope_Payload() {}
+
+func (*Envelope_CartItemFailed) isEnvelope_Payload() {}
+
+func (*Envelope_CartItemRemoved) isEnvelope_Payload() {}
+
+func (*Envelope_CartCleared) isEnvelope_Payload() {}
+
+func (*Envelope_OrderCreated) isEnvelope_Payload() {}
+
+func (*Envelope_CartAbandoned) isEnvelope_Payload() {}
+
+func (*Envelope_SkuCreated) isEnvelope_Payload() {}
+
+func (*Envelope_StockChanged) isEnvelope_Payload() {}
+
+func (*Envelope_StockDeleted) isEnvelope_Payload() {}
+
+func (*Envelope_PriceChanged) isEnvelope_Payload() {}
+
+func (*Envelope_ReservationExpired) isEnvelope_Payload() {}
+
+func (*Envelope_CatalogSkuCreated) isEnvelope_Payload() {}
+
+func (*Envelope_CatalogSkuUpdated) isEnvelope_Payload() {}
+
+func (*Envelope_CatalogSkuArchived) isEnvelope_Payload() {}
+
+type CartItemAdded struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	CartId string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	Sku    string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count  int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// "added", or "merged" for lines folded in from a guest cart.
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItemAdded) Reset() {
+	*x = CartItemAdded{}
+	mi := &file_events_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItemAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItemAdded) ProtoMessage() {}
+
+func (x *CartItemAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItemAdded.ProtoReflect.Descriptor instead.
+func (*CartItemAdded) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CartItemAdded) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *CartItemAdded) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CartItemAdded) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CartItemAdded) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CartItemFailed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItemFailed) Reset() {
+	*x = CartItemFailed{}
+	mi := &file_events_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItemFailed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItemFailed) ProtoMessage() {}
+
+func (x *CartItemFailed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItemFailed.ProtoReflect.Descriptor instead.
+func (*CartItemFailed) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CartItemFailed) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *CartItemFailed) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CartItemFailed) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CartItemFailed) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CartItemFailed) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Count is the quantity the line held when it was removed.
+type CartItemRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItemRemoved) Reset() {
+	*x = CartItemRemoved{}
+	mi := &file_events_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItemRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItemRemoved) ProtoMessage() {}
+
+func (x *CartItemRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItemRemoved.ProtoReflect.Descriptor instead.
+func (*CartItemRemoved) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CartItemRemoved) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *CartItemRemoved) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CartItemRemoved) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CartLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartLine) Reset() {
+	*x = CartLine{}
+	mi := &file_events_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartLine) ProtoMessage() {}
+
+func (x *CartLine) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartLine.ProtoReflect.Descriptor instead.
+func (*CartLine) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CartLine) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CartLine) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CartCleared struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	CartId string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	Items  []*CartLine            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// One of cleared, checkout, merged (a guest cart folded into a user's) or
+	// expired (purged after CART_RETENTION).
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartCleared) Reset() {
+	*x = CartCleared{}
+	mi := &file_events_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartCleared) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartCleared) ProtoMessage() {}
+
+func (x *CartCleared) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartCleared.ProtoReflect.Descriptor instead.
+func (*CartCleared) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CartCleared) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *CartCleared) GetItems() []*CartLine {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CartCleared) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type OrderItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Rounded float kept for consumers that predate unit_price.
+	//
+	// Deprecated: Marked as deprecated in events/events.proto.
+	Price         float64      `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_events_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in events/events.proto.
+func (x *OrderItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderItem) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+type OrderCreated struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CartId  string                 `protobuf:"bytes,2,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	Items   []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// Rounded float kept for consumers that predate total.
+	//
+	// Deprecated: Marked as deprecated in events/events.proto.
+	TotalPrice    float64      `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Subtotal      *money.Money `protobuf:"bytes,5,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount      *money.Money `protobuf:"bytes,6,opt,name=discount,proto3" json:"discount,omitempty"`
+	PromoCodes    []string     `protobuf:"bytes,7,rep,name=promo_codes,json=promoCodes,proto3" json:"promo_codes,omitempty"`
+	Total         *money.Money `protobuf:"bytes,8,opt,name=total,proto3" json:"total,omitempty"`
+	Status        string       `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderCreated) Reset() {
+	*x = OrderCreated{}
+	mi := &file_events_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderCreated) ProtoMessage() {}
+
+func (x *OrderCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderCreated.ProtoReflect.Descriptor instead.
+func (*OrderCreated) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OrderCreated) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderCreated) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *OrderCreated) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in events/events.proto.
+func (x *OrderCreated) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *OrderCreated) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *OrderCreated) GetDiscount() *money.Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *OrderCreated) GetPromoCodes() []string {
+	if x != nil {
+		return x.PromoCodes
+	}
+	return nil
+}
+
+func (x *OrderCreated) GetTotal() *money.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *OrderCreated) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CartAbandoned struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CartId         string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	Items          []*CartLine            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	LastActivityAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CartAbandoned) Reset() {
+	*x = CartAbandoned{}
+	mi := &file_events_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartAbandoned) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartAbandoned) ProtoMessage() {}
+
+func (x *CartAbandoned) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartAbandoned.ProtoReflect.Descriptor instead.
+func (*CartAbandoned) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CartAbandoned) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *CartAbandoned) GetItems() []*CartLine {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CartAbandoned) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+type SKUCreated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Rounded float kept for consumers that predate unit_price.
+	//
+	// Deprecated: Marked as deprecated in events/events.proto.
+	Price         float64      `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Count         int32        `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SKUCreated) Reset() {
+	*x = SKUCreated{}
+	mi := &file_events_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SKUCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SKUCreated) ProtoMessage() {}
+
+func (x *SKUCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SKUCreated.ProtoReflect.Descriptor instead.
+func (*SKUCreated) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SKUCreated) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in events/events.proto.
+func (x *SKUCreated) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *SKUCreated) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *SKUCreated) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// StockChanged carries the stock of the SKU across all locations: count is
+// their total and unit_price the highest of their prices.
+type StockChanged struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Rounded float kept for consumers that predate unit_price.
+	//
+	// Deprecated: Marked as deprecated in events/events.proto.
+	Price         float64      `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockChanged) Reset() {
+	*x = StockChanged{}
+	mi := &file_events_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockChanged) ProtoMessage() {}
+
+func (x *StockChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockChanged.ProtoReflect.Descriptor instead.
+func (*StockChanged) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StockChanged) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *StockChanged) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in events/events.proto.
+func (x *StockChanged) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *StockChanged) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+// StockDeleted describes one deleted stock row. Remaining is the count of the
+// SKU left across all locations after the delete.
+type StockDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Remaining     int32                  `protobuf:"varint,4,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockDeleted) Reset() {
+	*x = StockDeleted{}
+	mi := &file_events_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockDeleted) ProtoMessage() {}
+
+func (x *StockDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockDeleted.ProtoReflect.Descriptor instead.
+func (*StockDeleted) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StockDeleted) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *StockDeleted) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *StockDeleted) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *StockDeleted) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// PriceChanged is published when restocking a location reprices it.
+type PriceChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	OldPrice      *money.Money           `protobuf:"bytes,3,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice      *money.Money           `protobuf:"bytes,4,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChanged) Reset() {
+	*x = PriceChanged{}
+	mi := &file_events_events_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChanged) ProtoMessage() {}
+
+func (x *PriceChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChanged.ProtoReflect.Descriptor instead.
+func (*PriceChanged) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PriceChanged) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *PriceChanged) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *PriceChanged) GetOldPrice() *money.Money {
+	if x != nil {
+		return x.OldPrice
+	}
+	return nil
+}
+
+func (x *PriceChanged) GetNewPrice() *money.Money {
+	if x != nil {
+		return x.NewPrice
+	}
+	return nil
+}
+
+type ReservationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationItem) Reset() {
+	*x = ReservationItem{}
+	mi := &file_events_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationItem) ProtoMessage() {}
+
+func (x *ReservationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationItem.ProtoReflect.Descriptor instead.
+func (*ReservationItem) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReservationItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ReservationItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReservationExpired struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	ExpiredAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationExpired) Reset() {
+	*x = ReservationExpired{}
+	mi := &file_events_events_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationExpired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationExpired) ProtoMessage() {}
+
+func (x *ReservationExpired) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationExpired.ProtoReflect.Descriptor instead.
+func (*ReservationExpired) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReservationExpired) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReservationExpired) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReservationExpired) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReservationExpired) GetExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+// CatalogSKU is a SKU catalog entry after a catalog_sku_* event. archived_at
+// is unset unless the SKU is archived.
+type CatalogSKU struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	CurrencyCode  string                 `protobuf:"bytes,4,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	Archived      bool                   `protobuf:"varint,5,opt,name=archived,proto3" json:"archived,omitempty"`
+	ArchivedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogSKU) Reset() {
+	*x = CatalogSKU{}
+	mi := &file_events_events_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogSKU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogSKU) ProtoMessage() {}
+
+func (x *CatalogSKU) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogSKU.ProtoReflect.Descriptor instead.
+func (*CatalogSKU) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CatalogSKU) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CatalogSKU) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CatalogSKU) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CatalogSKU) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *CatalogSKU) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *CatalogSKU) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+var File_events_events_proto protoreflect.FileDescriptor
+
+const file_events_events_proto_rawDesc = "" +
+	"\n" +
+	"\x13events/events.proto\x12\x06events\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11money/money.proto\"\xde\t\n" +
+	"\bEnvelope\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\x05R\rschemaVersion\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12G\n" +
+	"\rtrace_context\x18\x06 \x03(\v2\".events.Envelope.TraceContextEntryR\ftraceContext\x12?\n" +
+	"\x0fcart_item_added\x18\n" +
+	" \x01(\v2\x15.events.CartItemAddedH\x00R\rcartItemAdded\x12B\n" +
+	"\x10cart_item_failed\x18\v \x01(\v2\x16.events.CartItemFailedH\x00R\x0ecartItemFailed\x12E\n" +
+	"\x11cart_item_removed\x18\f \x01(\v2\x17.events.CartItemRemovedH\x00R\x0fcartItemRemoved\x128\n" +
+	"\fcart_cleared\x18\r \x01(\v2\x13.events.CartClearedH\x00R\vcartCleared\x12;\n" +
+	"\rorder_created\x18\x0e \x01(\v2\x14.events.OrderCreatedH\x00R\forderCreated\x12>\n" +
+	"\x0ecart_abandoned\x18\x0f \x01(\v2\x15.events.CartAbandonedH\x00R\rcartAbandoned\x125\n" +
+	"\vsku_created\x18\x1e \x01(\v2\x12.events.SKUCreatedH\x00R\n" +
+	"skuCreated\x12;\n" +
+	"\rstock_changed\x18\x1f \x01(\v2\x14.events.StockChangedH\x00R\fstockChanged\x12;\n" +
+	"\rstock_deleted\x18  \x01(\v2\x14.events.StockDeletedH\x00R\fstockDeleted\x12;\n" +
+	"\rprice_changed\x18! \x01(\v2\x14.events.PriceChangedH\x00R\fpriceChanged\x12M\n" +
+	"\x13reservation_expired\x18\" \x01(\v2\x1a.events.ReservationExpiredH\x00R\x12reservationExpired\x12D\n" +
+	"\x13catalog_sku_created\x18# \x01(\v2\x12.events.CatalogSKUH\x00R\x11catalogSkuCreated\x12D\n" +
+	"\x13catalog_sku_updated\x18$ \x01(\v2\x12.events.CatalogSKUH\x00R\x11catalogSkuUpdated\x12F\n" +
+	"\x14catalog_sku_archived\x18% \x01(\v2\x12.events.CatalogSKUH\x00R\x12catalogSkuArchived\x1a?\n" +
+	"\x11TraceContextEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\apayload\"h\n" +
+	"\rCartItemAdded\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\x81\x01\n" +
+	"\x0eCartItemFailed\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"R\n" +
+	"\x0fCartItemRemoved\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"2\n" +
+	"\bCartLine\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"f\n" +
+	"\vCartCleared\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12&\n" +
+	"\x05items\x18\x02 \x03(\v2\x10.events.CartLineR\x05items\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"z\n" +
+	"\tOrderItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x01B\x02\x18\x01R\x05price\x12+\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\f.money.MoneyR\tunitPrice\"\xc1\x02\n" +
+	"\fOrderCreated\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\acart_id\x18\x02 \x01(\tR\x06cartId\x12'\n" +
+	"\x05items\x18\x03 \x03(\v2\x11.events.OrderItemR\x05items\x12#\n" +
+	"\vtotal_price\x18\x04 \x01(\x01B\x02\x18\x01R\n" +
+	"totalPrice\x12(\n" +
+	"\bsubtotal\x18\x05 \x01(\v2\f.money.MoneyR\bsubtotal\x12(\n" +
+	"\bdiscount\x18\x06 \x01(\v2\f.money.MoneyR\bdiscount\x12\x1f\n" +
+	"\vpromo_codes\x18\a \x03(\tR\n" +
+	"promoCodes\x12\"\n" +
+	"\x05total\x18\b \x01(\v2\f.money.MoneyR\x05total\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\"\x96\x01\n" +
+	"\rCartAbandoned\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12&\n" +
+	"\x05items\x18\x02 \x03(\v2\x10.events.CartLineR\x05items\x12D\n" +
+	"\x10last_activity_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\"{\n" +
+	"\n" +
+	"SKUCreated\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x18\n" +
+	"\x05price\x18\x02 \x01(\x01B\x02\x18\x01R\x05price\x12+\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\v2\f.money.MoneyR\tunitPrice\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"}\n" +
+	"\fStockChanged\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x01B\x02\x18\x01R\x05price\x12+\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\f.money.MoneyR\tunitPrice\"p\n" +
+	"\fStockDeleted\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x1c\n" +
+	"\tremaining\x18\x04 \x01(\x05R\tremaining\"\x92\x01\n" +
+	"\fPriceChanged\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12)\n" +
+	"\told_price\x18\x03 \x01(\v2\f.money.MoneyR\boldPrice\x12)\n" +
+	"\tnew_price\x18\x04 \x01(\v2\f.money.MoneyR\bnewPrice\"9\n" +
+	"\x0fReservationItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xbe\x01\n" +
+	"\x12ReservationExpired\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x05items\x18\x03 \x03(\v2\x17.events.ReservationItemR\x05items\x129\n" +
+	"\n" +
+	"expired_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiredAt\"\xc4\x01\n" +
+	"\n" +
+	"CatalogSKU\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12#\n" +
+	"\rcurrency_code\x18\x04 \x01(\tR\fcurrencyCode\x12\x1a\n" +
+	"\barchived\x18\x05 \x01(\bR\barchived\x12;\n" +
+	"\varchived_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAtB\x12Z\x10pkg/api/eventspbb\x06proto3"
+
+var (
+	file_events_events_proto_rawDescOnce sync.Once
+	file_events_events_proto_rawDescData []byte
+)
+
+func file_events_events_proto_rawDescGZIP() []byte {
+	file_events_events_proto_rawDescOnce.Do(func() {
+		file_events_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_events_proto_rawDesc), len(file_events_events_proto_rawDesc)))
+	})
+	return file_events_events_proto_rawDescData
+}
+
+var file_events_events_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_events_events_proto_goTypes = []any{
+	(*Envelope)(nil),              // 0: events.Envelope
+	(*CartItemAdded)(nil),         // 1: events.CartItemAdded
+	(*CartItemFailed)(nil),        // 2: events.CartItemFailed
+	(*CartItemRemoved)(nil),       // 3: events.CartItemRemoved
+	(*CartLine)(nil),              // 4: events.CartLine
+	(*CartCleared)(nil),           // 5: events.CartCleared
+	(*OrderItem)(nil),             // 6: events.OrderItem
+	(*OrderCreated)(nil),          // 7: events.OrderCreated
+	(*CartAbandoned)(nil),         // 8: events.CartAbandoned
+	(*SKUCreated)(nil),            // 9: events.SKUCreated
+	(*StockChanged)(nil),          // 10: events.StockChanged
+	(*StockDeleted)(nil),          // 11: events.StockDeleted
+	(*PriceChanged)(nil),          // 12: events.PriceChanged
+	(*ReservationItem)(nil),       // 13: events.ReservationItem
+	(*ReservationExpired)(nil),    // 14: events.ReservationExpired
+	(*CatalogSKU)(nil),            // 15: events.CatalogSKU
+	nil,                           // 16: events.Envelope.TraceContextEntry
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*money.Money)(nil),           // 18: money.Money
+}
+var file_events_events_proto_depIdxs = []int32{
+	17, // 0: events.Envelope.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 1: events.Envelope.trace_context:type_name -> events.Envelope.TraceContextEntry
+	1,  // 2: events.Envelope.cart_item_added:type_name -> events.CartItemAdded
+	2,  // 3: events.Envelope.cart_item_failed:type_name -> events.CartItemFailed
+	3,  // 4: events.Envelope.cart_item_removed:type_name -> events.CartItemRemoved
+	5,  // 5: events.Envelope.cart_cleared:type_name -> events.CartCleared
+	7,  // 6: events.Envelope.order_created:type_name -> events.OrderCreated
+	8,  // 7: events.Envelope.cart_abandoned:type_name -> events.CartAbandoned
+	9,  // 8: events.Envelope.sku_created:type_name -> events.SKUCreated
+	10, // 9: events.Envelope.stock_changed:type_name -> events.StockChanged
+	11, // 10: events.Envelope.stock_deleted:type_name -> events.StockDeleted
+	12, // 11: events.Envelope.price_changed:type_name -> events.PriceChanged
+	14, // 12: events.Envelope.reservation_expired:type_name -> events.ReservationExpired
+	15, // 13: events.Envelope.catalog_sku_created:type_name -> events.CatalogSKU
+	15, // 14: events.Envelope.catalog_sku_updated:type_name -> events.CatalogSKU
+	15, // 15: events.Envelope.catalog_sku_archived:type_name -> events.CatalogSKU
+	4,  // 16: events.CartCleared.items:type_name -> events.CartLine
+	18, // 17: events.OrderItem.unit_price:type_name -> money.Money
+	6,  // 18: events.OrderCreated.items:type_name -> events.OrderItem
+	18, // 19: events.OrderCreated.subtotal:type_name -> money.Money
+	18, // 20: events.OrderCreated.discount:type_name -> money.Money
+	18, // 21: events.OrderCreated.total:type_name -> money.Money
+	4,  // 22: events.CartAbandoned.items:type_name -> events.CartLine
+	17, // 23: events.CartAbandoned.last_activity_at:type_name -> google.protobuf.Timestamp
+	18, // 24: events.SKUCreated.unit_price:type_name -> money.Money
+	18, // 25: events.StockChanged.unit_price:type_name -> money.Money
+	18, // 26: events.PriceChanged.old_price:type_name -> money.Money
+	18, // 27: events.PriceChanged.new_price:type_name -> money.Money
+	13, // 28: events.ReservationExpired.items:type_name -> events.ReservationItem
+	17, // 29: events.ReservationExpired.expired_at:type_name -> google.protobuf.Timestamp
+	17, // 30: events.CatalogSKU.archived_at:type_name -> google.protobuf.Timestamp
+	31, // [31:31] is the sub-list for method output_type
+	31, // [31:31] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
+}
+
+func init() { file_events_events_proto_init() }
+func file_events_events_proto_init() {
+	if File_events_events_proto != nil {
+		return
+	}
+	file_events_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_CartItemAdded)(nil),
+		(*Envelope_CartItemFailed)(nil),
+		(*Envelope_CartItemRemoved)(nil),
+		(*Envelope_CartCleared)(nil),
+		(*Envelope_OrderCreated)(nil),
+		(*Envelope_CartAbandoned)(nil),
+		(*Envelope_SkuCreated)(nil),
+		(*Envelope_StockChanged)(nil),
+		(*Envelope_StockDeleted)(nil),
+		(*Envelope_PriceChanged)(nil),
+		(*Envelope_ReservationExpired)(nil),
+		(*Envelope_CatalogSkuCreated)(nil),
+		(*Envelope_CatalogSkuUpdated)(nil),
+		(*Envelope_CatalogSkuArchived)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_events_proto_rawDesc), len(file_events_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_events_proto_goTypes,
+		DependencyIndexes: file_events_events_proto_depIdxs,
+		MessageInfos:      file_events_events_proto_msgTypes,
+	}.Build()
+	File_events_events_proto = out.File
+	file_events_events_proto_goTypes = nil
+	file_events_events_proto_depIdxs = nil
+}
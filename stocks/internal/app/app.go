@@ -12,12 +12,15 @@ import (
 
 	"stocks/internal/config"
 	"stocks/internal/db"
+	"stocks/internal/idempotency"
 	"stocks/internal/kafka"
 	"stocks/internal/log"
 	"stocks/internal/log/zap"
 	"stocks/internal/metrics"
+	"stocks/internal/outbox"
 	"stocks/internal/repository"
 	"stocks/internal/server"
+	"stocks/internal/sweeper"
 	"stocks/internal/trace"
 	"stocks/internal/usecase"
 
@@ -27,7 +30,7 @@ import (
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 )
 
-const serverCount = 4
+const serverCount = 6
 
 func Run(envFile string) error {
 	cfg, err := config.Load(envFile)
@@ -87,7 +90,11 @@ func Run(envFile string) error {
 		}
 	}()
 
-	useCase := usecase.NewStockUsecase(repo, txManager, producer, logger)
+	outboxRepo := repository.NewPostgresOutboxRepo(dbx, txCtxGetter)
+	idempotencyRepo := repository.NewPostgresIdempotencyRepo(dbx, txCtxGetter)
+	eventProducer := kafka.NewProducerWithSink(producerConfig.Service, producerConfig.ContentType, outbox.NewWriter(outboxRepo), logger)
+
+	useCase := usecase.NewStockUsecase(repo, txManager, eventProducer, logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -113,7 +120,7 @@ func Run(envFile string) error {
 		defer wg.Done()
 		logger.Info("Starting gRPC server", log.String("port", cfg.GRPCPort))
 
-		if err := server.StartGRPCServer(ctx, cfg, useCase, logger, metricsInstance); err != nil {
+		if err := server.StartGRPCServer(ctx, cfg, useCase, idempotencyRepo, logger, metricsInstance); err != nil {
 			errCh <- fmt.Errorf("gRPC server failed: %w", err)
 		}
 	}()
@@ -127,6 +134,33 @@ func Run(envFile string) error {
 		}
 	}()
 
+	go func() {
+		defer wg.Done()
+		logger.Info("Starting reservation sweeper",
+			log.String("interval", cfg.ReservationSweepInterval.String()),
+		)
+
+		sweeper.New(useCase, cfg.ReservationSweepInterval, logger).Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		logger.Info("Starting outbox relay",
+			log.String("interval", cfg.OutboxRelayInterval.String()),
+		)
+
+		outbox.NewRelay(outboxRepo, txManager, producer, cfg.OutboxRelayInterval, metricsInstance, logger).Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		logger.Info("Starting idempotency key cleaner",
+			log.String("ttl", cfg.IdempotencyKeyTTL.String()),
+		)
+
+		idempotency.NewCleaner(idempotencyRepo, config.IdempotencyCleanupInterval, logger).Run(ctx)
+	}()
+
 	select {
 	case sig := <-stop:
 		logger.Info("Shutdown signal received", log.String("signal", sig.String()))
@@ -135,6 +169,7 @@ func Run(envFile string) error {
 		return err
 	}
 
+	cancel()
 	wg.Wait()
 	logger.Info("Stocks server gracefully stopped")
 
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleSeller Role = "seller"
+	RoleBuyer  Role = "buyer"
+	// RoleService is held by other services acting on their own behalf,
+	// such as cart reducing stock at checkout.
+	RoleService Role = "service"
+)
+
+// Identity is the authenticated caller, taken from a verified token.
+type Identity struct {
+	UserID  int64
+	Subject string
+	Roles   []Role
+}
+
+func (i Identity) HasRole(role Role) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+type identityKey struct{}
+
+func NewContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"stocks/internal/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+// UnaryServerInterceptor rejects calls without a valid bearer token and puts
+// the caller's Identity in the handler context.
+func UnaryServerInterceptor(verifier *Verifier, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		token, ok := bearerToken(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+
+		identity, err := verifier.Verify(token)
+		if err != nil {
+			logger.Warn("rejected token",
+				log.String("method", info.FullMethod),
+				log.Error(err),
+			)
+
+			return nil, status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+		}
+
+		return handler(NewContext(ctx, identity), req)
+	}
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	value := values[0]
+	if len(value) <= len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(value[len(bearerPrefix):]), true
+}
@@ -0,0 +1,94 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stocks/internal/auth"
+	"stocks/internal/log/zap"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: testSecret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+
+	interceptor := auth.UnaryServerInterceptor(verifier, logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/stock.StockService/GetItem"}
+
+	tests := []struct {
+		name     string
+		header   string
+		wantCode codes.Code
+		wantID   int64
+	}{
+		{
+			name:   "valid bearer token",
+			header: "Bearer " + signHMAC(t, testSecret, userClaims("9", time.Hour)),
+			wantID: 9,
+		},
+		{
+			name:     "missing header",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "wrong scheme",
+			header:   "Basic dXNlcjpwYXNz",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			header:   "Bearer " + signHMAC(t, "other-secret", userClaims("9", time.Hour)),
+			wantCode: codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.header))
+			}
+
+			var gotID int64
+
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				identity, ok := auth.FromContext(ctx)
+				if !ok {
+					t.Fatal("identity missing from context")
+				}
+				gotID = identity.UserID
+
+				return nil, nil
+			})
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, tt.wantID, gotID)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"stocks/internal/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "test-secret"
+
+func signHMAC(t *testing.T, secret string, claims jwt.Claims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return token
+}
+
+func userClaims(sub string, expiresIn time.Duration) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   sub,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+	}
+}
+
+func TestVerifier_HMAC(t *testing.T) {
+	t.Parallel()
+
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: testSecret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantID  int64
+		wantErr bool
+	}{
+		{
+			name:   "valid token",
+			token:  signHMAC(t, testSecret, userClaims("42", time.Hour)),
+			wantID: 42,
+		},
+		{
+			name:    "expired token",
+			token:   signHMAC(t, testSecret, userClaims("42", -time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:    "wrong secret",
+			token:   signHMAC(t, "other-secret", userClaims("42", time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:    "subject is not a user id",
+			token:   signHMAC(t, testSecret, userClaims("alice", time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:    "missing expiry",
+			token:   signHMAC(t, testSecret, jwt.RegisteredClaims{Subject: "42"}),
+			wantErr: true,
+		},
+		{
+			name:    "not a token",
+			token:   "garbage",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			identity, err := verifier.Verify(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, auth.ErrInvalidToken)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, tt.wantID, identity.UserID)
+		})
+	}
+}
+
+func TestVerifier_IssuerAndAudience(t *testing.T) {
+	t.Parallel()
+
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: testSecret, Issuer: "idp", Audience: "stocks"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims := userClaims("7", time.Hour)
+	claims.Issuer = "idp"
+	claims.Audience = jwt.ClaimStrings{"stocks"}
+
+	_, err = verifier.Verify(signHMAC(t, testSecret, claims))
+	assert.NoError(t, err)
+
+	claims.Audience = jwt.ClaimStrings{"cart"}
+
+	_, err = verifier.Verify(signHMAC(t, testSecret, claims))
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": "k1",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys, err := auth.ParseJWKS(jwks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sign := func(kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, userClaims("5", time.Hour))
+		token.Header["kid"] = kid
+
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return signed
+	}
+
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256"}))
+
+	_, err = parser.Parse(sign("k1"), keys.Keyfunc)
+	assert.NoError(t, err)
+
+	_, err = parser.Parse(sign("unknown"), keys.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestVerifier_Roles(t *testing.T) {
+	t.Parallel()
+
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: testSecret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		roles     []string
+		wantRoles []auth.Role
+	}{
+		{
+			name:      "no roles claim defaults to buyer",
+			wantRoles: []auth.Role{auth.RoleBuyer},
+		},
+		{
+			name:      "known roles are kept",
+			roles:     []string{"seller", "admin", "seller", "service"},
+			wantRoles: []auth.Role{auth.RoleSeller, auth.RoleAdmin, auth.RoleService},
+		},
+		{
+			name:      "unknown roles are dropped",
+			roles:     []string{"superuser"},
+			wantRoles: []auth.Role{auth.RoleBuyer},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			token := signHMAC(t, testSecret, struct {
+				jwt.RegisteredClaims
+				Roles []string `json:"roles,omitempty"`
+			}{
+				RegisteredClaims: userClaims("3", time.Hour),
+				Roles:            tt.roles,
+			})
+
+			identity, err := verifier.Verify(token)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, tt.wantRoles, identity.Roles)
+		})
+	}
+}
+
+func TestNewVerifier_Config(t *testing.T) {
+	t.Parallel()
+
+	_, err := auth.NewVerifier(auth.Config{})
+	assert.ErrorIs(t, err, auth.ErrNotConfigured)
+
+	_, err = auth.NewVerifier(auth.Config{HMACSecret: testSecret, JWKSFile: "jwks.json"})
+	assert.Error(t, err)
+}
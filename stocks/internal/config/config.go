@@ -5,6 +5,8 @@ import (
 	"os"
 	"time"
 
+	"stocks/internal/auth"
+
 	"github.com/joho/godotenv"
 )
 
@@ -21,6 +23,17 @@ type Config struct {
 	ReadTimeout    time.Duration
 	WriteTimeout   time.Duration
 	IdleTimeout    time.Duration
+
+	ReservationSweepInterval time.Duration
+	OutboxRelayInterval      time.Duration
+	// IdempotencyKeyTTL is how long the response to a request with an
+	// Idempotency-Key is replayed.
+	IdempotencyKeyTTL time.Duration
+
+	AuthJWKSFile   string
+	AuthHMACSecret string
+	AuthIssuer     string
+	AuthAudience   string
 }
 
 func Load(envFile string) (*Config, error) {
@@ -41,15 +54,64 @@ func Load(envFile string) (*Config, error) {
 		ReadTimeout:    ReadTimeout,
 		WriteTimeout:   WriteTimeout,
 		IdleTimeout:    IdleTimeout,
+
+		ReservationSweepInterval: DefaultReservationSweepInterval,
+		OutboxRelayInterval:      DefaultOutboxRelayInterval,
+		IdempotencyKeyTTL:        DefaultIdempotencyKeyTTL,
+
+		AuthJWKSFile:   os.Getenv("AUTH_JWKS_FILE"),
+		AuthHMACSecret: os.Getenv("AUTH_HMAC_SECRET"),
+		AuthIssuer:     os.Getenv("AUTH_ISSUER"),
+		AuthAudience:   os.Getenv("AUTH_AUDIENCE"),
 	}
 
 	if cfg.DBHost == "" || cfg.DBUser == "" || cfg.DBName == "" {
 		return nil, fmt.Errorf("missing required environment variables")
 	}
 
+	if cfg.AuthJWKSFile == "" && cfg.AuthHMACSecret == "" {
+		return nil, fmt.Errorf("AUTH_JWKS_FILE or AUTH_HMAC_SECRET must be set")
+	}
+
+	if v := os.Getenv("RESERVATION_SWEEP_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid RESERVATION_SWEEP_INTERVAL %q", v)
+		}
+
+		cfg.ReservationSweepInterval = interval
+	}
+
+	if v := os.Getenv("OUTBOX_RELAY_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid OUTBOX_RELAY_INTERVAL %q", v)
+		}
+
+		cfg.OutboxRelayInterval = interval
+	}
+
+	if v := os.Getenv("IDEMPOTENCY_KEY_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL %q", v)
+		}
+
+		cfg.IdempotencyKeyTTL = ttl
+	}
+
 	return cfg, nil
 }
 
+func (c *Config) AuthConfig() auth.Config {
+	return auth.Config{
+		JWKSFile:   c.AuthJWKSFile,
+		HMACSecret: c.AuthHMACSecret,
+		Issuer:     c.AuthIssuer,
+		Audience:   c.AuthAudience,
+	}
+}
+
 func (c *Config) PostgresConnStr() string {
 	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
@@ -6,4 +6,10 @@ const (
 	ReadTimeout  = 5 * time.Second
 	WriteTimeout = 10 * time.Second
 	IdleTimeout  = 15 * time.Second
+
+	DefaultReservationSweepInterval = 30 * time.Second
+	DefaultOutboxRelayInterval      = time.Second
+
+	DefaultIdempotencyKeyTTL   = 24 * time.Hour
+	IdempotencyCleanupInterval = 10 * time.Minute
 )
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reservations (
+    reservation_id BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'active',
+    expires_at     TIMESTAMPTZ NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservations_active_expires_at
+    ON reservations (expires_at)
+    WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS reservation_items (
+    reservation_id BIGINT NOT NULL REFERENCES reservations (reservation_id) ON DELETE CASCADE,
+    sku            BIGINT NOT NULL REFERENCES sku_info (sku),
+    count          INTEGER NOT NULL CHECK (count > 0),
+    PRIMARY KEY (reservation_id, sku)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_items_sku ON reservation_items (sku);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reservation_items;
+DROP TABLE IF EXISTS reservations;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE stock_items DROP CONSTRAINT IF EXISTS stock_items_sku_key;
+
+ALTER TABLE stock_items
+    ADD CONSTRAINT stock_items_sku_location_key UNIQUE (sku, location);
+
+CREATE INDEX IF NOT EXISTS idx_stock_items_location ON stock_items (location);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_stock_items_location;
+
+ALTER TABLE stock_items DROP CONSTRAINT IF EXISTS stock_items_sku_location_key;
+
+ALTER TABLE stock_items
+    ADD CONSTRAINT stock_items_sku_key UNIQUE (sku);
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stock_movements (
+    movement_id BIGSERIAL PRIMARY KEY,
+    sku         BIGINT NOT NULL,
+    location    TEXT NOT NULL,
+    delta       INTEGER NOT NULL,
+    reason      TEXT NOT NULL,
+    user_id     BIGINT NOT NULL,
+    trace_id    TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_sku ON stock_movements (sku, movement_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_location ON stock_movements (location, movement_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_user ON stock_movements (user_id, movement_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_created_at ON stock_movements (created_at);
+
+-- The ledger is append-only.
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_no_update_delete
+    BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_movements;
+DROP FUNCTION IF EXISTS stock_movements_append_only();
+-- +goose StatementEnd
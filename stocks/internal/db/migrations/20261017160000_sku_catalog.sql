@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sku_info
+    ADD COLUMN archived_at TIMESTAMPTZ,
+    ADD COLUMN created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at  TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_sku_info_type ON sku_info (type, sku);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sku_info_type;
+
+ALTER TABLE sku_info
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS archived_at;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_stock_items_location_price ON stock_items (location, price, sku);
+CREATE INDEX IF NOT EXISTS idx_stock_items_location_count ON stock_items (location, count, sku);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_stock_items_location_count;
+DROP INDEX IF EXISTS idx_stock_items_location_price;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sku_info
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sku_info
+    DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- owner_id is whoever owned the stock row when it moved; user_id stays the
+-- actor, e.g. the buyer of a sale.
+ALTER TABLE stock_movements
+    ADD COLUMN owner_id BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE stock_movements DISABLE TRIGGER stock_movements_no_update_delete;
+
+UPDATE stock_movements m
+SET owner_id = s.user_id
+FROM stock_items s
+WHERE s.sku = m.sku AND s.location = m.location;
+
+ALTER TABLE stock_movements ENABLE TRIGGER stock_movements_no_update_delete;
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_owner ON stock_movements (owner_id, movement_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_stock_movements_owner;
+
+ALTER TABLE stock_movements
+    DROP COLUMN IF EXISTS owner_id;
+-- +goose StatementEnd
@@ -3,6 +3,7 @@ package delivery_test
 import (
 	"context"
 	stdErr "errors"
+	"fmt"
 	"testing"
 
 	"stocks/internal/delivery"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestHandler_AddItem(t *testing.T) {
@@ -61,11 +64,12 @@ func TestHandler_AddItem(t *testing.T) {
 			name: "ownership violation error",
 			req:  validReq,
 			mockSetup: func() {
-				mockUsecase.EXPECT().Add(gomock.Any(), gomock.Any()).Return(errors.ErrOwnershipViolation)
+				mockUsecase.EXPECT().Add(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("%w: %w", errors.ErrPermissionDenied, errors.ErrOwnershipViolation))
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 			},
-			expectedErr: "ownership violation",
+			expectedErr: "code = PermissionDenied desc = permission denied: ownership violation",
 		},
 		{
 			name: "internal server error",
@@ -83,7 +87,7 @@ func TestHandler_AddItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := server.AddItem(context.Background(), tt.req)
+			resp, err := server.AddItem(userContext(1), tt.req)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
@@ -96,3 +100,17 @@ func TestHandler_AddItem(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_AddItem_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := delivery.NewStockServer(mocks.NewMockStockUseCase(ctrl), mocks.NewMockLogger(ctrl))
+
+	resp, err := server.AddItem(context.Background(), &stockspb.AddItemRequest{Sku: "100", Location: "warehouse1", Count: 2})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Nil(t, resp)
+}
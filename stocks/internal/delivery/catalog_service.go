@@ -0,0 +1,160 @@
+package delivery
+
+import (
+	"context"
+
+	"stocks/internal/errors"
+	"stocks/internal/log"
+	stockpb "stocks/pkg/api/stocks"
+
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *StockServer) CreateSKU(ctx context.Context, req *stockpb.CreateSKURequest) (*stockpb.SKU, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "CreateSKU")
+	defer span.End()
+
+	s.logger.Info("CreateSKU called",
+		log.String("sku", req.GetSku()),
+		log.String("name", req.GetName()),
+		log.String("type", req.GetType()),
+	)
+
+	sku, err := ValidateCreateSKURequest(req)
+	if err != nil {
+		s.logger.Error("Invalid CreateSKU request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	created, err := s.usecase.CreateSKU(ctx, sku)
+	if err != nil {
+		return nil, s.catalogError("CreateSKU", "failed to create SKU", err)
+	}
+
+	s.logger.Info("SKU created", log.UInt32("sku", created.SKU))
+
+	return SKUToProto(created), nil
+}
+
+func (s *StockServer) UpdateSKU(ctx context.Context, req *stockpb.UpdateSKURequest) (*stockpb.SKU, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "UpdateSKU")
+	defer span.End()
+
+	s.logger.Info("UpdateSKU called",
+		log.String("sku", req.GetSku()),
+		log.String("name", req.GetName()),
+		log.String("type", req.GetType()),
+	)
+
+	sku, err := ValidateUpdateSKURequest(req)
+	if err != nil {
+		s.logger.Error("Invalid UpdateSKU request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	updated, err := s.usecase.UpdateSKU(ctx, sku)
+	if err != nil {
+		return nil, s.catalogError("UpdateSKU", "failed to update SKU", err)
+	}
+
+	s.logger.Info("SKU updated", log.UInt32("sku", updated.SKU))
+
+	return SKUToProto(updated), nil
+}
+
+func (s *StockServer) ArchiveSKU(ctx context.Context, req *stockpb.ArchiveSKURequest) (*stockpb.SKU, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "ArchiveSKU")
+	defer span.End()
+
+	s.logger.Info("ArchiveSKU called", log.String("sku", req.GetSku()))
+
+	sku, err := ParseSKU(req.GetSku())
+	if err != nil {
+		s.logger.Error("Invalid ArchiveSKU request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	archived, err := s.usecase.ArchiveSKU(ctx, sku)
+	if err != nil {
+		return nil, s.catalogError("ArchiveSKU", "failed to archive SKU", err)
+	}
+
+	s.logger.Info("SKU archived", log.UInt32("sku", archived.SKU))
+
+	return SKUToProto(archived), nil
+}
+
+func (s *StockServer) GetSKU(ctx context.Context, req *stockpb.GetSKURequest) (*stockpb.SKU, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "GetSKU")
+	defer span.End()
+
+	s.logger.Info("GetSKU called", log.String("sku", req.GetSku()))
+
+	sku, err := ParseSKU(req.GetSku())
+	if err != nil {
+		s.logger.Error("Invalid GetSKU request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	found, err := s.usecase.GetSKU(ctx, sku)
+	if err != nil {
+		return nil, s.catalogError("GetSKU", "failed to get SKU", err)
+	}
+
+	return SKUToProto(found), nil
+}
+
+func (s *StockServer) ListSKUs(ctx context.Context, req *stockpb.ListSKUsRequest) (*stockpb.ListSKUsResponse, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "ListSKUs")
+	defer span.End()
+
+	s.logger.Info("ListSKUs called",
+		log.String("type", req.GetType()),
+		log.Bool("include_archived", req.GetIncludeArchived()),
+	)
+
+	filter, err := ValidateListSKUsRequest(req)
+	if err != nil {
+		s.logger.Error("Invalid ListSKUs request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	skus, err := s.usecase.ListSKUs(ctx, filter)
+	if err != nil {
+		return nil, s.catalogError("ListSKUs", "failed to list SKUs", err)
+	}
+
+	s.logger.Info("ListSKUs succeeded", log.Int("skus_count", len(skus)))
+
+	return SKUsToProto(skus, filter.Limit), nil
+}
+
+func (s *StockServer) catalogError(method, internalMsg string, err error) error {
+	if denied := s.permissionDenied(method, err); denied != nil {
+		return denied
+	}
+
+	fields := []log.Field{log.Error(err)}
+
+	switch err {
+	case errors.ErrSKUNotFound:
+		s.logger.Error(method+" error: not found", fields...)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.ErrSKUExists:
+		s.logger.Error(method+" error: already exists", fields...)
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.ErrSKUArchived:
+		s.logger.Error(method+" error: archived", fields...)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		s.logger.Error(method+" error: internal", fields...)
+		return status.Error(codes.Internal, internalMsg)
+	}
+}
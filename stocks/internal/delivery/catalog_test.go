@@ -0,0 +1,257 @@
+package delivery_test
+
+import (
+	"context"
+	"fmt"
+	"stocks/internal/delivery"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+	"stocks/internal/usecase/mocks"
+	stockspb "stocks/pkg/api/stocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_CreateSKU(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	createdAt := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	sku := models.SKU{SKU: 11011, Name: "scarf", Type: "apparel", Currency: "RUB", CreatedAt: createdAt, UpdatedAt: createdAt}
+
+	tests := []struct {
+		name           string
+		req            *stockspb.CreateSKURequest
+		mockSetup      func()
+		expectedResult *stockspb.SKU
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			req:  &stockspb.CreateSKURequest{Sku: "11011", Name: " scarf ", Type: "apparel"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().CreateSKU(gomock.Any(), models.SKU{SKU: 11011, Name: "scarf", Type: "apparel", Currency: "RUB"}).Return(sku, nil)
+			},
+			expectedResult: &stockspb.SKU{
+				Sku:          "11011",
+				Name:         "scarf",
+				Type:         "apparel",
+				CurrencyCode: "RUB",
+				CreatedAt:    "2026-10-17T12:00:00Z",
+				UpdatedAt:    "2026-10-17T12:00:00Z",
+			},
+		},
+		{
+			name: "invalid currency",
+			req:  &stockspb.CreateSKURequest{Sku: "11011", Name: "scarf", Type: "apparel", CurrencyCode: "rubles"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "invalid currency code",
+		},
+		{
+			name: "missing name",
+			req:  &stockspb.CreateSKURequest{Sku: "11011", Type: "apparel"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "code = InvalidArgument desc = name must be non-empty",
+		},
+		{
+			name: "already exists",
+			req:  &stockspb.CreateSKURequest{Sku: "11011", Name: "scarf", Type: "apparel"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().CreateSKU(gomock.Any(), gomock.Any()).Return(models.SKU{}, errors.ErrSKUExists)
+			},
+			expectedErr: "code = AlreadyExists",
+		},
+		{
+			name: "not an admin",
+			req:  &stockspb.CreateSKURequest{Sku: "11011", Name: "scarf", Type: "apparel"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().CreateSKU(gomock.Any(), gomock.Any()).
+					Return(models.SKU{}, fmt.Errorf("%w: only admins can manage the SKU catalog", errors.ErrPermissionDenied))
+			},
+			expectedErr: "code = PermissionDenied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.CreateSKU(context.Background(), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_ArchiveSKU(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	archivedAt := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		mockSetup       func()
+		expectedArchive string
+		expectedErr     string
+	}{
+		{
+			name: "success",
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().ArchiveSKU(gomock.Any(), uint32(1001)).
+					Return(models.SKU{SKU: 1001, Name: "t-shirt", Type: "apparel", ArchivedAt: archivedAt}, nil)
+			},
+			expectedArchive: "2026-10-17T12:00:00Z",
+		},
+		{
+			name: "already archived",
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().ArchiveSKU(gomock.Any(), uint32(1001)).Return(models.SKU{}, errors.ErrSKUArchived)
+			},
+			expectedErr: "code = FailedPrecondition",
+		},
+		{
+			name: "not found",
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().ArchiveSKU(gomock.Any(), uint32(1001)).Return(models.SKU{}, errors.ErrSKUNotFound)
+			},
+			expectedErr: "code = NotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.ArchiveSKU(context.Background(), &stockspb.ArchiveSKURequest{Sku: "1001"})
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, resp.GetArchived())
+				assert.Equal(t, tt.expectedArchive, resp.GetArchivedAt())
+			}
+		})
+	}
+}
+
+func TestHandler_ListSKUs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	tests := []struct {
+		name          string
+		req           *stockspb.ListSKUsRequest
+		mockSetup     func()
+		expectedSKUs  []string
+		expectedToken string
+		expectedErr   string
+	}{
+		{
+			name: "full page returns a token",
+			req:  &stockspb.ListSKUsRequest{Type: "apparel", PageSize: 2, PageToken: "1000"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().ListSKUs(gomock.Any(), models.SKUFilter{Type: "apparel", AfterSKU: 1000, Limit: 2}).
+					Return([]models.SKU{{SKU: 1001}, {SKU: 6066}}, nil)
+			},
+			expectedSKUs:  []string{"1001", "6066"},
+			expectedToken: "6066",
+		},
+		{
+			name: "last page",
+			req:  &stockspb.ListSKUsRequest{IncludeArchived: true},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().ListSKUs(gomock.Any(), models.SKUFilter{IncludeArchived: true, Limit: 100}).
+					Return([]models.SKU{{SKU: 1001}}, nil)
+			},
+			expectedSKUs: []string{"1001"},
+		},
+		{
+			name: "invalid page token",
+			req:  &stockspb.ListSKUsRequest{PageToken: "abc"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "invalid page_token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.ListSKUs(context.Background(), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			skus := make([]string, 0, len(resp.GetSkus()))
+			for _, sku := range resp.GetSkus() {
+				skus = append(skus, sku.GetSku())
+			}
+
+			assert.Equal(t, tt.expectedSKUs, skus)
+			assert.Equal(t, tt.expectedToken, resp.GetNextPageToken())
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package delivery_test
+
+import (
+	"context"
+
+	"stocks/internal/auth"
+)
+
+func userContext(userID int64) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{UserID: userID})
+}
+
+func serviceContext(userID int64) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{UserID: userID, Roles: []auth.Role{auth.RoleService}})
+}
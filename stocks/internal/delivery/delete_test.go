@@ -1,9 +1,10 @@
 package delivery_test
 
 import (
-	"context"
 	stdErr "errors"
+	"fmt"
 	"stocks/internal/delivery"
+	"stocks/internal/errors"
 	"stocks/internal/usecase/mocks"
 	stockspb "stocks/pkg/api/stocks"
 	"testing"
@@ -26,6 +27,7 @@ func TestHandler_DeleteItem(t *testing.T) {
 	validReq := &stockspb.DeleteItemRequest{
 		Sku:      "1001",
 		Location: "warehouse1",
+		UserId:   1,
 	}
 
 	tests := []struct {
@@ -39,7 +41,7 @@ func TestHandler_DeleteItem(t *testing.T) {
 			name: "success",
 			req:  validReq,
 			mockSetup: func() {
-				mockUsecase.EXPECT().Delete(gomock.Any(), uint32(1001)).Return(nil)
+				mockUsecase.EXPECT().Delete(gomock.Any(), int64(1), uint32(1001), "warehouse1").Return(nil)
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 			},
 			expectedResult: &stockspb.StockResponse{Message: "Item deleted successfully"},
@@ -48,19 +50,53 @@ func TestHandler_DeleteItem(t *testing.T) {
 			name: "internal error from usecase",
 			req:  validReq,
 			mockSetup: func() {
-				mockUsecase.EXPECT().Delete(gomock.Any(), uint32(1001)).Return(stdErr.New("db error"))
+				mockUsecase.EXPECT().Delete(gomock.Any(), int64(1), uint32(1001), "warehouse1").Return(stdErr.New("db error"))
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
 			},
 			expectedErr: "failed to delete item",
 		},
+		{
+			name: "request user_id is ignored",
+			req: &stockspb.DeleteItemRequest{
+				Sku:      "1001",
+				Location: "warehouse1",
+				UserId:   99,
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().Delete(gomock.Any(), int64(1), uint32(1001), "warehouse1").Return(nil)
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+			},
+			expectedResult: &stockspb.StockResponse{Message: "Item deleted successfully"},
+		},
+		{
+			name: "buyer cannot delete",
+			req:  validReq,
+			mockSetup: func() {
+				mockUsecase.EXPECT().Delete(gomock.Any(), int64(1), uint32(1001), "warehouse1").
+					Return(fmt.Errorf("%w: buyers have read-only access to stock", errors.ErrPermissionDenied))
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "code = PermissionDenied desc = permission denied: buyers have read-only access to stock",
+		},
+		{
+			name: "not found at location",
+			req:  validReq,
+			mockSetup: func() {
+				mockUsecase.EXPECT().Delete(gomock.Any(), int64(1), uint32(1001), "warehouse1").Return(errors.ErrItemNotFound)
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "NotFound",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := server.DeleteItem(context.Background(), tt.req)
+			resp, err := server.DeleteItem(userContext(1), tt.req)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
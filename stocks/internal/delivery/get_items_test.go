@@ -0,0 +1,123 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/delivery"
+	"stocks/internal/models"
+	"stocks/internal/money"
+	"stocks/internal/usecase/mocks"
+	moneypb "stocks/pkg/api/money"
+	stockspb "stocks/pkg/api/stocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetItems(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	validReq := &stockspb.GetItemsRequest{
+		Skus: []string{"2020", "1001", "2020", "3030"},
+	}
+
+	foundItems := []models.StockItem{
+		{SKU: 1001, Location: "loc1", Price: money.New("RUB", 1550), Count: 10, Reserved: 2},
+		{SKU: 2020, Location: "loc2", Price: money.New("RUB", 500), Count: 1},
+	}
+
+	tests := []struct {
+		name           string
+		req            *stockspb.GetItemsRequest
+		mockSetup      func()
+		expectedResult *stockspb.GetItemsResponse
+		expectedErr    string
+	}{
+		{
+			name: "success with missing sku",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().GetBySKUs(gomock.Any(), []uint32{2020, 1001, 3030}).Return(foundItems, nil)
+			},
+			expectedResult: &stockspb.GetItemsResponse{
+				Results: []*stockspb.GetItemsResult{
+					{
+						Sku:    "2020",
+						Status: stockspb.ItemStatus_ITEM_STATUS_FOUND,
+						Item: &stockspb.StockItem{
+							Sku: "2020", Location: "loc2", Count: 1, Price: 5, Available: 1,
+							UnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 5},
+						},
+					},
+					{
+						Sku:    "1001",
+						Status: stockspb.ItemStatus_ITEM_STATUS_FOUND,
+						Item: &stockspb.StockItem{
+							Sku: "1001", Location: "loc1", Count: 10, Price: 15.5, Reserved: 2, Available: 8,
+							UnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 15, Nanos: 500_000_000},
+						},
+					},
+					{
+						Sku:    "3030",
+						Status: stockspb.ItemStatus_ITEM_STATUS_NOT_FOUND,
+					},
+				},
+			},
+		},
+		{
+			name: "empty skus",
+			req:  &stockspb.GetItemsRequest{},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "skus must be non-empty",
+		},
+		{
+			name: "invalid sku",
+			req:  &stockspb.GetItemsRequest{Skus: []string{"1001", "abc"}},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "invalid sku format",
+		},
+		{
+			name: "internal error",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().GetBySKUs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedErr: "failed to get items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.GetItems(context.Background(), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
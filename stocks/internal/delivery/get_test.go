@@ -5,7 +5,9 @@ import (
 	"errors"
 	"stocks/internal/delivery"
 	"stocks/internal/models"
+	"stocks/internal/money"
 	"stocks/internal/usecase/mocks"
+	moneypb "stocks/pkg/api/money"
 	stockspb "stocks/pkg/api/stocks"
 	"testing"
 
@@ -34,8 +36,9 @@ func TestHandler_GetItem(t *testing.T) {
 		SKU:      1001,
 		Name:     "t-shirt",
 		Type:     "clothing",
-		Price:    15.5,
+		Price:    money.New("RUB", 1550),
 		Count:    10,
+		Reserved: 3,
 		Location: "loc1",
 	}
 
@@ -50,7 +53,7 @@ func TestHandler_GetItem(t *testing.T) {
 			name: "not found",
 			req:  validReq,
 			mockSetup: func() {
-				mockUsecase.EXPECT().GetBySKU(gomock.Any(), uint32(1001)).
+				mockUsecase.EXPECT().GetBySKU(gomock.Any(), uint32(1001), "loc1").
 					Return(models.StockItem{}, errors.New("not found"))
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
@@ -61,15 +64,20 @@ func TestHandler_GetItem(t *testing.T) {
 			name: "success",
 			req:  validReq,
 			mockSetup: func() {
-				mockUsecase.EXPECT().GetBySKU(gomock.Any(), uint32(1001)).
+				mockUsecase.EXPECT().GetBySKU(gomock.Any(), uint32(1001), "loc1").
 					Return(expectedItem, nil)
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 			},
 			expectedResult: &stockspb.StockItem{
-				Sku:      "1001",
-				Location: "loc1",
-				Count:    int32(expectedItem.Count),
-				Price:    float32(expectedItem.Price),
+				Sku:       "1001",
+				Location:  "loc1",
+				Count:     int32(expectedItem.Count),
+				Price:     15.5,
+				UnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 15, Nanos: 500_000_000},
+				Reserved:  3,
+				Available: 7,
+				Name:      "t-shirt",
+				Type:      "clothing",
 			},
 		},
 		{
@@ -77,7 +85,7 @@ func TestHandler_GetItem(t *testing.T) {
 			req:  validReq,
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetBySKU(gomock.Any(), uint32(1001)).
+					GetBySKU(gomock.Any(), uint32(1001), "loc1").
 					Return(models.StockItem{}, errors.New("db error"))
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
@@ -5,12 +5,16 @@ import (
 	"errors"
 	"stocks/internal/delivery"
 	"stocks/internal/models"
+	"stocks/internal/money"
 	"stocks/internal/usecase/mocks"
+	moneypb "stocks/pkg/api/money"
 	stockspb "stocks/pkg/api/stocks"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestHandler_ListByLocation(t *testing.T) {
@@ -34,7 +38,7 @@ func TestHandler_ListByLocation(t *testing.T) {
 			SKU:      1001,
 			Name:     "T-Shirt",
 			Type:     "clothing",
-			Price:    15.0,
+			Price:    money.New("RUB", 1500),
 			Count:    5,
 			Location: "loc1",
 		},
@@ -52,7 +56,7 @@ func TestHandler_ListByLocation(t *testing.T) {
 			req:  validReq,
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					ListByLocation(gomock.Any(), "loc1", gomock.Any(), gomock.Any()).
+					ListByLocation(gomock.Any(), models.LocationFilter{Location: "loc1", Limit: 100}).
 					Return(nil, errors.New("db error"))
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
@@ -64,7 +68,7 @@ func TestHandler_ListByLocation(t *testing.T) {
 			req:  validReq,
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					ListByLocation(gomock.Any(), "loc1", gomock.Any(), gomock.Any()).
+					ListByLocation(gomock.Any(), models.LocationFilter{Location: "loc1", Limit: 100}).
 					Return(expectedItems, nil)
 				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 			},
@@ -99,3 +103,137 @@ func TestHandler_ListByLocation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ListByLocation_Filters(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	firstPage := []models.StockItem{
+		{SKU: 1001, Price: money.New("RUB", 3000), Count: 5, Location: "loc1"},
+		{SKU: 1002, Price: money.New("RUB", 2050), Count: 7, Location: "loc1"},
+	}
+
+	mockUsecase.EXPECT().
+		ListByLocation(gomock.Any(), models.LocationFilter{
+			Location: "loc1",
+			Type:     "apparel",
+			Currency: "RUB",
+			MinPrice: money.New("RUB", 1099),
+			MaxPrice: money.New("RUB", 5000),
+			MinCount: 2,
+			Sort:     models.SortByPriceDesc,
+			Limit:    2,
+		}).
+		Return(firstPage, nil)
+
+	req := &stockspb.ListByLocationRequest{
+		Location:     "loc1",
+		Type:         "apparel",
+		MinUnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 10, Nanos: 990_000_000},
+		MaxUnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 50},
+		MinCount:     2,
+		Sort:         stockspb.StockSort_STOCK_SORT_PRICE_DESC,
+		PageSize:     2,
+	}
+
+	resp, err := server.ListByLocation(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Len(t, resp.Items, 2)
+	assert.NotEmpty(t, resp.NextPageToken)
+
+	mockUsecase.EXPECT().
+		ListByLocation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter models.LocationFilter) ([]models.StockItem, error) {
+			assert.Equal(t, &models.LocationCursor{SKU: 1002, Price: money.New("", 2050)}, filter.After)
+			return firstPage[:1], nil
+		})
+
+	req.PageToken = resp.NextPageToken
+
+	resp, err = server.ListByLocation(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Empty(t, resp.NextPageToken, "a short page is the last one")
+
+	req.Sort = stockspb.StockSort_STOCK_SORT_COUNT_ASC
+
+	_, err = server.ListByLocation(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "token replayed with another sort")
+}
+
+func TestValidateListByLocationRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *stockspb.ListByLocationRequest
+		wantErr string
+	}{
+		{
+			name:    "missing location",
+			req:     &stockspb.ListByLocationRequest{},
+			wantErr: "location must be non-empty",
+		},
+		{
+			name:    "page size too large",
+			req:     &stockspb.ListByLocationRequest{Location: "loc1", PageSize: 1001},
+			wantErr: "page_size must be between 0 and 1000",
+		},
+		{
+			name:    "min price above max price",
+			req:     &stockspb.ListByLocationRequest{Location: "loc1", MinPrice: 20, MaxPrice: 10},
+			wantErr: "min_price must not exceed max_price",
+		},
+		{
+			name: "currencies of the price bounds differ",
+			req: &stockspb.ListByLocationRequest{
+				Location:     "loc1",
+				MinUnitPrice: &moneypb.Money{CurrencyCode: "RUB", Units: 1},
+				MaxUnitPrice: &moneypb.Money{CurrencyCode: "USD", Units: 2},
+			},
+			wantErr: "min_unit_price and max_unit_price must share a currency",
+		},
+		{
+			name:    "price with more than two decimals",
+			req:     &stockspb.ListByLocationRequest{Location: "loc1", MinUnitPrice: &moneypb.Money{Units: 1, Nanos: 1}},
+			wantErr: "invalid min_unit_price: invalid amount: more than two decimals",
+		},
+		{
+			name:    "negative min count",
+			req:     &stockspb.ListByLocationRequest{Location: "loc1", MinCount: -1},
+			wantErr: "min_count must be between 0 and 65535",
+		},
+		{
+			name:    "unknown sort",
+			req:     &stockspb.ListByLocationRequest{Location: "loc1", Sort: 42},
+			wantErr: "invalid sort",
+		},
+		{
+			name:    "garbage page token",
+			req:     &stockspb.ListByLocationRequest{Location: "loc1", PageToken: "not-a-token"},
+			wantErr: "invalid page_token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := delivery.ValidateListByLocationRequest(tt.req)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
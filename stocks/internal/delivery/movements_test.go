@@ -0,0 +1,180 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/delivery"
+	"stocks/internal/models"
+	"stocks/internal/usecase/mocks"
+	stockspb "stocks/pkg/api/stocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ListMovements(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+
+	movements := []models.StockMovement{
+		{
+			ID:        9,
+			SKU:       1001,
+			Location:  "loc1",
+			Delta:     -2,
+			Reason:    models.MovementReasonSale,
+			UserID:    7,
+			TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			CreatedAt: from.Add(time.Hour),
+		},
+		{
+			ID:        4,
+			SKU:       1001,
+			Location:  "loc1",
+			Delta:     10,
+			Reason:    models.MovementReasonRestock,
+			UserID:    1,
+			CreatedAt: from,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		req            *stockspb.ListMovementsRequest
+		mockSetup      func()
+		expectedResult *stockspb.ListMovementsResponse
+		expectedErr    string
+	}{
+		{
+			name: "full page returns next token",
+			req: &stockspb.ListMovementsRequest{
+				Sku:       "1001",
+				Location:  "loc1",
+				From:      "2026-10-01T00:00:00Z",
+				To:        "2026-10-02T00:00:00Z",
+				PageSize:  2,
+				PageToken: "15",
+			},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().ListMovements(gomock.Any(), models.MovementFilter{
+					SKU:      1001,
+					Location: "loc1",
+					From:     from,
+					To:       to,
+					BeforeID: 15,
+					Limit:    2,
+				}).Return(movements, nil)
+			},
+			expectedResult: &stockspb.ListMovementsResponse{
+				Movements: []*stockspb.StockMovement{
+					{
+						MovementId: "9",
+						Sku:        "1001",
+						Location:   "loc1",
+						Delta:      -2,
+						Reason:     "sale",
+						UserId:     7,
+						TraceId:    "4bf92f3577b34da6a3ce929d0e0e4736",
+						CreatedAt:  "2026-10-01T01:00:00Z",
+					},
+					{
+						MovementId: "4",
+						Sku:        "1001",
+						Location:   "loc1",
+						Delta:      10,
+						Reason:     "restock",
+						UserId:     1,
+						CreatedAt:  "2026-10-01T00:00:00Z",
+					},
+				},
+				NextPageToken: "4",
+			},
+		},
+		{
+			name: "last page has no token",
+			req:  &stockspb.ListMovementsRequest{UserId: 7},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().ListMovements(gomock.Any(), models.MovementFilter{
+					UserID: 7,
+					Limit:  100,
+				}).Return(movements[:1], nil)
+			},
+			expectedResult: &stockspb.ListMovementsResponse{
+				Movements: []*stockspb.StockMovement{
+					{
+						MovementId: "9",
+						Sku:        "1001",
+						Location:   "loc1",
+						Delta:      -2,
+						Reason:     "sale",
+						UserId:     7,
+						TraceId:    "4bf92f3577b34da6a3ce929d0e0e4736",
+						CreatedAt:  "2026-10-01T01:00:00Z",
+					},
+				},
+			},
+		},
+		{
+			name: "inverted time range",
+			req: &stockspb.ListMovementsRequest{
+				From: "2026-10-02T00:00:00Z",
+				To:   "2026-10-01T00:00:00Z",
+			},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "from must be before to",
+		},
+		{
+			name: "invalid page token",
+			req:  &stockspb.ListMovementsRequest{PageToken: "abc"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "invalid page_token",
+		},
+		{
+			name: "internal error",
+			req:  &stockspb.ListMovementsRequest{Location: "loc2"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().ListMovements(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedErr: "failed to list movements",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.ListMovements(context.Background(), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
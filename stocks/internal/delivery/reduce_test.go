@@ -0,0 +1,135 @@
+package delivery_test
+
+import (
+	"context"
+	"fmt"
+	"stocks/internal/delivery"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+	"stocks/internal/usecase/mocks"
+	stockspb "stocks/pkg/api/stocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ReduceStock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	validReq := &stockspb.ReduceStockRequest{
+		Items: []*stockspb.ReduceStockItem{
+			{Sku: "1001", Count: 2},
+			{Sku: "2020", Count: 1},
+		},
+	}
+
+	expectedItems := []models.StockItem{
+		{SKU: 1001, Count: 2, UserID: 1},
+		{SKU: 2020, Count: 1, UserID: 1},
+	}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		req            *stockspb.ReduceStockRequest
+		mockSetup      func()
+		expectedResult *stockspb.StockResponse
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Reduce(gomock.Any(), expectedItems).Return(nil)
+			},
+			expectedResult: &stockspb.StockResponse{Message: "Stock reduced successfully"},
+		},
+		{
+			name: "empty items",
+			req:  &stockspb.ReduceStockRequest{},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "items must be non-empty",
+		},
+		{
+			name: "not enough stock",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().Reduce(gomock.Any(), expectedItems).Return(errors.ErrNotEnoughStock)
+			},
+			expectedErr: "not enough stock available",
+		},
+		{
+			name: "buyer is denied",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().Reduce(gomock.Any(), expectedItems).
+					Return(fmt.Errorf("%w: buyers have read-only access to stock", errors.ErrPermissionDenied))
+			},
+			expectedErr: "PermissionDenied",
+		},
+		{
+			name: "service reduces on behalf of the buyer",
+			ctx:  serviceContext(99),
+			req: &stockspb.ReduceStockRequest{
+				Items:  validReq.GetItems(),
+				UserId: 1,
+			},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Reduce(gomock.Any(), expectedItems).Return(nil)
+			},
+			expectedResult: &stockspb.StockResponse{Message: "Stock reduced successfully"},
+		},
+		{
+			name: "buyers cannot reduce on behalf of others",
+			req: &stockspb.ReduceStockRequest{
+				Items:  validReq.GetItems(),
+				UserId: 2,
+			},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Reduce(gomock.Any(), expectedItems).Return(nil)
+			},
+			expectedResult: &stockspb.StockResponse{Message: "Stock reduced successfully"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = userContext(1)
+			}
+
+			resp, err := server.ReduceStock(ctx, tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
@@ -1,14 +1,52 @@
 package delivery
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
+	"time"
 
+	"stocks/internal/auth"
 	"stocks/internal/models"
+	"stocks/internal/money"
+	moneypb "stocks/pkg/api/money"
 	stockpb "stocks/pkg/api/stocks"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// UserIDFromContext returns the authenticated caller. The user_id fields of
+// the write requests are ignored: the caller always acts as itself.
+func UserIDFromContext(ctx context.Context) (int64, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	return identity.UserID, nil
+}
+
+// ActingUserID returns the user a call is made for: the caller, or the user
+// named by onBehalfOf when a service calls on their behalf.
+func ActingUserID(ctx context.Context, onBehalfOf uint64) (int64, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	if identity.HasRole(auth.RoleService) && onBehalfOf != 0 && onBehalfOf <= math.MaxInt64 {
+		return int64(onBehalfOf), nil
+	}
+
+	return identity.UserID, nil
+}
+
 func ParseSKU(sku string) (uint32, error) {
 	if sku == "" {
 		return 0, errors.New("sku must be non-empty")
@@ -22,7 +60,279 @@ func ParseSKU(sku string) (uint32, error) {
 	return uint32(skuUint64), nil
 }
 
-func ValidateAddItemRequest(req *stockpb.AddItemRequest) (models.StockItem, error) {
+func ValidateGetItemsRequest(req *stockpb.GetItemsRequest) ([]uint32, error) {
+	if len(req.GetSkus()) == 0 {
+		return nil, errors.New("skus must be non-empty")
+	}
+
+	if len(req.GetSkus()) > maxBatchSize {
+		return nil, fmt.Errorf("at most %d skus can be requested at once", maxBatchSize)
+	}
+
+	seen := make(map[uint32]struct{}, len(req.GetSkus()))
+	skus := make([]uint32, 0, len(req.GetSkus()))
+
+	for _, rawSKU := range req.GetSkus() {
+		sku, err := ParseSKU(rawSKU)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[sku]; ok {
+			continue
+		}
+
+		seen[sku] = struct{}{}
+		skus = append(skus, sku)
+	}
+
+	return skus, nil
+}
+
+func ValidateListMovementsRequest(req *stockpb.ListMovementsRequest) (models.MovementFilter, error) {
+	filter := models.MovementFilter{
+		Location: req.GetLocation(),
+		UserID:   int64(req.GetUserId()),
+		Limit:    defaultMovementsPageSize,
+	}
+
+	if req.GetSku() != "" {
+		sku, err := ParseSKU(req.GetSku())
+		if err != nil {
+			return models.MovementFilter{}, err
+		}
+
+		filter.SKU = sku
+	}
+
+	var err error
+
+	if req.GetFrom() != "" {
+		filter.From, err = time.Parse(time.RFC3339, req.GetFrom())
+		if err != nil {
+			return models.MovementFilter{}, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+
+	if req.GetTo() != "" {
+		filter.To, err = time.Parse(time.RFC3339, req.GetTo())
+		if err != nil {
+			return models.MovementFilter{}, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return models.MovementFilter{}, errors.New("from must be before to")
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxMovementsPageSize {
+		return models.MovementFilter{}, fmt.Errorf("page_size must be between 0 and %d", maxMovementsPageSize)
+	}
+
+	if req.GetPageSize() > 0 {
+		filter.Limit = int64(req.GetPageSize())
+	}
+
+	if req.GetPageToken() != "" {
+		filter.BeforeID, err = strconv.ParseInt(req.GetPageToken(), 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			return models.MovementFilter{}, errors.New("invalid page_token")
+		}
+	}
+
+	return filter, nil
+}
+
+var stockSorts = map[stockpb.StockSort]models.StockSort{
+	stockpb.StockSort_STOCK_SORT_UNSPECIFIED: models.SortBySKU,
+	stockpb.StockSort_STOCK_SORT_SKU_ASC:     models.SortBySKU,
+	stockpb.StockSort_STOCK_SORT_SKU_DESC:    models.SortBySKUDesc,
+	stockpb.StockSort_STOCK_SORT_PRICE_ASC:   models.SortByPrice,
+	stockpb.StockSort_STOCK_SORT_PRICE_DESC:  models.SortByPriceDesc,
+	stockpb.StockSort_STOCK_SORT_COUNT_ASC:   models.SortByCount,
+	stockpb.StockSort_STOCK_SORT_COUNT_DESC:  models.SortByCountDesc,
+}
+
+// locationPageToken is the JSON behind a ListByLocation page token. It keeps
+// the sort order so a token cannot be replayed against another order.
+type locationPageToken struct {
+	Sort  models.StockSort `json:"o"`
+	SKU   uint32           `json:"s"`
+	Price int64            `json:"p,omitempty"`
+	Count uint16           `json:"c,omitempty"`
+}
+
+func ValidateListByLocationRequest(req *stockpb.ListByLocationRequest) (models.LocationFilter, error) {
+	filter := models.LocationFilter{
+		Location: req.GetLocation(),
+		Type:     strings.TrimSpace(req.GetType()),
+		Limit:    defaultPageSize,
+	}
+
+	if filter.Location == "" {
+		return models.LocationFilter{}, errors.New("location must be non-empty")
+	}
+
+	var err error
+
+	filter.MinPrice, err = requestPrice(req.GetMinUnitPrice(), req.GetMinPrice())
+	if err != nil {
+		return models.LocationFilter{}, fmt.Errorf("invalid min_unit_price: %w", err)
+	}
+
+	filter.MaxPrice, err = requestPrice(req.GetMaxUnitPrice(), req.GetMaxPrice())
+	if err != nil {
+		return models.LocationFilter{}, fmt.Errorf("invalid max_unit_price: %w", err)
+	}
+
+	if filter.MinPrice.Cents < 0 || filter.MaxPrice.Cents < 0 {
+		return models.LocationFilter{}, errors.New("min_price and max_price must not be negative")
+	}
+
+	if filter.MaxPrice.Cents > 0 && filter.MinPrice.Cents > filter.MaxPrice.Cents {
+		return models.LocationFilter{}, errors.New("min_price must not exceed max_price")
+	}
+
+	filter.Currency = filter.MinPrice.Currency
+	if filter.Currency == "" {
+		filter.Currency = filter.MaxPrice.Currency
+	}
+
+	if filter.MaxPrice.Currency != "" && filter.MaxPrice.Currency != filter.Currency {
+		return models.LocationFilter{}, errors.New("min_unit_price and max_unit_price must share a currency")
+	}
+
+	if req.GetMinCount() < 0 || req.GetMinCount() > math.MaxUint16 {
+		return models.LocationFilter{}, fmt.Errorf("min_count must be between 0 and %d", math.MaxUint16)
+	}
+
+	filter.MinCount = uint16(req.GetMinCount())
+
+	order, ok := stockSorts[req.GetSort()]
+	if !ok {
+		return models.LocationFilter{}, errors.New("invalid sort")
+	}
+
+	filter.Sort = order
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxPageSize {
+		return models.LocationFilter{}, fmt.Errorf("page_size must be between 0 and %d", maxPageSize)
+	}
+
+	if req.GetPageSize() > 0 {
+		filter.Limit = int64(req.GetPageSize())
+	}
+
+	if req.GetPageToken() != "" {
+		cursor, err := decodeLocationPageToken(req.GetPageToken(), filter.Sort)
+		if err != nil {
+			return models.LocationFilter{}, err
+		}
+
+		filter.After = &cursor
+	}
+
+	return filter, nil
+}
+
+func LocationItemsToProto(filter models.LocationFilter, items []models.StockItem) *stockpb.ListByLocationResponse {
+	resp := &stockpb.ListByLocationResponse{
+		Location: filter.Location,
+		Items:    ToProtoList(items),
+	}
+
+	if int64(len(items)) == filter.Limit && len(items) > 0 {
+		last := items[len(items)-1]
+		resp.NextPageToken = encodeLocationPageToken(filter.Sort, models.LocationCursor{
+			SKU:   last.SKU,
+			Price: last.Price,
+			Count: last.Count,
+		})
+	}
+
+	return resp
+}
+
+func encodeLocationPageToken(sort models.StockSort, cursor models.LocationCursor) string {
+	token := locationPageToken{Sort: sort, SKU: cursor.SKU}
+
+	switch sort {
+	case models.SortByPrice, models.SortByPriceDesc:
+		token.Price = cursor.Price.Cents
+	case models.SortByCount, models.SortByCountDesc:
+		token.Count = cursor.Count
+	}
+
+	data, _ := json.Marshal(token)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeLocationPageToken(value string, sort models.StockSort) (models.LocationCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return models.LocationCursor{}, errors.New("invalid page_token")
+	}
+
+	var token locationPageToken
+	if err := json.Unmarshal(data, &token); err != nil || token.SKU == 0 {
+		return models.LocationCursor{}, errors.New("invalid page_token")
+	}
+
+	if token.Sort != sort {
+		return models.LocationCursor{}, errors.New("page_token was issued for another sort")
+	}
+
+	return models.LocationCursor{SKU: token.SKU, Price: money.New("", token.Price), Count: token.Count}, nil
+}
+
+// requestPrice reads a price from its Money field, or from the deprecated
+// float field when the Money one is not set. A float price has no currency.
+func requestPrice(price *moneypb.Money, legacy float32) (money.Money, error) {
+	if price == nil {
+		return money.FromFloat("", float64(legacy)), nil
+	}
+
+	m, err := money.FromProto(price)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	if m.Currency != "" {
+		if err := money.ValidateCurrency(m.Currency); err != nil {
+			return money.Money{}, err
+		}
+	}
+
+	return m, nil
+}
+
+func MovementsToProto(movements []models.StockMovement, limit int64) *stockpb.ListMovementsResponse {
+	resp := &stockpb.ListMovementsResponse{
+		Movements: make([]*stockpb.StockMovement, 0, len(movements)),
+	}
+
+	for _, movement := range movements {
+		resp.Movements = append(resp.Movements, &stockpb.StockMovement{
+			MovementId: strconv.FormatInt(movement.ID, 10),
+			Sku:        strconv.FormatUint(uint64(movement.SKU), 10),
+			Location:   movement.Location,
+			Delta:      movement.Delta,
+			Reason:     string(movement.Reason),
+			UserId:     uint64(movement.UserID),
+			TraceId:    movement.TraceID,
+			CreatedAt:  movement.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	if int64(len(movements)) == limit && len(movements) > 0 {
+		resp.NextPageToken = strconv.FormatInt(movements[len(movements)-1].ID, 10)
+	}
+
+	return resp
+}
+
+func ValidateAddItemRequest(userID int64, req *stockpb.AddItemRequest) (models.StockItem, error) {
 	if req.GetLocation() == "" {
 		return models.StockItem{}, errors.New("location must be non-empty")
 	}
@@ -36,21 +346,170 @@ func ValidateAddItemRequest(req *stockpb.AddItemRequest) (models.StockItem, erro
 		return models.StockItem{}, errors.New("count must be greater than zero")
 	}
 
+	price, err := requestPrice(req.GetUnitPrice(), req.GetPrice())
+	if err != nil {
+		return models.StockItem{}, fmt.Errorf("invalid unit_price: %w", err)
+	}
+
+	if price.Cents < 0 {
+		return models.StockItem{}, errors.New("price must not be negative")
+	}
+
 	return models.StockItem{
 		SKU:      sku,
 		Location: req.GetLocation(),
 		Count:    uint16(req.GetCount()),
-		Price:    float64(req.GetPrice()),
-		UserID:   int64(req.GetUserId()),
+		Price:    price,
+		UserID:   userID,
+	}, nil
+}
+
+func ValidateReduceStockRequest(userID int64, req *stockpb.ReduceStockRequest) ([]models.StockItem, error) {
+	if len(req.GetItems()) == 0 {
+		return nil, errors.New("items must be non-empty")
+	}
+
+	items := make([]models.StockItem, 0, len(req.GetItems()))
+	for _, reqItem := range req.GetItems() {
+		sku, err := ParseSKU(reqItem.GetSku())
+		if err != nil {
+			return nil, err
+		}
+
+		if reqItem.GetCount() <= 0 || reqItem.GetCount() > math.MaxUint16 {
+			return nil, fmt.Errorf("count for sku %d must be between 1 and %d", sku, math.MaxUint16)
+		}
+
+		items = append(items, models.StockItem{
+			SKU:    sku,
+			Count:  uint16(reqItem.GetCount()),
+			UserID: userID,
+		})
+	}
+
+	return items, nil
+}
+
+func ParseReservationID(reservationID string) (int64, error) {
+	if reservationID == "" {
+		return 0, errors.New("reservation_id must be non-empty")
+	}
+
+	id, err := strconv.ParseInt(reservationID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid reservation_id format: %s", reservationID)
+	}
+
+	return id, nil
+}
+
+func ValidateReserveItemsRequest(userID int64, req *stockpb.ReserveItemsRequest) (models.Reservation, error) {
+	if len(req.GetItems()) == 0 {
+		return models.Reservation{}, errors.New("items must be non-empty")
+	}
+
+	ttl := defaultReservationTTL
+	if req.GetTtlSeconds() < 0 {
+		return models.Reservation{}, errors.New("ttl_seconds must not be negative")
+	}
+
+	if req.GetTtlSeconds() > 0 {
+		ttl = time.Duration(req.GetTtlSeconds()) * time.Second
+	}
+
+	if ttl > maxReservationTTL {
+		return models.Reservation{}, fmt.Errorf("ttl_seconds must not exceed %d", int64(maxReservationTTL.Seconds()))
+	}
+
+	seen := make(map[uint32]struct{}, len(req.GetItems()))
+	items := make([]models.ReservationItem, 0, len(req.GetItems()))
+
+	for _, reqItem := range req.GetItems() {
+		sku, err := ParseSKU(reqItem.GetSku())
+		if err != nil {
+			return models.Reservation{}, err
+		}
+
+		if _, ok := seen[sku]; ok {
+			return models.Reservation{}, fmt.Errorf("duplicate sku %d", sku)
+		}
+
+		seen[sku] = struct{}{}
+
+		if reqItem.GetCount() <= 0 || reqItem.GetCount() > math.MaxUint16 {
+			return models.Reservation{}, fmt.Errorf("count for sku %d must be between 1 and %d", sku, math.MaxUint16)
+		}
+
+		items = append(items, models.ReservationItem{
+			SKU:   sku,
+			Count: uint16(reqItem.GetCount()),
+		})
+	}
+
+	return models.Reservation{
+		UserID:    userID,
+		Items:     items,
+		ExpiresAt: time.Now().Add(ttl),
 	}, nil
 }
 
+func ReservationToProto(reservation models.Reservation) *stockpb.Reservation {
+	items := make([]*stockpb.ReservationItem, 0, len(reservation.Items))
+	for _, item := range reservation.Items {
+		items = append(items, &stockpb.ReservationItem{
+			Sku:   strconv.FormatUint(uint64(item.SKU), 10),
+			Count: int32(item.Count),
+		})
+	}
+
+	return &stockpb.Reservation{
+		ReservationId: strconv.FormatInt(reservation.ID, 10),
+		UserId:        uint64(reservation.UserID),
+		Status:        string(reservation.Status),
+		Items:         items,
+		ExpiresAt:     reservation.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
+
 func ToProto(item models.StockItem) *stockpb.StockItem {
 	return &stockpb.StockItem{
-		Sku:      strconv.FormatUint(uint64(item.SKU), 10),
-		Location: item.Location,
-		Count:    int32(item.Count),
+		Sku:       strconv.FormatUint(uint64(item.SKU), 10),
+		Location:  item.Location,
+		Count:     int32(item.Count),
+		Price:     float32(item.Price.Float64()),
+		UnitPrice: money.ToProto(item.Price),
+		Reserved:  int32(item.Reserved),
+		Available: int32(item.Available()),
+		Name:      item.Name,
+		Type:      item.Type,
+		Archived:  item.Archived,
+	}
+}
+
+// ToGetItemsResponse keeps the order of the requested SKUs and reports the
+// ones missing from items as not found.
+func ToGetItemsResponse(skus []uint32, items []models.StockItem) *stockpb.GetItemsResponse {
+	found := make(map[uint32]models.StockItem, len(items))
+	for _, item := range items {
+		found[item.SKU] = item
 	}
+
+	results := make([]*stockpb.GetItemsResult, 0, len(skus))
+	for _, sku := range skus {
+		result := &stockpb.GetItemsResult{
+			Sku:    strconv.FormatUint(uint64(sku), 10),
+			Status: stockpb.ItemStatus_ITEM_STATUS_NOT_FOUND,
+		}
+
+		if item, ok := found[sku]; ok {
+			result.Status = stockpb.ItemStatus_ITEM_STATUS_FOUND
+			result.Item = ToProto(item)
+		}
+
+		results = append(results, result)
+	}
+
+	return &stockpb.GetItemsResponse{Results: results}
 }
 
 func ToProtoList(items []models.StockItem) []*stockpb.StockItem {
@@ -60,3 +519,115 @@ func ToProtoList(items []models.StockItem) []*stockpb.StockItem {
 	}
 	return pbItems
 }
+
+func ValidateCreateSKURequest(req *stockpb.CreateSKURequest) (models.SKU, error) {
+	sku, err := ParseSKU(req.GetSku())
+	if err != nil {
+		return models.SKU{}, err
+	}
+
+	if sku == 0 {
+		return models.SKU{}, errors.New("sku must be positive")
+	}
+
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return models.SKU{}, errors.New("name must be non-empty")
+	}
+
+	typ := strings.TrimSpace(req.GetType())
+	if typ == "" {
+		return models.SKU{}, errors.New("type must be non-empty")
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(req.GetCurrencyCode()))
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	if err := money.ValidateCurrency(currency); err != nil {
+		return models.SKU{}, err
+	}
+
+	return models.SKU{SKU: sku, Name: name, Type: typ, Currency: currency}, nil
+}
+
+func ValidateUpdateSKURequest(req *stockpb.UpdateSKURequest) (models.SKU, error) {
+	sku, err := ParseSKU(req.GetSku())
+	if err != nil {
+		return models.SKU{}, err
+	}
+
+	update := models.SKU{
+		SKU:  sku,
+		Name: strings.TrimSpace(req.GetName()),
+		Type: strings.TrimSpace(req.GetType()),
+	}
+
+	if update.Name == "" && update.Type == "" {
+		return models.SKU{}, errors.New("name or type must be set")
+	}
+
+	return update, nil
+}
+
+func ValidateListSKUsRequest(req *stockpb.ListSKUsRequest) (models.SKUFilter, error) {
+	filter := models.SKUFilter{
+		Type:            strings.TrimSpace(req.GetType()),
+		IncludeArchived: req.GetIncludeArchived(),
+		Limit:           defaultSKUsPageSize,
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxSKUsPageSize {
+		return models.SKUFilter{}, fmt.Errorf("page_size must be between 0 and %d", maxSKUsPageSize)
+	}
+
+	if req.GetPageSize() > 0 {
+		filter.Limit = int64(req.GetPageSize())
+	}
+
+	if req.GetPageToken() != "" {
+		after, err := ParseSKU(req.GetPageToken())
+		if err != nil {
+			return models.SKUFilter{}, errors.New("invalid page_token")
+		}
+
+		filter.AfterSKU = after
+	}
+
+	return filter, nil
+}
+
+func SKUToProto(sku models.SKU) *stockpb.SKU {
+	resp := &stockpb.SKU{
+		Sku:          strconv.FormatUint(uint64(sku.SKU), 10),
+		Name:         sku.Name,
+		Type:         sku.Type,
+		CurrencyCode: sku.Currency,
+		Archived:     sku.Archived(),
+		CreatedAt:    sku.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:    sku.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+
+	if sku.Archived() {
+		resp.ArchivedAt = sku.ArchivedAt.UTC().Format(time.RFC3339)
+	}
+
+	return resp
+}
+
+func SKUsToProto(skus []models.SKU, limit int64) *stockpb.ListSKUsResponse {
+	resp := &stockpb.ListSKUsResponse{
+		Skus: make([]*stockpb.SKU, 0, len(skus)),
+	}
+
+	for _, sku := range skus {
+		resp.Skus = append(resp.Skus, SKUToProto(sku))
+	}
+
+	if int64(len(skus)) == limit && len(skus) > 0 {
+		resp.NextPageToken = strconv.FormatUint(uint64(skus[len(skus)-1].SKU), 10)
+	}
+
+	return resp
+}
@@ -0,0 +1,266 @@
+package delivery_test
+
+import (
+	"context"
+	"stocks/internal/delivery"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+	"stocks/internal/usecase/mocks"
+	stockspb "stocks/pkg/api/stocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ReserveItems(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	validReq := &stockspb.ReserveItemsRequest{
+		UserId:     7,
+		TtlSeconds: 60,
+		Items: []*stockspb.ReservationItem{
+			{Sku: "1001", Count: 2},
+		},
+	}
+
+	expiresAt := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	matchReservation := gomock.AssignableToTypeOf(models.Reservation{})
+
+	tests := []struct {
+		name           string
+		req            *stockspb.ReserveItemsRequest
+		mockSetup      func()
+		expectedResult *stockspb.Reservation
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Reserve(gomock.Any(), matchReservation).
+					DoAndReturn(func(_ context.Context, r models.Reservation) (models.Reservation, error) {
+						r.ID = 42
+						r.Status = models.ReservationStatusActive
+						r.ExpiresAt = expiresAt
+
+						return r, nil
+					})
+			},
+			expectedResult: &stockspb.Reservation{
+				ReservationId: "42",
+				UserId:        7,
+				Status:        "active",
+				Items:         []*stockspb.ReservationItem{{Sku: "1001", Count: 2}},
+				ExpiresAt:     "2026-10-17T12:00:00Z",
+			},
+		},
+		{
+			name: "empty items",
+			req:  &stockspb.ReserveItemsRequest{},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "items must be non-empty",
+		},
+		{
+			name: "duplicate sku",
+			req: &stockspb.ReserveItemsRequest{
+				Items: []*stockspb.ReservationItem{
+					{Sku: "1001", Count: 1},
+					{Sku: "1001", Count: 2},
+				},
+			},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "duplicate sku 1001",
+		},
+		{
+			name: "ttl too long",
+			req: &stockspb.ReserveItemsRequest{
+				TtlSeconds: 7 * 24 * 3600,
+				Items:      []*stockspb.ReservationItem{{Sku: "1001", Count: 1}},
+			},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "ttl_seconds must not exceed",
+		},
+		{
+			name: "not enough stock",
+			req:  validReq,
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().Reserve(gomock.Any(), matchReservation).
+					Return(models.Reservation{}, errors.ErrNotEnoughStock)
+			},
+			expectedErr: "not enough stock available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.ReserveItems(userContext(7), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_ReleaseReservation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	tests := []struct {
+		name           string
+		req            *stockspb.ReservationRequest
+		mockSetup      func()
+		expectedResult *stockspb.StockResponse
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			req:  &stockspb.ReservationRequest{ReservationId: "42"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Release(gomock.Any(), int64(42)).Return(nil)
+			},
+			expectedResult: &stockspb.StockResponse{Message: "Reservation released successfully"},
+		},
+		{
+			name: "invalid id",
+			req:  &stockspb.ReservationRequest{ReservationId: "abc"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+			},
+			expectedErr: "invalid reservation_id format",
+		},
+		{
+			name: "not found",
+			req:  &stockspb.ReservationRequest{ReservationId: "42"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().Release(gomock.Any(), int64(42)).Return(errors.ErrReservationNotFound)
+			},
+			expectedErr: "reservation not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.ReleaseReservation(context.Background(), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_CommitReservation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockStockUseCase(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	server := delivery.NewStockServer(mockUsecase, mockLogger)
+
+	tests := []struct {
+		name           string
+		req            *stockspb.ReservationRequest
+		mockSetup      func()
+		expectedResult *stockspb.StockResponse
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			req:  &stockspb.ReservationRequest{ReservationId: "42"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockUsecase.EXPECT().Commit(gomock.Any(), int64(42)).Return(nil)
+			},
+			expectedResult: &stockspb.StockResponse{Message: "Reservation committed successfully"},
+		},
+		{
+			name: "expired",
+			req:  &stockspb.ReservationRequest{ReservationId: "42"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().Commit(gomock.Any(), int64(42)).Return(errors.ErrReservationExpired)
+			},
+			expectedErr: "reservation has expired",
+		},
+		{
+			name: "internal error",
+			req:  &stockspb.ReservationRequest{ReservationId: "42"},
+			mockSetup: func() {
+				mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+				mockUsecase.EXPECT().Commit(gomock.Any(), int64(42)).Return(assert.AnError)
+			},
+			expectedErr: "failed to commit reservation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := server.CommitReservation(context.Background(), tt.req)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, resp)
+			}
+		})
+	}
+}
@@ -2,8 +2,8 @@ package delivery
 
 import (
 	"context"
-	"fmt"
-	"strconv"
+	stdErrors "errors"
+	"time"
 
 	"stocks/internal/errors"
 	"stocks/internal/log"
@@ -16,8 +16,18 @@ import (
 )
 
 const (
-	defaultPageSize   = 100
-	defaultPageNumber = 1
+	defaultPageSize = 100
+	maxPageSize     = 1000
+	maxBatchSize    = 500
+
+	defaultMovementsPageSize = 100
+	maxMovementsPageSize     = 1000
+
+	defaultReservationTTL = 15 * time.Minute
+	maxReservationTTL     = 24 * time.Hour
+
+	defaultSKUsPageSize = 100
+	maxSKUsPageSize     = 1000
 )
 
 type StockServer struct {
@@ -38,13 +48,19 @@ func (s *StockServer) AddItem(ctx context.Context, req *stockpb.AddItemRequest)
 	ctx, span := tr.Start(ctx, "AddItem")
 	defer span.End()
 
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("AddItem called",
+		log.Int64("user_id", userID),
 		log.String("sku", req.GetSku()),
 		log.Int32("count", req.GetCount()),
 		log.String("location", req.GetLocation()),
 	)
 
-	item, err := ValidateAddItemRequest(req)
+	item, err := ValidateAddItemRequest(userID, req)
 	if err != nil {
 		s.logger.Error("Invalid AddItem request", log.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -52,15 +68,24 @@ func (s *StockServer) AddItem(ctx context.Context, req *stockpb.AddItemRequest)
 
 	err = s.usecase.Add(ctx, item)
 	if err != nil {
+		if denied := s.permissionDenied("AddItem", err); denied != nil {
+			return nil, denied
+		}
+
 		fields := []log.Field{log.Error(err)}
 
+		if stdErrors.Is(err, errors.ErrCurrencyMismatch) {
+			s.logger.Error("AddItem error: currency mismatch", fields...)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
 		switch err {
 		case errors.ErrInvalidSKU:
 			s.logger.Error("AddItem error: invalid SKU", fields...)
 			return nil, status.Error(codes.InvalidArgument, err.Error())
-		case errors.ErrOwnershipViolation:
-			s.logger.Error("AddItem error: ownership violation", fields...)
-			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.ErrSKUArchived:
+			s.logger.Error("AddItem error: archived SKU", fields...)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
 		default:
 			s.logger.Error("AddItem error: internal", fields...)
 			return nil, status.Error(codes.Internal, err.Error())
@@ -80,7 +105,16 @@ func (s *StockServer) DeleteItem(ctx context.Context, req *stockpb.DeleteItemReq
 	ctx, span := tr.Start(ctx, "DeleteItem")
 	defer span.End()
 
-	s.logger.Info("DeleteItem called", log.String("sku", req.GetSku()))
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("DeleteItem called",
+		log.Int64("user_id", userID),
+		log.String("sku", req.GetSku()),
+		log.String("location", req.GetLocation()),
+	)
 
 	sku, err := ParseSKU(req.GetSku())
 	if err != nil {
@@ -88,9 +122,18 @@ func (s *StockServer) DeleteItem(ctx context.Context, req *stockpb.DeleteItemReq
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = s.usecase.Delete(ctx, sku)
+	err = s.usecase.Delete(ctx, userID, sku, req.GetLocation())
 	if err != nil {
+		if denied := s.permissionDenied("DeleteItem", err); denied != nil {
+			return nil, denied
+		}
+
 		s.logger.Error("DeleteItem failed", log.Error(err))
+
+		if err == errors.ErrItemNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
 		return nil, status.Error(codes.Internal, "failed to delete item")
 	}
 
@@ -104,7 +147,10 @@ func (s *StockServer) GetItem(ctx context.Context, req *stockpb.GetItemRequest)
 	ctx, span := tr.Start(ctx, "GetItem")
 	defer span.End()
 
-	s.logger.Info("GetItem called", log.String("sku", req.GetSku()))
+	s.logger.Info("GetItem called",
+		log.String("sku", req.GetSku()),
+		log.String("location", req.GetLocation()),
+	)
 
 	sku, err := ParseSKU(req.GetSku())
 	if err != nil {
@@ -112,7 +158,7 @@ func (s *StockServer) GetItem(ctx context.Context, req *stockpb.GetItemRequest)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	item, err := s.usecase.GetBySKU(ctx, sku)
+	item, err := s.usecase.GetBySKU(ctx, sku, req.GetLocation())
 	if err != nil {
 		s.logger.Error("GetItem failed to get item", log.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -122,15 +168,37 @@ func (s *StockServer) GetItem(ctx context.Context, req *stockpb.GetItemRequest)
 		log.String("sku", req.GetSku()),
 		log.String("location", item.Location),
 		log.Int("count", int(item.Count)),
-		log.Float32("price", float32(item.Price)),
+		log.String("price", item.Price.String()),
+	)
+
+	return ToProto(item), nil
+}
+
+func (s *StockServer) GetItems(ctx context.Context, req *stockpb.GetItemsRequest) (*stockpb.GetItemsResponse, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "GetItems")
+	defer span.End()
+
+	s.logger.Info("GetItems called", log.Int("skus_count", len(req.GetSkus())))
+
+	skus, err := ValidateGetItemsRequest(req)
+	if err != nil {
+		s.logger.Error("Invalid GetItems request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	items, err := s.usecase.GetBySKUs(ctx, skus)
+	if err != nil {
+		s.logger.Error("GetItems failed", log.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get items")
+	}
+
+	s.logger.Info("GetItems succeeded",
+		log.Int("requested", len(skus)),
+		log.Int("found", len(items)),
 	)
 
-	return &stockpb.StockItem{
-		Sku:      strconv.FormatUint(uint64(item.SKU), 10),
-		Location: item.Location,
-		Count:    int32(item.Count),
-		Price:    float32(item.Price),
-	}, nil
+	return ToGetItemsResponse(skus, items), nil
 }
 
 func (s *StockServer) ListByLocation(ctx context.Context, req *stockpb.ListByLocationRequest) (*stockpb.ListByLocationResponse, error) {
@@ -138,15 +206,18 @@ func (s *StockServer) ListByLocation(ctx context.Context, req *stockpb.ListByLoc
 	ctx, span := tr.Start(ctx, "ListByLocation")
 	defer span.End()
 
-	s.logger.Info("ListByLocation called", log.String("location", req.GetLocation()))
+	s.logger.Info("ListByLocation called",
+		log.String("location", req.GetLocation()),
+		log.String("page_token", req.GetPageToken()),
+	)
 
-	if req.GetLocation() == "" {
-		err := fmt.Errorf("location must be non-empty")
-		s.logger.Error("ListByLocation invalid location", log.Error(err))
-		return nil, status.Error(codes.InvalidArgument, "location must be non-empty")
+	filter, err := ValidateListByLocationRequest(req)
+	if err != nil {
+		s.logger.Error("Invalid ListByLocation request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	items, err := s.usecase.ListByLocation(ctx, req.GetLocation(), defaultPageSize, defaultPageNumber)
+	items, err := s.usecase.ListByLocation(ctx, filter)
 	if err != nil {
 		s.logger.Error("ListByLocation failed", log.Error(err))
 		return nil, status.Error(codes.Internal, "failed to list items")
@@ -154,8 +225,209 @@ func (s *StockServer) ListByLocation(ctx context.Context, req *stockpb.ListByLoc
 
 	s.logger.Info("ListByLocation succeeded", log.String("location", req.GetLocation()), log.Int("items_count", len(items)))
 
-	return &stockpb.ListByLocationResponse{
-		Location: req.GetLocation(),
-		Items:    ToProtoList(items),
-	}, nil
+	return LocationItemsToProto(filter, items), nil
+}
+
+func (s *StockServer) ReduceStock(ctx context.Context, req *stockpb.ReduceStockRequest) (*stockpb.StockResponse, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "ReduceStock")
+	defer span.End()
+
+	// Cart reduces stock as a service for the buyer who checked out.
+	userID, err := ActingUserID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("ReduceStock called",
+		log.Int64("user_id", userID),
+		log.Int("items_count", len(req.GetItems())),
+	)
+
+	items, err := ValidateReduceStockRequest(userID, req)
+	if err != nil {
+		s.logger.Error("Invalid ReduceStock request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.usecase.Reduce(ctx, items)
+	if err != nil {
+		if denied := s.permissionDenied("ReduceStock", err); denied != nil {
+			return nil, denied
+		}
+
+		fields := []log.Field{log.Error(err)}
+
+		switch err {
+		case errors.ErrItemNotFound:
+			s.logger.Error("ReduceStock error: item not found", fields...)
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.ErrNotEnoughStock:
+			s.logger.Error("ReduceStock error: not enough stock", fields...)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			s.logger.Error("ReduceStock error: internal", fields...)
+			return nil, status.Error(codes.Internal, "failed to reduce stock")
+		}
+	}
+
+	s.logger.Info("Stock reduced successfully", log.Int("items_count", len(items)))
+
+	return &stockpb.StockResponse{Message: "Stock reduced successfully"}, nil
+}
+
+func (s *StockServer) ListMovements(ctx context.Context, req *stockpb.ListMovementsRequest) (*stockpb.ListMovementsResponse, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "ListMovements")
+	defer span.End()
+
+	s.logger.Info("ListMovements called",
+		log.String("sku", req.GetSku()),
+		log.String("location", req.GetLocation()),
+		log.UInt64("user_id", req.GetUserId()),
+	)
+
+	filter, err := ValidateListMovementsRequest(req)
+	if err != nil {
+		s.logger.Error("Invalid ListMovements request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	movements, err := s.usecase.ListMovements(ctx, filter)
+	if err != nil {
+		if denied := s.permissionDenied("ListMovements", err); denied != nil {
+			return nil, denied
+		}
+
+		s.logger.Error("ListMovements failed", log.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list movements")
+	}
+
+	s.logger.Info("ListMovements succeeded", log.Int("movements_count", len(movements)))
+
+	return MovementsToProto(movements, filter.Limit), nil
+}
+
+func (s *StockServer) ReserveItems(ctx context.Context, req *stockpb.ReserveItemsRequest) (*stockpb.Reservation, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "ReserveItems")
+	defer span.End()
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("ReserveItems called",
+		log.Int64("user_id", userID),
+		log.Int("items_count", len(req.GetItems())),
+	)
+
+	reservation, err := ValidateReserveItemsRequest(userID, req)
+	if err != nil {
+		s.logger.Error("Invalid ReserveItems request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reservation, err = s.usecase.Reserve(ctx, reservation)
+	if err != nil {
+		fields := []log.Field{log.Error(err)}
+
+		switch err {
+		case errors.ErrItemNotFound:
+			s.logger.Error("ReserveItems error: item not found", fields...)
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.ErrNotEnoughStock:
+			s.logger.Error("ReserveItems error: not enough stock", fields...)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			s.logger.Error("ReserveItems error: internal", fields...)
+			return nil, status.Error(codes.Internal, "failed to reserve items")
+		}
+	}
+
+	s.logger.Info("Items reserved successfully",
+		log.Int64("reservation_id", reservation.ID),
+		log.Int("items_count", len(reservation.Items)),
+	)
+
+	return ReservationToProto(reservation), nil
+}
+
+func (s *StockServer) ReleaseReservation(ctx context.Context, req *stockpb.ReservationRequest) (*stockpb.StockResponse, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "ReleaseReservation")
+	defer span.End()
+
+	s.logger.Info("ReleaseReservation called", log.String("reservation_id", req.GetReservationId()))
+
+	reservationID, err := ParseReservationID(req.GetReservationId())
+	if err != nil {
+		s.logger.Error("Invalid ReleaseReservation request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.usecase.Release(ctx, reservationID)
+	if err != nil {
+		return nil, s.reservationError("ReleaseReservation", "failed to release reservation", err)
+	}
+
+	s.logger.Info("Reservation released successfully", log.Int64("reservation_id", reservationID))
+
+	return &stockpb.StockResponse{Message: "Reservation released successfully"}, nil
+}
+
+func (s *StockServer) CommitReservation(ctx context.Context, req *stockpb.ReservationRequest) (*stockpb.StockResponse, error) {
+	tr := otel.Tracer("stocks-server")
+	ctx, span := tr.Start(ctx, "CommitReservation")
+	defer span.End()
+
+	s.logger.Info("CommitReservation called", log.String("reservation_id", req.GetReservationId()))
+
+	reservationID, err := ParseReservationID(req.GetReservationId())
+	if err != nil {
+		s.logger.Error("Invalid CommitReservation request", log.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.usecase.Commit(ctx, reservationID)
+	if err != nil {
+		return nil, s.reservationError("CommitReservation", "failed to commit reservation", err)
+	}
+
+	s.logger.Info("Reservation committed successfully", log.Int64("reservation_id", reservationID))
+
+	return &stockpb.StockResponse{Message: "Reservation committed successfully"}, nil
+}
+
+func (s *StockServer) reservationError(method, internalMsg string, err error) error {
+	if denied := s.permissionDenied(method, err); denied != nil {
+		return denied
+	}
+
+	fields := []log.Field{log.Error(err)}
+
+	switch err {
+	case errors.ErrReservationNotFound, errors.ErrItemNotFound:
+		s.logger.Error(method+" error: not found", fields...)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.ErrReservationInactive, errors.ErrReservationExpired, errors.ErrNotEnoughStock:
+		s.logger.Error(method+" error: failed precondition", fields...)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		s.logger.Error(method+" error: internal", fields...)
+		return status.Error(codes.Internal, internalMsg)
+	}
+}
+
+// permissionDenied turns a policy denial into PermissionDenied, keeping the
+// reason in the status message. It returns nil for any other error.
+func (s *StockServer) permissionDenied(method string, err error) error {
+	if !stdErrors.Is(err, errors.ErrPermissionDenied) {
+		return nil
+	}
+
+	s.logger.Error(method+" error: permission denied", log.Error(err))
+
+	return status.Error(codes.PermissionDenied, err.Error())
 }
@@ -3,8 +3,17 @@ package errors
 import "errors"
 
 var (
-	ErrItemExists         = errors.New("item already exists")
-	ErrItemNotFound       = errors.New("item not found")
-	ErrInvalidSKU         = errors.New("invalid SKU — not registered")
-	ErrOwnershipViolation = errors.New("ownership violation: user does not own this SKU")
+	ErrItemExists          = errors.New("item already exists")
+	ErrItemNotFound        = errors.New("item not found")
+	ErrInvalidSKU          = errors.New("invalid SKU — not registered")
+	ErrSKUNotFound         = errors.New("SKU not found")
+	ErrSKUExists           = errors.New("SKU already exists")
+	ErrSKUArchived         = errors.New("SKU is archived")
+	ErrOwnershipViolation  = errors.New("ownership violation: user does not own this SKU")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrNotEnoughStock      = errors.New("not enough stock available")
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrReservationInactive = errors.New("reservation is not active")
+	ErrReservationExpired  = errors.New("reservation has expired")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
 )
@@ -0,0 +1,56 @@
+package event
+
+import (
+	"fmt"
+
+	eventspb "stocks/pkg/api/events"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// HeaderContentType is the record header that says how the envelope in the
+// record value is encoded.
+const HeaderContentType = "content-type"
+
+const (
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeJSON     = "application/json"
+)
+
+// ContentType maps a KAFKA_EVENT_ENCODING value to the content type events
+// are published with.
+func ContentType(encoding string) (string, error) {
+	switch encoding {
+	case "", "protobuf":
+		return ContentTypeProtobuf, nil
+	case "json":
+		return ContentTypeJSON, nil
+	default:
+		return "", fmt.Errorf("unknown event encoding %q, want protobuf or json", encoding)
+	}
+}
+
+// TypeOf returns the event type of env, which is the name of its payload
+// field, or "" if no payload is set.
+func TypeOf(env *eventspb.Envelope) string {
+	m := env.ProtoReflect()
+
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("payload"))
+	if fd == nil {
+		return ""
+	}
+
+	return string(fd.Name())
+}
+
+func Marshal(env *eventspb.Envelope, contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeProtobuf:
+		return proto.Marshal(env)
+	case ContentTypeJSON:
+		return protojson.Marshal(env)
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+}
@@ -1,20 +1,27 @@
 package event
 
-type KafkaMessage struct {
-	Type      string      `json:"type"`
-	Service   string      `json:"service"`
-	Timestamp string      `json:"timestamp"`
-	Payload   interface{} `json:"payload"`
-}
-
-type SKUCreatedPayload struct {
-	SKU   string  `json:"sku"`
-	Price float64 `json:"price"`
-	Count int     `json:"count"`
-}
+// Event types are the names of the payload fields of eventspb.Envelope.
+const (
+	TypeSKUCreated         = "sku_created"
+	TypeStockChanged       = "stock_changed"
+	TypeStockDeleted       = "stock_deleted"
+	TypePriceChanged       = "price_changed"
+	TypeReservationExpired = "reservation_expired"
+	TypeCatalogSKUCreated  = "catalog_sku_created"
+	TypeCatalogSKUUpdated  = "catalog_sku_updated"
+	TypeCatalogSKUArchived = "catalog_sku_archived"
+)
 
-type StockChangedPayload struct {
-	SKU   string  `json:"sku"`
-	Count int     `json:"count"`
-	Price float64 `json:"price"`
+// SchemaVersions holds the current payload schema version of every event the
+// service publishes. A version is bumped whenever a payload changes in a way
+// consumers cannot ignore: a field removed, renamed or given a new meaning.
+var SchemaVersions = map[string]int32{
+	TypeSKUCreated:         1,
+	TypeStockChanged:       1,
+	TypeStockDeleted:       1,
+	TypePriceChanged:       1,
+	TypeReservationExpired: 1,
+	TypeCatalogSKUCreated:  1,
+	TypeCatalogSKUUpdated:  1,
+	TypeCatalogSKUArchived: 1,
 }
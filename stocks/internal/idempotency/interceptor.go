@@ -0,0 +1,185 @@
+// Package idempotency makes retried mutating RPCs safe: the first response
+// under an Idempotency-Key is stored and replayed to the retries.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"time"
+
+	"stocks/internal/auth"
+	"stocks/internal/log"
+	"stocks/internal/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// Header is the metadata key of the idempotency key; the gateway maps the
+	// Idempotency-Key HTTP header to it.
+	Header = "idempotency-key"
+	// ReplayedHeader is set on responses replayed from the store.
+	ReplayedHeader = "idempotent-replayed"
+
+	maxKeyLength = 255
+	// storeTimeout bounds the writes made after the handler returned. They
+	// must not share the request's deadline: a client that timed out would
+	// otherwise leave its key in progress until it expires.
+	storeTimeout = 5 * time.Second
+)
+
+type Store interface {
+	Reserve(ctx context.Context, scope, key string, requestHash []byte, ttl time.Duration) (models.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, scope, key, responseType string, response []byte) error
+	Release(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// UnaryServerInterceptor honors idempotency keys on methods. It must run after
+// the auth interceptor: keys belong to the caller, and calls without a caller
+// are not made idempotent.
+func UnaryServerInterceptor(store Store, ttl time.Duration, logger log.Logger, methods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !slices.Contains(methods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		key := metadataValue(ctx, Header)
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		if len(key) > maxKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "idempotency key must be at most %d characters", maxKeyLength)
+		}
+
+		identity, ok := auth.FromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		requestHash, err := hash(info.FullMethod, msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to hash request")
+		}
+
+		scope := callerScope(identity)
+
+		record, reserved, err := store.Reserve(ctx, scope, key, requestHash, ttl)
+		if err != nil {
+			logger.Error("failed to reserve idempotency key", log.String("method", info.FullMethod), log.Error(err))
+			return nil, status.Error(codes.Unavailable, "idempotency store unavailable")
+		}
+
+		if !reserved {
+			return replay(ctx, record, requestHash)
+		}
+
+		resp, err := handler(ctx, req)
+
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+		defer cancel()
+
+		if err != nil {
+			// Failed requests are not stored, so the client may retry them.
+			if releaseErr := store.Release(storeCtx, scope, key); releaseErr != nil {
+				logger.Error("failed to release idempotency key", log.String("method", info.FullMethod), log.Error(releaseErr))
+			}
+
+			return nil, err
+		}
+
+		if err = complete(storeCtx, store, scope, key, resp); err != nil {
+			// The key stays reserved until it expires: replaying nothing is
+			// safer than running the request twice.
+			logger.Error("failed to store idempotent response", log.String("method", info.FullMethod), log.Error(err))
+		}
+
+		return resp, nil
+	}
+}
+
+func complete(ctx context.Context, store Store, scope, key string, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("response %T is not a protobuf message", resp)
+	}
+
+	response, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return store.Complete(ctx, scope, key, string(msg.ProtoReflect().Descriptor().FullName()), response)
+}
+
+func replay(ctx context.Context, record models.IdempotencyRecord, requestHash []byte) (interface{}, error) {
+	if !slices.Equal(record.RequestHash, requestHash) {
+		return nil, status.Error(codes.InvalidArgument, "idempotency key reused with a different request")
+	}
+
+	if !record.Completed() {
+		return nil, status.Error(codes.Aborted, "a request with this idempotency key is in progress")
+	}
+
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(record.ResponseType))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "unknown stored response type")
+	}
+
+	msg := msgType.New().Interface()
+	if err = proto.Unmarshal(record.Response, msg); err != nil {
+		return nil, status.Error(codes.Internal, "invalid stored response")
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ReplayedHeader, "true"))
+
+	return msg, nil
+}
+
+// hash identifies a request by its method and its deterministic encoding, so
+// reusing a key on another method also counts as a different request.
+func hash(method string, req proto.Message) ([]byte, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write(body)
+
+	return h.Sum(nil), nil
+}
+
+func callerScope(identity auth.Identity) string {
+	return fmt.Sprintf("user:%d", identity.UserID)
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
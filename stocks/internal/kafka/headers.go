@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// traceHeaders captures the trace context of ctx in W3C form (traceparent,
+// tracestate, baggage) so it can travel with the message.
+func traceHeaders(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	return carrier
+}
+
+func recordHeaders(headers map[string]string) []sarama.RecordHeader {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	records := make([]sarama.RecordHeader, 0, len(keys))
+	for _, key := range keys {
+		records = append(records, sarama.RecordHeader{
+			Key:   []byte(key),
+			Value: []byte(headers[key]),
+		})
+	}
+
+	return records
+}
@@ -2,49 +2,58 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"math"
+	"io"
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
 	"stocks/internal/event"
 	"stocks/internal/log"
+	"stocks/internal/models"
+	"stocks/internal/money"
+	eventspb "stocks/pkg/api/events"
 
 	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const maxProducerRetry = 5
 
+// ContentType is event.ContentTypeProtobuf or event.ContentTypeJSON.
+// Idempotent makes the broker drop the duplicates retries would write; it
+// needs RequiredAcks to be sarama.WaitForAll.
 type ProducerConfig struct {
-	Brokers   []string
-	Topic     string
-	Partition int32
-	Service   string
+	Brokers      []string
+	Topic        string
+	Service      string
+	ContentType  string
+	RequiredAcks sarama.RequiredAcks
+	Idempotent   bool
 }
 
+// Producer publishes stock and catalog events keyed by SKU and reservation
+// events keyed by user, so the events of one of them land on one partition
+// and are consumed in order.
 type Producer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	partition int32
-	service   string
-	logger    log.Logger
+	sink        Sink
+	service     string
+	contentType string
+	logger      log.Logger
 }
 
-func NewProducer(cfg *ProducerConfig, logger log.Logger) (*Producer, error) {
-	config := sarama.NewConfig()
-	config.Producer.RequiredAcks = sarama.NoResponse
-	config.Producer.Return.Successes = true
-	config.Producer.Retry.Max = maxProducerRetry
-	config.Producer.Partitioner = func(topic string) sarama.Partitioner {
-		return sarama.NewManualPartitioner(topic)
-	}
+type saramaSink struct {
+	producer sarama.SyncProducer
+	topic    string
+	logger   log.Logger
+}
 
-	producer, err := sarama.NewSyncProducer(cfg.Brokers, config)
+func NewProducer(cfg *ProducerConfig, logger log.Logger) (*Producer, error) {
+	producer, err := sarama.NewSyncProducer(cfg.Brokers, newSaramaConfig(cfg))
 	if err != nil {
 		logger.Error("failed to create Kafka producer", log.Error(err))
 		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
@@ -53,101 +62,330 @@ func NewProducer(cfg *ProducerConfig, logger log.Logger) (*Producer, error) {
 	logger.Info("Kafka producer created",
 		log.Strings("brokers", cfg.Brokers),
 		log.String("topic", cfg.Topic),
-		log.Int32("partition", cfg.Partition),
 		log.String("service", cfg.Service),
+		log.Int("required_acks", int(cfg.RequiredAcks)),
+		log.Bool("idempotent", cfg.Idempotent),
+		log.String("content_type", cfg.ContentType),
 	)
 
+	sink := &saramaSink{
+		producer: producer,
+		topic:    cfg.Topic,
+		logger:   logger,
+	}
+
+	return NewProducerWithSink(cfg.Service, cfg.ContentType, sink, logger), nil
+}
+
+// newSaramaConfig spreads messages over all partitions of the topic by the
+// hash of their key.
+func newSaramaConfig(cfg *ProducerConfig) *sarama.Config {
+	config := sarama.NewConfig()
+	// Idempotent producing needs Kafka 0.11 or newer.
+	config.Version = sarama.V2_8_0_0
+	config.Producer.RequiredAcks = cfg.RequiredAcks
+	config.Producer.Idempotent = cfg.Idempotent
+	config.Producer.Return.Successes = true
+	config.Producer.Retry.Max = maxProducerRetry
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+
+	if cfg.Idempotent {
+		// With more requests in flight a retried batch could overtake the
+		// next one and break the order of a key.
+		config.Net.MaxOpenRequests = 1
+	}
+
+	return config
+}
+
+// NewProducerWithSink builds a producer that encodes events the same way as
+// the Kafka producer but hands them to sink instead of a broker.
+func NewProducerWithSink(service, contentType string, sink Sink, logger log.Logger) *Producer {
 	return &Producer{
-		producer:  producer,
-		topic:     cfg.Topic,
-		partition: cfg.Partition,
-		service:   cfg.Service,
-		logger:    logger,
-	}, nil
+		sink:        sink,
+		service:     service,
+		contentType: contentType,
+		logger:      logger,
+	}
 }
 
-func (p *Producer) SendSKUCreated(ctx context.Context, sku string, price float64, count int) error {
+func (p *Producer) SendSKUCreated(ctx context.Context, sku string, price money.Money, count int) error {
 	tr := otel.Tracer("kafka-producer")
-	ctx, span := tr.Start(ctx, "SendSKUCreated")
+	ctx, span := tr.Start(ctx, "SendSKUCreated", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("sku", sku),
-		attribute.Float64("price", price),
+		attribute.String("price", price.String()),
 		attribute.Int("count", count),
 	)
 
-	payload := event.SKUCreatedPayload{
-		SKU:   sku,
-		Price: price,
-		Count: count,
-	}
+	payload := &eventspb.Envelope_SkuCreated{SkuCreated: &eventspb.SKUCreated{
+		Sku:       sku,
+		Price:     price.Float64(),
+		UnitPrice: money.ToProto(price),
+		Count:     int32(count),
+	}}
 
 	p.logger.Info("Sending sku_created event",
 		log.String("sku", sku),
-		log.Float64("price", price),
+		log.String("price", price.String()),
 		log.Int("count", count),
 	)
 
-	return p.send(ctx, "sku_created", payload)
+	return p.send(ctx, sku, &eventspb.Envelope{Payload: payload})
 }
 
-func (p *Producer) SendStockChanged(ctx context.Context, sku string, count int, price float64) error {
+func (p *Producer) SendStockChanged(ctx context.Context, sku string, count int, price money.Money) error {
 	tr := otel.Tracer("kafka-producer")
-	ctx, span := tr.Start(ctx, "SendStockChanged")
+	ctx, span := tr.Start(ctx, "SendStockChanged", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("sku", sku),
 		attribute.Int("count", count),
-		attribute.Float64("price", price),
+		attribute.String("price", price.String()),
 	)
 
-	payload := event.StockChangedPayload{
-		SKU:   sku,
-		Count: count,
-		Price: price,
-	}
+	payload := &eventspb.Envelope_StockChanged{StockChanged: &eventspb.StockChanged{
+		Sku:       sku,
+		Count:     int32(count),
+		Price:     price.Float64(),
+		UnitPrice: money.ToProto(price),
+	}}
 
 	p.logger.Info("Sending stock_changed event",
 		log.String("sku", sku),
 		log.Int("count", count),
-		log.Float64("price", price),
+		log.String("price", price.String()),
+	)
+
+	return p.send(ctx, sku, &eventspb.Envelope{Payload: payload})
+}
+
+// SendStockDeleted publishes one deleted stock row; remaining is what is left
+// of the SKU across all locations.
+func (p *Producer) SendStockDeleted(ctx context.Context, item models.StockItem, remaining int) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, "SendStockDeleted", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	sku := strconv.FormatUint(uint64(item.SKU), 10)
+
+	span.SetAttributes(
+		attribute.String("sku", sku),
+		attribute.String("location", item.Location),
+		attribute.Int("count", int(item.Count)),
+		attribute.Int("remaining", remaining),
+	)
+
+	payload := &eventspb.Envelope_StockDeleted{StockDeleted: &eventspb.StockDeleted{
+		Sku:       sku,
+		Location:  item.Location,
+		Count:     int32(item.Count),
+		Remaining: int32(remaining),
+	}}
+
+	p.logger.Info("Sending stock_deleted event",
+		log.String("sku", sku),
+		log.String("location", item.Location),
+		log.Int("count", int(item.Count)),
+		log.Int("remaining", remaining),
+	)
+
+	return p.send(ctx, sku, &eventspb.Envelope{Payload: payload})
+}
+
+func (p *Producer) SendPriceChanged(ctx context.Context, sku, location string, oldPrice, newPrice money.Money) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, "SendPriceChanged", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sku", sku),
+		attribute.String("location", location),
+		attribute.String("old_price", oldPrice.String()),
+		attribute.String("new_price", newPrice.String()),
+	)
+
+	payload := &eventspb.Envelope_PriceChanged{PriceChanged: &eventspb.PriceChanged{
+		Sku:      sku,
+		Location: location,
+		OldPrice: money.ToProto(oldPrice),
+		NewPrice: money.ToProto(newPrice),
+	}}
+
+	p.logger.Info("Sending price_changed event",
+		log.String("sku", sku),
+		log.String("location", location),
+		log.String("old_price", oldPrice.String()),
+		log.String("new_price", newPrice.String()),
 	)
 
-	return p.send(ctx, "stock_changed", payload)
+	return p.send(ctx, sku, &eventspb.Envelope{Payload: payload})
 }
 
-func (p *Producer) send(ctx context.Context, eventType string, payload interface{}) error {
-	msg := event.KafkaMessage{
-		Type:      eventType,
-		Service:   p.service,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Payload:   payload,
+func (p *Producer) SendReservationExpired(ctx context.Context, reservation models.Reservation) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, "SendReservationExpired", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	reservationID := strconv.FormatInt(reservation.ID, 10)
+
+	span.SetAttributes(
+		attribute.String("reservation_id", reservationID),
+		attribute.Int64("user_id", reservation.UserID),
+		attribute.Int("items_count", len(reservation.Items)),
+	)
+
+	items := make([]*eventspb.ReservationItem, 0, len(reservation.Items))
+	for _, item := range reservation.Items {
+		items = append(items, &eventspb.ReservationItem{
+			Sku:   strconv.FormatUint(uint64(item.SKU), 10),
+			Count: int32(item.Count),
+		})
+	}
+
+	payload := &eventspb.Envelope_ReservationExpired{ReservationExpired: &eventspb.ReservationExpired{
+		ReservationId: reservationID,
+		UserId:        strconv.FormatInt(reservation.UserID, 10),
+		Items:         items,
+		ExpiredAt:     timestamppb.New(reservation.ExpiresAt),
+	}}
+
+	p.logger.Info("Sending reservation_expired event",
+		log.String("reservation_id", reservationID),
+		log.Int("items_count", len(items)),
+	)
+
+	return p.send(ctx, strconv.FormatInt(reservation.UserID, 10), &eventspb.Envelope{Payload: payload})
+}
+
+func (p *Producer) SendCatalogSKUCreated(ctx context.Context, sku models.SKU) error {
+	return p.sendCatalogEvent(ctx, "SendCatalogSKUCreated", event.TypeCatalogSKUCreated, sku)
+}
+
+func (p *Producer) SendCatalogSKUUpdated(ctx context.Context, sku models.SKU) error {
+	return p.sendCatalogEvent(ctx, "SendCatalogSKUUpdated", event.TypeCatalogSKUUpdated, sku)
+}
+
+func (p *Producer) SendCatalogSKUArchived(ctx context.Context, sku models.SKU) error {
+	return p.sendCatalogEvent(ctx, "SendCatalogSKUArchived", event.TypeCatalogSKUArchived, sku)
+}
+
+// sendCatalogEvent publishes the full catalog entry, keyed by SKU so catalog
+// and stock events of one SKU stay in order.
+func (p *Producer) sendCatalogEvent(ctx context.Context, spanName, eventType string, sku models.SKU) error {
+	tr := otel.Tracer("kafka-producer")
+	ctx, span := tr.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	key := strconv.FormatUint(uint64(sku.SKU), 10)
+
+	span.SetAttributes(
+		attribute.String("sku", key),
+		attribute.String("type", sku.Type),
+		attribute.Bool("archived", sku.Archived()),
+	)
+
+	entry := &eventspb.CatalogSKU{
+		Sku:          key,
+		Name:         sku.Name,
+		Type:         sku.Type,
+		CurrencyCode: sku.Currency,
+		Archived:     sku.Archived(),
 	}
 
-	valueBytes, err := json.Marshal(msg)
+	if sku.Archived() {
+		entry.ArchivedAt = timestamppb.New(sku.ArchivedAt)
+	}
+
+	env := &eventspb.Envelope{}
+
+	switch eventType {
+	case event.TypeCatalogSKUCreated:
+		env.Payload = &eventspb.Envelope_CatalogSkuCreated{CatalogSkuCreated: entry}
+	case event.TypeCatalogSKUUpdated:
+		env.Payload = &eventspb.Envelope_CatalogSkuUpdated{CatalogSkuUpdated: entry}
+	case event.TypeCatalogSKUArchived:
+		env.Payload = &eventspb.Envelope_CatalogSkuArchived{CatalogSkuArchived: entry}
+	default:
+		return fmt.Errorf("unknown catalog event type %q", eventType)
+	}
+
+	p.logger.Info("Sending "+eventType+" event",
+		log.String("sku", key),
+		log.String("name", sku.Name),
+		log.String("type", sku.Type),
+	)
+
+	return p.send(ctx, key, env)
+}
+
+// send fills in the envelope of an event whose payload is set and hands it to
+// the sink encoded as the configured content type. key picks the partition.
+func (p *Producer) send(ctx context.Context, key string, env *eventspb.Envelope) error {
+	eventType := event.TypeOf(env)
+	headers := traceHeaders(ctx)
+
+	env.EventId = uuid.NewString()
+	env.Type = eventType
+	env.SchemaVersion = event.SchemaVersions[eventType]
+	env.Source = p.service
+	env.OccurredAt = timestamppb.Now()
+	env.TraceContext = headers
+
+	valueBytes, err := event.Marshal(env, p.contentType)
 	if err != nil {
 		p.logger.Error("failed to marshal Kafka message", log.Error(err))
 		return fmt.Errorf("failed to marshal Kafka message: %w", err)
 	}
 
+	msgHeaders := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		msgHeaders[k] = v
+	}
+	msgHeaders[event.HeaderContentType] = p.contentType
+
+	return p.sink.Publish(ctx, Message{
+		Key:     key,
+		Type:    eventType,
+		Value:   valueBytes,
+		Headers: msgHeaders,
+	})
+}
+
+// Publish sends an already encoded message as is.
+func (p *Producer) Publish(ctx context.Context, msg Message) error {
+	return p.sink.Publish(ctx, msg)
+}
+
+func (p *Producer) Close() error {
+	closer, ok := p.sink.(io.Closer)
+	if !ok {
+		return nil
+	}
+
+	return closer.Close()
+}
+
+func (s *saramaSink) Publish(_ context.Context, msg Message) error {
 	producerMsg := &sarama.ProducerMessage{
-		Topic:     p.topic,
-		Partition: p.partition,
-		Value:     sarama.ByteEncoder(valueBytes),
-		Key:       sarama.StringEncoder(fmt.Sprintf("%s-%d", p.service, time.Now().UnixNano())),
+		Topic:   s.topic,
+		Value:   sarama.ByteEncoder(msg.Value),
+		Key:     sarama.StringEncoder(msg.Key),
+		Headers: recordHeaders(msg.Headers),
 	}
 
-	partition, offset, err := p.producer.SendMessage(producerMsg)
+	partition, offset, err := s.producer.SendMessage(producerMsg)
 	if err != nil {
-		p.logger.Error("failed to send Kafka message", log.Error(err))
+		s.logger.Error("failed to send Kafka message", log.Error(err))
 		return fmt.Errorf("failed to send Kafka message: %w", err)
 	}
 
-	p.logger.Info("Kafka message sent",
-		log.String("event_type", eventType),
-		log.String("topic", p.topic),
+	s.logger.Info("Kafka message sent",
+		log.String("event_type", msg.Type),
+		log.String("topic", s.topic),
 		log.Int32("partition", partition),
 		log.Int64("offset", offset),
 	)
@@ -155,12 +393,12 @@ func (p *Producer) send(ctx context.Context, eventType string, payload interface
 	return nil
 }
 
-func (p *Producer) Close() error {
-	err := p.producer.Close()
+func (s *saramaSink) Close() error {
+	err := s.producer.Close()
 	if err != nil {
-		p.logger.Error("failed to close Kafka producer", log.Error(err))
+		s.logger.Error("failed to close Kafka producer", log.Error(err))
 	} else {
-		p.logger.Info("Kafka producer closed")
+		s.logger.Info("Kafka producer closed")
 	}
 	return err
 }
@@ -177,29 +415,53 @@ func NewProducerConfigFromEnv() (*ProducerConfig, error) {
 		topic = "metrics"
 	}
 
-	partitionStr := os.Getenv("PARTITION")
-	if partitionStr == "" {
-		partitionStr = "1"
+	service := os.Getenv("SERVICE_NAME")
+	if service == "" {
+		service = "stocks-service"
 	}
 
-	partitionInt, err := strconv.Atoi(partitionStr)
+	contentType, err := event.ContentType(os.Getenv("KAFKA_EVENT_ENCODING"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse PARTITION: %w", err)
+		return nil, fmt.Errorf("failed to parse KAFKA_EVENT_ENCODING: %w", err)
 	}
-	if partitionInt < math.MinInt32 || partitionInt > math.MaxInt32 {
-		return nil, fmt.Errorf("partition value %d out of int32 range", partitionInt)
+
+	acks, err := parseRequiredAcks(os.Getenv("KAFKA_REQUIRED_ACKS"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KAFKA_REQUIRED_ACKS: %w", err)
 	}
-	partition := int32(partitionInt)
 
-	service := os.Getenv("SERVICE_NAME")
-	if service == "" {
-		service = "stocks-service"
+	idempotent := true
+	if idempotentStr := os.Getenv("KAFKA_IDEMPOTENT"); idempotentStr != "" {
+		if idempotent, err = strconv.ParseBool(idempotentStr); err != nil {
+			return nil, fmt.Errorf("failed to parse KAFKA_IDEMPOTENT: %w", err)
+		}
+	}
+
+	if idempotent && acks != sarama.WaitForAll {
+		return nil, fmt.Errorf("KAFKA_IDEMPOTENT needs KAFKA_REQUIRED_ACKS=all")
 	}
 
 	return &ProducerConfig{
-		Brokers:   brokers,
-		Topic:     topic,
-		Partition: int32(partition),
-		Service:   service,
+		Brokers:      brokers,
+		Topic:        topic,
+		Service:      service,
+		ContentType:  contentType,
+		RequiredAcks: acks,
+		Idempotent:   idempotent,
 	}, nil
 }
+
+// parseRequiredAcks reads how many replicas must confirm a write: none,
+// leader or all (the default).
+func parseRequiredAcks(s string) (sarama.RequiredAcks, error) {
+	switch s {
+	case "", "all":
+		return sarama.WaitForAll, nil
+	case "leader":
+		return sarama.WaitForLocal, nil
+	case "none":
+		return sarama.NoResponse, nil
+	default:
+		return 0, fmt.Errorf("unknown acks %q, want none, leader or all", s)
+	}
+}
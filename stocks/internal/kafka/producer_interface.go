@@ -2,12 +2,34 @@ package kafka
 
 import (
 	"context"
+	"stocks/internal/models"
+	"stocks/internal/money"
 )
 
 //go:generate mockgen -source=internal/kafka/producer_interface.go -destination=internal/usecase/mocks/mock_producer.go -package=mocks
 
 type ProducerInterface interface {
-	SendSKUCreated(ctx context.Context, sku string, price float64, count int) error
-	SendStockChanged(ctx context.Context, sku string, count int, price float64) error
+	SendSKUCreated(ctx context.Context, sku string, price money.Money, count int) error
+	SendStockChanged(ctx context.Context, sku string, count int, price money.Money) error
+	SendStockDeleted(ctx context.Context, item models.StockItem, remaining int) error
+	SendPriceChanged(ctx context.Context, sku, location string, oldPrice, newPrice money.Money) error
+	SendReservationExpired(ctx context.Context, reservation models.Reservation) error
+	SendCatalogSKUCreated(ctx context.Context, sku models.SKU) error
+	SendCatalogSKUUpdated(ctx context.Context, sku models.SKU) error
+	SendCatalogSKUArchived(ctx context.Context, sku models.SKU) error
 	Close() error
 }
+
+// Message is an encoded event ready to be delivered. Key orders messages that
+// belong to the same entity; Headers carry the content type of Value and the
+// trace context of the producer.
+type Message struct {
+	Key     string
+	Type    string
+	Value   []byte
+	Headers map[string]string
+}
+
+type Sink interface {
+	Publish(ctx context.Context, msg Message) error
+}
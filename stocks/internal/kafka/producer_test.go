@@ -0,0 +1,212 @@
+package kafka_test
+
+import (
+	"context"
+	"stocks/internal/event"
+	"stocks/internal/kafka"
+	"stocks/internal/log/zap"
+	"stocks/internal/models"
+	"stocks/internal/money"
+	eventspb "stocks/pkg/api/events"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type recordingSink struct {
+	messages []kafka.Message
+}
+
+func (s *recordingSink) Publish(_ context.Context, msg kafka.Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func TestProducer_PropagatesTraceContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+
+	sink := &recordingSink{}
+	producer := kafka.NewProducerWithSink("stocks", event.ContentTypeProtobuf, sink, logger)
+
+	if err := producer.SendStockChanged(ctx, "1001", 5, money.New("RUB", 1000)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sink.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(sink.messages))
+	}
+
+	msg := sink.messages[0]
+	if msg.Key != "1001" {
+		t.Fatalf("expected key 1001, got %q", msg.Key)
+	}
+
+	want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if got := msg.Headers["traceparent"]; got != want {
+		t.Fatalf("expected traceparent %q, got %q", want, got)
+	}
+
+	var env eventspb.Envelope
+	if err := proto.Unmarshal(msg.Value, &env); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+
+	if got := env.GetTraceContext()["traceparent"]; got != want {
+		t.Fatalf("expected envelope traceparent %q, got %q", want, got)
+	}
+}
+
+func TestProducer_SendStockDeleted(t *testing.T) {
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	sink := &recordingSink{}
+	producer := kafka.NewProducerWithSink("stocks", event.ContentTypeProtobuf, sink, logger)
+
+	item := models.StockItem{SKU: 1001, Location: "loc1", Count: 4}
+	if err := producer.SendStockDeleted(context.Background(), item, 6); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sink.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(sink.messages))
+	}
+
+	msg := sink.messages[0]
+	if got := msg.Headers[event.HeaderContentType]; got != event.ContentTypeProtobuf {
+		t.Fatalf("expected content type %q, got %q", event.ContentTypeProtobuf, got)
+	}
+
+	var env eventspb.Envelope
+	if err := proto.Unmarshal(msg.Value, &env); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+
+	if env.GetType() != event.TypeStockDeleted || env.GetSchemaVersion() != event.SchemaVersions[event.TypeStockDeleted] {
+		t.Fatalf("unexpected type %q version %d", env.GetType(), env.GetSchemaVersion())
+	}
+
+	if env.GetEventId() == "" || env.GetSource() != "stocks" || env.GetOccurredAt() == nil {
+		t.Fatalf("envelope metadata not set: %v", &env)
+	}
+
+	want := &eventspb.StockDeleted{Sku: "1001", Location: "loc1", Count: 4, Remaining: 6}
+	if !proto.Equal(env.GetStockDeleted(), want) {
+		t.Fatalf("expected payload %v, got %v", want, env.GetStockDeleted())
+	}
+}
+
+func TestProducer_JSONEncoding(t *testing.T) {
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	sink := &recordingSink{}
+	producer := kafka.NewProducerWithSink("stocks", event.ContentTypeJSON, sink, logger)
+
+	sku := models.SKU{SKU: 1001, Name: "t-shirt", Type: "apparel", Currency: "RUB"}
+	if err := producer.SendCatalogSKUUpdated(context.Background(), sku); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := sink.messages[0]
+	if got := msg.Headers[event.HeaderContentType]; got != event.ContentTypeJSON {
+		t.Fatalf("expected content type %q, got %q", event.ContentTypeJSON, got)
+	}
+
+	var env eventspb.Envelope
+	if err := protojson.Unmarshal(msg.Value, &env); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+
+	if env.GetType() != event.TypeCatalogSKUUpdated || env.GetCatalogSkuUpdated().GetName() != "t-shirt" {
+		t.Fatalf("unexpected event %v", &env)
+	}
+}
+
+func TestProducer_SendReservationExpiredKeyedByUser(t *testing.T) {
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	sink := &recordingSink{}
+	producer := kafka.NewProducerWithSink("stocks", event.ContentTypeProtobuf, sink, logger)
+
+	reservation := models.Reservation{ID: 7, UserID: 42}
+	if err := producer.SendReservationExpired(context.Background(), reservation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := sink.messages[0].Key; got != "42" {
+		t.Fatalf("expected key 42, got %q", got)
+	}
+}
+
+func TestNewProducerConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name           string
+		acks           string
+		idempotent     string
+		wantAcks       sarama.RequiredAcks
+		wantIdempotent bool
+		wantErr        bool
+	}{
+		{name: "defaults", wantAcks: sarama.WaitForAll, wantIdempotent: true},
+		{name: "leader acks", acks: "leader", idempotent: "false", wantAcks: sarama.WaitForLocal},
+		{name: "no acks", acks: "none", idempotent: "0", wantAcks: sarama.NoResponse},
+		{name: "idempotent without all acks", acks: "none", wantErr: true},
+		{name: "unknown acks", acks: "2", wantErr: true},
+		{name: "invalid idempotent", idempotent: "yes please", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_BROKERS", "kafka1:9092")
+			t.Setenv("KAFKA_REQUIRED_ACKS", tt.acks)
+			t.Setenv("KAFKA_IDEMPOTENT", tt.idempotent)
+
+			cfg, err := kafka.NewProducerConfigFromEnv()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if cfg.RequiredAcks != tt.wantAcks || cfg.Idempotent != tt.wantIdempotent {
+				t.Fatalf("expected acks %d idempotent %t, got %d %t", tt.wantAcks, tt.wantIdempotent, cfg.RequiredAcks, cfg.Idempotent)
+			}
+		})
+	}
+}
@@ -23,6 +23,11 @@ type Metrics struct {
 	RequestsTotal   *prometheus.CounterVec
 	RequestDuration *prometheus.HistogramVec
 	RequestErrors   *prometheus.CounterVec
+
+	OutboxPending       prometheus.Gauge
+	OutboxLag           prometheus.Gauge
+	OutboxPublished     prometheus.Counter
+	OutboxPublishErrors prometheus.Counter
 }
 
 func (m *Metrics) IncRequest(path, method string) {
@@ -75,9 +80,36 @@ func RegisterMetrics() *Metrics {
 			},
 			[]string{"path", "method"},
 		),
+		OutboxPending: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "stocks_outbox_pending_messages",
+				Help: "Number of events waiting in the outbox",
+			},
+		),
+		OutboxLag: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "stocks_outbox_lag_seconds",
+				Help: "Age of the oldest event waiting in the outbox",
+			},
+		),
+		OutboxPublished: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "stocks_outbox_published_total",
+				Help: "Total number of outbox events published to Kafka",
+			},
+		),
+		OutboxPublishErrors: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "stocks_outbox_publish_errors_total",
+				Help: "Total number of failed outbox publish attempts",
+			},
+		),
 	}
 
-	prometheus.MustRegister(m.RequestsTotal, m.RequestDuration, m.RequestErrors)
+	prometheus.MustRegister(
+		m.RequestsTotal, m.RequestDuration, m.RequestErrors,
+		m.OutboxPending, m.OutboxLag, m.OutboxPublished, m.OutboxPublishErrors,
+	)
 
 	return m
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type OutboxMessage struct {
+	ID            int64
+	Key           string
+	EventType     string
+	Payload       []byte
+	Headers       map[string]string
+	Attempts      int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
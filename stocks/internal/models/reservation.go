@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"
+	ReservationStatusCommitted ReservationStatus = "committed"
+	ReservationStatusReleased  ReservationStatus = "released"
+	ReservationStatusExpired   ReservationStatus = "expired"
+)
+
+type Reservation struct {
+	ID        int64
+	UserID    int64
+	Status    ReservationStatus
+	Items     []ReservationItem
+	ExpiresAt time.Time
+}
+
+type ReservationItem struct {
+	SKU   uint32
+	Count uint16
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type SKU struct {
+	SKU        uint32
+	Name       string
+	Type       string
+	Currency   string
+	ArchivedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Archived reports whether the SKU has been withdrawn from the catalog. An
+// archived SKU keeps its history but cannot be stocked again.
+func (s SKU) Archived() bool {
+	return !s.ArchivedAt.IsZero()
+}
+
+type SKUFilter struct {
+	Type            string
+	IncludeArchived bool
+	AfterSKU        uint32
+	Limit           int64
+}
@@ -1,11 +1,57 @@
 package models
 
+import "stocks/internal/money"
+
 type StockItem struct {
 	UserID   int64
 	SKU      uint32
 	Name     string
 	Type     string
-	Price    float64
+	Price    money.Money
 	Count    uint16
+	Reserved uint16
+	Location string
+	Archived bool
+}
+
+func (i StockItem) Available() uint16 {
+	if i.Reserved >= i.Count {
+		return 0
+	}
+
+	return i.Count - i.Reserved
+}
+
+type StockSort int
+
+const (
+	SortBySKU StockSort = iota
+	SortBySKUDesc
+	SortByPrice
+	SortByPriceDesc
+	SortByCount
+	SortByCountDesc
+)
+
+// LocationCursor is the position of the last item of a page: its sort key and
+// its SKU as a tie-breaker. Only the key of the filter's sort is used.
+type LocationCursor struct {
+	SKU   uint32
+	Price money.Money
+	Count uint16
+}
+
+// LocationFilter selects stock of one location. A non-empty Currency limits
+// the result to SKUs priced in it; the price bounds are compared in that
+// currency.
+type LocationFilter struct {
 	Location string
+	Type     string
+	Currency string
+	MinPrice money.Money
+	MaxPrice money.Money
+	MinCount uint16
+	Sort     StockSort
+	After    *LocationCursor
+	Limit    int64
 }
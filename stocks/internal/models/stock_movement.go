@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+type MovementReason string
+
+const (
+	MovementReasonRestock           MovementReason = "restock"
+	MovementReasonRemoval           MovementReason = "removal"
+	MovementReasonSale              MovementReason = "sale"
+	MovementReasonReservationCommit MovementReason = "reservation_commit"
+)
+
+// StockMovement is one ledger row. UserID is who made the change and OwnerID
+// owns the stock row it changed, e.g. the buyer and the seller of a sale.
+type StockMovement struct {
+	ID        int64
+	SKU       uint32
+	Location  string
+	Delta     int32
+	Reason    MovementReason
+	UserID    int64
+	OwnerID   int64
+	TraceID   string
+	CreatedAt time.Time
+}
+
+type MovementFilter struct {
+	SKU      uint32
+	Location string
+	UserID   int64
+	OwnerID  int64
+	From     time.Time
+	To       time.Time
+	BeforeID int64
+	Limit    int64
+}
@@ -0,0 +1,151 @@
+// Package money holds exact amounts of a currency. Prices are stored with two
+// decimals, so an amount is kept as an integer count of hundredths (kopecks,
+// cents) and all arithmetic is integer arithmetic.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	stockErrors "stocks/internal/errors"
+)
+
+const DefaultCurrency = "RUB"
+
+const centsPerUnit = 100
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+type Money struct {
+	Currency string
+	Cents    int64
+}
+
+func New(currency string, cents int64) Money {
+	return Money{Currency: currency, Cents: cents}
+}
+
+// FromFloat rounds a legacy float price to hundredths.
+func FromFloat(currency string, value float64) Money {
+	return New(currency, int64(math.Round(value*centsPerUnit)))
+}
+
+// Parse reads a decimal amount such as "12.5" or "-0.07", the text form of a
+// NUMERIC column. More than two decimals are rejected rather than rounded.
+func Parse(currency, amount string) (Money, error) {
+	cents, err := ParseCents(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(currency, cents), nil
+}
+
+func ParseCents(amount string) (int64, error) {
+	value := strings.TrimSpace(amount)
+
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if !isDigits(whole) || len(fraction) > 2 || (fraction != "" && !isDigits(fraction)) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil || units > (math.MaxInt64-cents)/centsPerUnit {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	total := units*centsPerUnit + cents
+	if negative {
+		total = -total
+	}
+
+	return total, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateCurrency accepts three upper-case letters, the shape of an ISO 4217
+// code.
+func ValidateCurrency(code string) error {
+	if len(code) != 3 {
+		return fmt.Errorf("invalid currency code %q", code)
+	}
+
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("invalid currency code %q", code)
+		}
+	}
+
+	return nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Cents == 0
+}
+
+// Add sums two amounts of the same currency. A zero amount without a currency
+// is the starting point of a sum and takes the other currency.
+func (m Money) Add(other Money) (Money, error) {
+	switch {
+	case m.Currency == "" && m.Cents == 0:
+		return other, nil
+	case other.Currency == "" && other.Cents == 0:
+		return m, nil
+	case m.Currency != other.Currency:
+		return Money{}, fmt.Errorf("%w: %s and %s", stockErrors.ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	return New(m.Currency, m.Cents+other.Cents), nil
+}
+
+func (m Money) Mul(n int64) Money {
+	return New(m.Currency, m.Cents*n)
+}
+
+// Decimal formats the amount with two decimals, e.g. "12.50".
+func (m Money) Decimal() string {
+	cents := m.Cents
+
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/centsPerUnit, cents%centsPerUnit)
+}
+
+// Float64 is the nearest float, for the deprecated float fields and metrics.
+func (m Money) Float64() float64 {
+	return float64(m.Cents) / centsPerUnit
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
@@ -0,0 +1,109 @@
+package money_test
+
+import (
+	"stocks/internal/errors"
+	"stocks/internal/money"
+	moneypb "stocks/pkg/api/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		amount    string
+		wantCents int64
+		wantErr   bool
+	}{
+		{amount: "12.34", wantCents: 1234},
+		{amount: "12.5", wantCents: 1250},
+		{amount: "7", wantCents: 700},
+		{amount: "-0.07", wantCents: -7},
+		{amount: "0.10", wantCents: 10},
+		{amount: "1.005", wantErr: true},
+		{amount: "", wantErr: true},
+		{amount: ".5", wantErr: true},
+		{amount: "1.-5", wantErr: true},
+		{amount: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := money.Parse("RUB", tt.amount)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, money.ErrInvalidAmount)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, money.New("RUB", tt.wantCents), m)
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	// 0.1 + 0.2 is the classic float rounding error; hundredths add up exactly.
+	sum, err := money.New("RUB", 10).Add(money.New("RUB", 20))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, "0.30", sum.Decimal())
+	assert.Equal(t, "1234.50 RUB", money.New("RUB", 123450).String())
+
+	total, err := money.Money{}.Add(money.New("USD", 199))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, money.New("USD", 199), total, "a zero sum takes the first currency")
+
+	_, err = money.New("RUB", 100).Add(money.New("USD", 100))
+	assert.ErrorIs(t, err, errors.ErrCurrencyMismatch)
+
+	assert.Equal(t, "-3.99", money.New("RUB", -133).Mul(3).Decimal())
+	assert.Equal(t, money.New("RUB", 1099), money.FromFloat("RUB", float64(float32(10.99))))
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, cents := range []int64{0, 1, 99, 1250, -1250, -7} {
+		m := money.New("RUB", cents)
+
+		got, err := money.FromProto(money.ToProto(m))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		assert.Equal(t, m, got)
+	}
+
+	assert.Equal(t, &moneypb.Money{CurrencyCode: "RUB", Units: -12, Nanos: -500_000_000}, money.ToProto(money.New("RUB", -1250)))
+
+	invalid := []*moneypb.Money{
+		{Units: 1, Nanos: -10_000_000},
+		{Nanos: 1_000_000_000},
+		{Units: 1, Nanos: 5},
+	}
+	for _, pb := range invalid {
+		_, err := money.FromProto(pb)
+		assert.ErrorIs(t, err, money.ErrInvalidAmount)
+	}
+}
+
+func TestValidateCurrency(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, money.ValidateCurrency("RUB"))
+	assert.Error(t, money.ValidateCurrency("rub"))
+	assert.Error(t, money.ValidateCurrency("RUBL"))
+}
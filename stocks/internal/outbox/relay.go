@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"stocks/internal/kafka"
+	"stocks/internal/log"
+	"stocks/internal/metrics"
+	"stocks/internal/models"
+	"stocks/internal/repository"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+)
+
+const (
+	relayBatchSize = 100
+
+	minRetryBackoff = time.Second
+	maxRetryBackoff = time.Minute
+)
+
+// Relay moves outbox messages to Kafka. Messages are published in insertion
+// order; once a message for a key fails, later messages for that key wait
+// until it goes through. Delivery is at least once.
+type Relay struct {
+	repo      repository.OutboxRepository
+	txManager trm.Manager
+	publisher kafka.Sink
+	interval  time.Duration
+	metrics   *metrics.Metrics
+	logger    log.Logger
+}
+
+func NewRelay(
+	repo repository.OutboxRepository,
+	txManager trm.Manager,
+	publisher kafka.Sink,
+	interval time.Duration,
+	m *metrics.Metrics,
+	logger log.Logger,
+) *Relay {
+	return &Relay{
+		repo:      repo,
+		txManager: txManager,
+		publisher: publisher,
+		interval:  interval,
+		metrics:   m,
+		logger:    logger,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+			if err := r.RelayOnce(ctx); err != nil {
+				r.logger.Error("outbox relay failed", log.Error(err))
+			}
+
+			r.observeLag(ctx)
+		}
+	}
+}
+
+// RelayOnce publishes one batch of pending messages.
+func (r *Relay) RelayOnce(ctx context.Context) error {
+	return r.txManager.Do(ctx, func(ctx context.Context) error {
+		locked, err := r.repo.TryLockRelay(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		messages, err := r.repo.ListPending(ctx, relayBatchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		blocked := make(map[string]struct{})
+
+		for _, msg := range messages {
+			if _, ok := blocked[msg.Key]; ok {
+				continue
+			}
+
+			if msg.NextAttemptAt.After(now) {
+				blocked[msg.Key] = struct{}{}
+				continue
+			}
+
+			if err := r.publish(ctx, msg, now); err != nil {
+				blocked[msg.Key] = struct{}{}
+
+				if err := r.repo.MarkFailed(ctx, msg.ID, now.Add(retryBackoff(msg.Attempts)), err.Error()); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if err := r.repo.Delete(ctx, msg.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Relay) publish(ctx context.Context, msg models.OutboxMessage, now time.Time) error {
+	err := r.publisher.Publish(ctx, kafka.Message{
+		Key:     msg.Key,
+		Type:    msg.EventType,
+		Value:   msg.Payload,
+		Headers: msg.Headers,
+	})
+	if err != nil {
+		r.logger.Warn("failed to publish outbox message",
+			log.Int64("outbox_id", msg.ID),
+			log.String("event_type", msg.EventType),
+			log.Int("attempts", msg.Attempts+1),
+			log.Error(err),
+		)
+
+		if r.metrics != nil {
+			r.metrics.OutboxPublishErrors.Inc()
+		}
+
+		return err
+	}
+
+	if r.metrics != nil {
+		r.metrics.OutboxPublished.Inc()
+	}
+
+	r.logger.Debug("outbox message published",
+		log.Int64("outbox_id", msg.ID),
+		log.Duration("delay", now.Sub(msg.CreatedAt)),
+	)
+
+	return nil
+}
+
+func (r *Relay) observeLag(ctx context.Context) {
+	if r.metrics == nil {
+		return
+	}
+
+	pending, oldest, err := r.repo.Stats(ctx)
+	if err != nil {
+		r.logger.Error("failed to read outbox stats", log.Error(err))
+		return
+	}
+
+	r.metrics.OutboxPending.Set(float64(pending))
+
+	if pending == 0 {
+		r.metrics.OutboxLag.Set(0)
+		return
+	}
+
+	r.metrics.OutboxLag.Set(time.Since(oldest).Seconds())
+}
+
+func retryBackoff(attempts int) time.Duration {
+	backoff := minRetryBackoff
+	for i := 0; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRetryBackoff)
+}
@@ -0,0 +1,173 @@
+package outbox_test
+
+import (
+	"context"
+	stdErr "errors"
+	"stocks/internal/kafka"
+	"stocks/internal/log/zap"
+	"stocks/internal/models"
+	"stocks/internal/outbox"
+	"stocks/internal/repository/mocks"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/golang/mock/gomock"
+)
+
+type mockTxManager struct{}
+
+func (m *mockTxManager) Do(ctx context.Context, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
+
+func (m *mockTxManager) DoWithSettings(ctx context.Context, settings trm.Settings, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
+
+type fakeSink struct {
+	mu        sync.Mutex
+	failKeys  map[string]bool
+	published []kafka.Message
+}
+
+func (s *fakeSink) Publish(_ context.Context, msg kafka.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failKeys[msg.Key] {
+		return stdErr.New("broker unavailable")
+	}
+
+	s.published = append(s.published, msg)
+
+	return nil
+}
+
+func (s *fakeSink) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.published))
+	for _, msg := range s.published {
+		keys = append(keys, msg.Key)
+	}
+
+	return keys
+}
+
+func TestRelay_RelayOnce(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		failKeys  map[string]bool
+		mockSetup func(repo *mocks.MockOutboxRepository)
+		wantKeys  []string
+		wantErr   bool
+	}{
+		{
+			name: "publishes and deletes pending messages in order",
+			mockSetup: func(repo *mocks.MockOutboxRepository) {
+				repo.EXPECT().TryLockRelay(gomock.Any()).Return(true, nil)
+				repo.EXPECT().ListPending(gomock.Any(), gomock.Any()).Return([]models.OutboxMessage{
+					{ID: 1, Key: "1001", EventType: "sku_created", NextAttemptAt: past},
+					{ID: 2, Key: "1001", EventType: "stock_changed", NextAttemptAt: past},
+					{ID: 3, Key: "2002", EventType: "stock_changed", NextAttemptAt: past},
+				}, nil)
+				gomock.InOrder(
+					repo.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil),
+					repo.EXPECT().Delete(gomock.Any(), int64(2)).Return(nil),
+					repo.EXPECT().Delete(gomock.Any(), int64(3)).Return(nil),
+				)
+			},
+			wantKeys: []string{"1001", "1001", "2002"},
+		},
+		{
+			name:     "failed message blocks its key only",
+			failKeys: map[string]bool{"1001": true},
+			mockSetup: func(repo *mocks.MockOutboxRepository) {
+				repo.EXPECT().TryLockRelay(gomock.Any()).Return(true, nil)
+				repo.EXPECT().ListPending(gomock.Any(), gomock.Any()).Return([]models.OutboxMessage{
+					{ID: 1, Key: "1001", EventType: "stock_changed", NextAttemptAt: past},
+					{ID: 2, Key: "2002", EventType: "stock_changed", NextAttemptAt: past},
+					{ID: 3, Key: "1001", EventType: "stock_changed", NextAttemptAt: past},
+				}, nil)
+				repo.EXPECT().MarkFailed(gomock.Any(), int64(1), gomock.Any(), "broker unavailable").Return(nil)
+				repo.EXPECT().Delete(gomock.Any(), int64(2)).Return(nil)
+			},
+			wantKeys: []string{"2002"},
+		},
+		{
+			name: "message waiting for retry blocks its key",
+			mockSetup: func(repo *mocks.MockOutboxRepository) {
+				repo.EXPECT().TryLockRelay(gomock.Any()).Return(true, nil)
+				repo.EXPECT().ListPending(gomock.Any(), gomock.Any()).Return([]models.OutboxMessage{
+					{ID: 1, Key: "1001", EventType: "stock_changed", Attempts: 2, NextAttemptAt: future},
+					{ID: 2, Key: "1001", EventType: "stock_changed", NextAttemptAt: past},
+					{ID: 3, Key: "2002", EventType: "stock_changed", NextAttemptAt: past},
+				}, nil)
+				repo.EXPECT().Delete(gomock.Any(), int64(3)).Return(nil)
+			},
+			wantKeys: []string{"2002"},
+		},
+		{
+			name: "another relay holds the lock",
+			mockSetup: func(repo *mocks.MockOutboxRepository) {
+				repo.EXPECT().TryLockRelay(gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name: "delete error",
+			mockSetup: func(repo *mocks.MockOutboxRepository) {
+				repo.EXPECT().TryLockRelay(gomock.Any()).Return(true, nil)
+				repo.EXPECT().ListPending(gomock.Any(), gomock.Any()).Return([]models.OutboxMessage{
+					{ID: 1, Key: "1001", EventType: "stock_changed", NextAttemptAt: past},
+				}, nil)
+				repo.EXPECT().Delete(gomock.Any(), int64(1)).Return(stdErr.New("db error"))
+			},
+			wantKeys: []string{"1001"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger, cleanup, err := zap.NewLogger()
+			if err != nil {
+				t.Fatalf("failed to create logger: %v", err)
+			}
+			defer cleanup()
+
+			repo := mocks.NewMockOutboxRepository(ctrl)
+			tt.mockSetup(repo)
+
+			sink := &fakeSink{failKeys: tt.failKeys}
+			relay := outbox.NewRelay(repo, &mockTxManager{}, sink, time.Second, nil, logger)
+
+			err = relay.RelayOnce(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RelayOnce() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := sink.keys()
+			if len(got) != len(tt.wantKeys) {
+				t.Fatalf("published keys = %v, want %v", got, tt.wantKeys)
+			}
+
+			for i := range got {
+				if got[i] != tt.wantKeys[i] {
+					t.Fatalf("published keys = %v, want %v", got, tt.wantKeys)
+				}
+			}
+		})
+	}
+}
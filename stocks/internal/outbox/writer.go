@@ -0,0 +1,29 @@
+package outbox
+
+import (
+	"context"
+
+	"stocks/internal/kafka"
+	"stocks/internal/models"
+	"stocks/internal/repository"
+)
+
+// Writer is a kafka.Sink that stores messages in the outbox table. Enqueue
+// goes through the transaction in ctx, so an event only becomes visible to the
+// relay if the change that produced it commits.
+type Writer struct {
+	repo repository.OutboxRepository
+}
+
+func NewWriter(repo repository.OutboxRepository) *Writer {
+	return &Writer{repo: repo}
+}
+
+func (w *Writer) Publish(ctx context.Context, msg kafka.Message) error {
+	return w.repo.Enqueue(ctx, models.OutboxMessage{
+		Key:       msg.Key,
+		EventType: msg.Type,
+		Payload:   msg.Value,
+		Headers:   msg.Headers,
+	})
+}
@@ -0,0 +1,150 @@
+// Package policy decides which stock operations the caller in the context may
+// perform. Admins may do anything, sellers manage only the stock rows they
+// own, buyers may read stock and reserve and release it but not change it,
+// and services may write sold stock off and commit reservations.
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"stocks/internal/auth"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+)
+
+func caller(ctx context.Context) (auth.Identity, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return auth.Identity{}, deny("no authenticated caller")
+	}
+
+	return identity, nil
+}
+
+func deny(reason string) error {
+	return fmt.Errorf("%w: %s", errors.ErrPermissionDenied, reason)
+}
+
+// CreateStock allows admins and sellers to put new stock rows on sale.
+func CreateStock(ctx context.Context) error {
+	identity, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.HasRole(auth.RoleAdmin) || identity.HasRole(auth.RoleSeller) {
+		return nil
+	}
+
+	return deny("buyers have read-only access to stock")
+}
+
+// ManageStock allows admins to change any stock row and sellers to change the
+// rows they own.
+func ManageStock(ctx context.Context, ownerID int64) error {
+	identity, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case identity.HasRole(auth.RoleAdmin):
+		return nil
+	case identity.HasRole(auth.RoleSeller):
+		if identity.UserID == ownerID {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %w", errors.ErrPermissionDenied, errors.ErrOwnershipViolation)
+	default:
+		return deny("buyers have read-only access to stock")
+	}
+}
+
+// ReduceStock allows admins and services to write stock off. Buyers buy
+// through cart, which reduces stock as a service at checkout.
+func ReduceStock(ctx context.Context) error {
+	identity, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.HasRole(auth.RoleAdmin) || identity.HasRole(auth.RoleService) {
+		return nil
+	}
+
+	return deny("buyers have read-only access to stock")
+}
+
+// ManageCatalog allows only admins to change the SKU catalog.
+func ManageCatalog(ctx context.Context) error {
+	identity, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+
+	return deny("only admins can manage the SKU catalog")
+}
+
+// ManageReservation allows the user who placed a reservation and admins to
+// release it.
+func ManageReservation(ctx context.Context, ownerID int64) error {
+	identity, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.HasRole(auth.RoleAdmin) || identity.UserID == ownerID {
+		return nil
+	}
+
+	return deny("reservation belongs to another user")
+}
+
+// CommitReservation allows admins and services to commit a reservation.
+// Committing writes the reserved stock off, so it is gated like ReduceStock
+// rather than by who placed the reservation.
+func CommitReservation(ctx context.Context) error {
+	identity, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.HasRole(auth.RoleAdmin) || identity.HasRole(auth.RoleService) {
+		return nil
+	}
+
+	return deny("only admins and services can commit reservations")
+}
+
+// ListMovements lets admins audit every movement and sellers the movements of
+// their own stock rows, whoever made them. A seller's filter is narrowed to
+// the rows they own.
+func ListMovements(ctx context.Context, filter *models.MovementFilter) error {
+	identity, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case identity.HasRole(auth.RoleAdmin):
+		return nil
+	case identity.HasRole(auth.RoleSeller):
+		if filter.OwnerID == 0 {
+			filter.OwnerID = identity.UserID
+		}
+
+		if filter.OwnerID != identity.UserID {
+			return deny("sellers can only list movements of their own stock")
+		}
+
+		return nil
+	default:
+		return deny("buyers cannot list stock movements")
+	}
+}
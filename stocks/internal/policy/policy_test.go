@@ -0,0 +1,158 @@
+package policy_test
+
+import (
+	"context"
+	stdErr "errors"
+	"testing"
+
+	"stocks/internal/auth"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+	"stocks/internal/policy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func callerContext(userID int64, roles ...auth.Role) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{UserID: userID, Roles: roles})
+}
+
+func TestManageStock(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		ownerID int64
+		wantErr error
+	}{
+		{name: "admin manages any row", ctx: callerContext(1, auth.RoleAdmin), ownerID: 2},
+		{name: "seller manages own row", ctx: callerContext(2, auth.RoleSeller), ownerID: 2},
+		{
+			name:    "seller cannot manage another seller's row",
+			ctx:     callerContext(3, auth.RoleSeller),
+			ownerID: 2,
+			wantErr: errors.ErrOwnershipViolation,
+		},
+		{
+			name:    "buyer is read-only",
+			ctx:     callerContext(2, auth.RoleBuyer),
+			ownerID: 2,
+			wantErr: errors.ErrPermissionDenied,
+		},
+		{
+			name:    "no caller",
+			ctx:     context.Background(),
+			ownerID: 2,
+			wantErr: errors.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := policy.ManageStock(tt.ctx, tt.ownerID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, stdErr.Is(err, errors.ErrPermissionDenied))
+		})
+	}
+}
+
+func TestCreateStock(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, policy.CreateStock(callerContext(1, auth.RoleAdmin)))
+	assert.NoError(t, policy.CreateStock(callerContext(1, auth.RoleSeller)))
+	assert.ErrorIs(t, policy.CreateStock(callerContext(1, auth.RoleBuyer)), errors.ErrPermissionDenied)
+}
+
+func TestReduceStock(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, policy.ReduceStock(callerContext(1, auth.RoleAdmin)))
+	assert.NoError(t, policy.ReduceStock(callerContext(1, auth.RoleService)))
+	assert.ErrorIs(t, policy.ReduceStock(callerContext(1, auth.RoleSeller)), errors.ErrPermissionDenied)
+	assert.ErrorIs(t, policy.ReduceStock(callerContext(1, auth.RoleBuyer)), errors.ErrPermissionDenied)
+	assert.ErrorIs(t, policy.ReduceStock(context.Background()), errors.ErrPermissionDenied)
+}
+
+func TestManageCatalog(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, policy.ManageCatalog(callerContext(1, auth.RoleAdmin)))
+	assert.ErrorIs(t, policy.ManageCatalog(callerContext(1, auth.RoleSeller)), errors.ErrPermissionDenied)
+	assert.ErrorIs(t, policy.ManageCatalog(callerContext(1, auth.RoleBuyer)), errors.ErrPermissionDenied)
+}
+
+func TestManageReservation(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, policy.ManageReservation(callerContext(7, auth.RoleBuyer), 7))
+	assert.NoError(t, policy.ManageReservation(callerContext(1, auth.RoleAdmin), 7))
+	assert.ErrorIs(t, policy.ManageReservation(callerContext(8, auth.RoleSeller), 7), errors.ErrPermissionDenied)
+}
+
+func TestCommitReservation(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, policy.CommitReservation(callerContext(1, auth.RoleAdmin)))
+	assert.NoError(t, policy.CommitReservation(callerContext(1, auth.RoleService)))
+	assert.ErrorIs(t, policy.CommitReservation(callerContext(7, auth.RoleBuyer)), errors.ErrPermissionDenied,
+		"a buyer cannot commit even their own reservation")
+	assert.ErrorIs(t, policy.CommitReservation(callerContext(8, auth.RoleSeller)), errors.ErrPermissionDenied)
+	assert.ErrorIs(t, policy.CommitReservation(context.Background()), errors.ErrPermissionDenied)
+}
+
+func TestListMovements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		filter      models.MovementFilter
+		wantUserID  int64
+		wantOwnerID int64
+		wantErr     bool
+	}{
+		{name: "admin lists everything", ctx: callerContext(1, auth.RoleAdmin)},
+		{name: "admin filters by user", ctx: callerContext(1, auth.RoleAdmin), filter: models.MovementFilter{UserID: 5}, wantUserID: 5},
+		{name: "admin filters by owner", ctx: callerContext(1, auth.RoleAdmin), filter: models.MovementFilter{OwnerID: 5}, wantOwnerID: 5},
+		{name: "seller is narrowed to own stock", ctx: callerContext(5, auth.RoleSeller), wantOwnerID: 5},
+		{
+			name:        "seller filters their stock by buyer",
+			ctx:         callerContext(5, auth.RoleSeller),
+			filter:      models.MovementFilter{UserID: 6},
+			wantUserID:  6,
+			wantOwnerID: 5,
+		},
+		{
+			name:    "seller cannot list another owner",
+			ctx:     callerContext(5, auth.RoleSeller),
+			filter:  models.MovementFilter{OwnerID: 6},
+			wantErr: true,
+		},
+		{name: "buyer cannot list", ctx: callerContext(5, auth.RoleBuyer), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := tt.filter
+
+			err := policy.ListMovements(tt.ctx, &filter)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errors.ErrPermissionDenied)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUserID, filter.UserID)
+			assert.Equal(t, tt.wantOwnerID, filter.OwnerID)
+		})
+	}
+}
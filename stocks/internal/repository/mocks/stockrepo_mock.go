@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 	models "stocks/internal/models"
+	money "stocks/internal/money"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,18 +36,110 @@ func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ArchiveSKU mocks base method.
+func (m *MockStockRepository) ArchiveSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveSKU indicates an expected call of ArchiveSKU.
+func (mr *MockStockRepositoryMockRecorder) ArchiveSKU(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveSKU", reflect.TypeOf((*MockStockRepository)(nil).ArchiveSKU), ctx, sku)
+}
+
+// CreateReservation mocks base method.
+func (m *MockStockRepository) CreateReservation(ctx context.Context, reservation models.Reservation) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReservation", ctx, reservation)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReservation indicates an expected call of CreateReservation.
+func (mr *MockStockRepositoryMockRecorder) CreateReservation(ctx, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReservation", reflect.TypeOf((*MockStockRepository)(nil).CreateReservation), ctx, reservation)
+}
+
+// CreateSKU mocks base method.
+func (m *MockStockRepository) CreateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSKU indicates an expected call of CreateSKU.
+func (mr *MockStockRepositoryMockRecorder) CreateSKU(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSKU", reflect.TypeOf((*MockStockRepository)(nil).CreateSKU), ctx, sku)
+}
+
+// DecreaseCount mocks base method.
+func (m *MockStockRepository) DecreaseCount(ctx context.Context, sku uint32, count uint16) (models.StockItem, []models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseCount", ctx, sku, count)
+	ret0, _ := ret[0].(models.StockItem)
+	ret1, _ := ret[1].([]models.StockMovement)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DecreaseCount indicates an expected call of DecreaseCount.
+func (mr *MockStockRepositoryMockRecorder) DecreaseCount(ctx, sku, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseCount", reflect.TypeOf((*MockStockRepository)(nil).DecreaseCount), ctx, sku, count)
+}
+
 // Delete mocks base method.
-func (m *MockStockRepository) Delete(ctx context.Context, sku uint32) error {
+func (m *MockStockRepository) Delete(ctx context.Context, sku uint32, location string) ([]models.StockItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, sku)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Delete", ctx, sku, location)
+	ret0, _ := ret[0].([]models.StockItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockStockRepositoryMockRecorder) Delete(ctx, sku interface{}) *gomock.Call {
+func (mr *MockStockRepositoryMockRecorder) Delete(ctx, sku, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStockRepository)(nil).Delete), ctx, sku, location)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockStockRepository) ExpireReservations(ctx context.Context) ([]models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations", ctx)
+	ret0, _ := ret[0].([]models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockStockRepositoryMockRecorder) ExpireReservations(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStockRepository)(nil).Delete), ctx, sku)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockStockRepository)(nil).ExpireReservations), ctx)
+}
+
+// GetAvailableForUpdate mocks base method.
+func (m *MockStockRepository) GetAvailableForUpdate(ctx context.Context, sku uint32) (uint16, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableForUpdate", ctx, sku)
+	ret0, _ := ret[0].(uint16)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableForUpdate indicates an expected call of GetAvailableForUpdate.
+func (mr *MockStockRepositoryMockRecorder) GetAvailableForUpdate(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableForUpdate", reflect.TypeOf((*MockStockRepository)(nil).GetAvailableForUpdate), ctx, sku)
 }
 
 // GetBySKU mocks base method.
@@ -64,35 +157,93 @@ func (mr *MockStockRepositoryMockRecorder) GetBySKU(ctx, sku interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockStockRepository)(nil).GetBySKU), ctx, sku)
 }
 
-// GetByUserSKU mocks base method.
-func (m *MockStockRepository) GetByUserSKU(ctx context.Context, userID int64, sku uint32) (models.StockItem, error) {
+// GetBySKULocation mocks base method.
+func (m *MockStockRepository) GetBySKULocation(ctx context.Context, sku uint32, location string) (models.StockItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByUserSKU", ctx, userID, sku)
+	ret := m.ctrl.Call(m, "GetBySKULocation", ctx, sku, location)
 	ret0, _ := ret[0].(models.StockItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByUserSKU indicates an expected call of GetByUserSKU.
-func (mr *MockStockRepositoryMockRecorder) GetByUserSKU(ctx, userID, sku interface{}) *gomock.Call {
+// GetBySKULocation indicates an expected call of GetBySKULocation.
+func (mr *MockStockRepositoryMockRecorder) GetBySKULocation(ctx, sku, location interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserSKU", reflect.TypeOf((*MockStockRepository)(nil).GetByUserSKU), ctx, userID, sku)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKULocation", reflect.TypeOf((*MockStockRepository)(nil).GetBySKULocation), ctx, sku, location)
 }
 
-// GetSKUInfo mocks base method.
-func (m *MockStockRepository) GetSKUInfo(ctx context.Context, sku uint32) (string, string, error) {
+// GetBySKUs mocks base method.
+func (m *MockStockRepository) GetBySKUs(ctx context.Context, skus []uint32) ([]models.StockItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSKUInfo", ctx, sku)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetBySKUs", ctx, skus)
+	ret0, _ := ret[0].([]models.StockItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetSKUInfo indicates an expected call of GetSKUInfo.
-func (mr *MockStockRepositoryMockRecorder) GetSKUInfo(ctx, sku interface{}) *gomock.Call {
+// GetBySKUs indicates an expected call of GetBySKUs.
+func (mr *MockStockRepositoryMockRecorder) GetBySKUs(ctx, skus interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSKUInfo", reflect.TypeOf((*MockStockRepository)(nil).GetSKUInfo), ctx, sku)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKUs", reflect.TypeOf((*MockStockRepository)(nil).GetBySKUs), ctx, skus)
+}
+
+// GetReservationForUpdate mocks base method.
+func (m *MockStockRepository) GetReservationForUpdate(ctx context.Context, reservationID int64) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservationForUpdate", ctx, reservationID)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservationForUpdate indicates an expected call of GetReservationForUpdate.
+func (mr *MockStockRepositoryMockRecorder) GetReservationForUpdate(ctx, reservationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservationForUpdate", reflect.TypeOf((*MockStockRepository)(nil).GetReservationForUpdate), ctx, reservationID)
+}
+
+// GetSKU mocks base method.
+func (m *MockStockRepository) GetSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSKU indicates an expected call of GetSKU.
+func (mr *MockStockRepositoryMockRecorder) GetSKU(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSKU", reflect.TypeOf((*MockStockRepository)(nil).GetSKU), ctx, sku)
+}
+
+// GetSKUForUpdate mocks base method.
+func (m *MockStockRepository) GetSKUForUpdate(ctx context.Context, sku uint32) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSKUForUpdate", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSKUForUpdate indicates an expected call of GetSKUForUpdate.
+func (mr *MockStockRepositoryMockRecorder) GetSKUForUpdate(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSKUForUpdate", reflect.TypeOf((*MockStockRepository)(nil).GetSKUForUpdate), ctx, sku)
+}
+
+// InsertMovement mocks base method.
+func (m *MockStockRepository) InsertMovement(ctx context.Context, movement models.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMovement", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertMovement indicates an expected call of InsertMovement.
+func (mr *MockStockRepositoryMockRecorder) InsertMovement(ctx, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMovement", reflect.TypeOf((*MockStockRepository)(nil).InsertMovement), ctx, movement)
 }
 
 // InsertStockItem mocks base method.
@@ -110,30 +261,89 @@ func (mr *MockStockRepositoryMockRecorder) InsertStockItem(ctx, item interface{}
 }
 
 // ListByLocation mocks base method.
-func (m *MockStockRepository) ListByLocation(ctx context.Context, location string, pageSize, currentPage int64) ([]models.StockItem, error) {
+func (m *MockStockRepository) ListByLocation(ctx context.Context, filter models.LocationFilter) ([]models.StockItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByLocation", ctx, location, pageSize, currentPage)
+	ret := m.ctrl.Call(m, "ListByLocation", ctx, filter)
 	ret0, _ := ret[0].([]models.StockItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByLocation indicates an expected call of ListByLocation.
-func (mr *MockStockRepositoryMockRecorder) ListByLocation(ctx, location, pageSize, currentPage interface{}) *gomock.Call {
+func (mr *MockStockRepositoryMockRecorder) ListByLocation(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLocation", reflect.TypeOf((*MockStockRepository)(nil).ListByLocation), ctx, location, pageSize, currentPage)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLocation", reflect.TypeOf((*MockStockRepository)(nil).ListByLocation), ctx, filter)
+}
+
+// ListMovements mocks base method.
+func (m *MockStockRepository) ListMovements(ctx context.Context, filter models.MovementFilter) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, filter)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockStockRepositoryMockRecorder) ListMovements(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockStockRepository)(nil).ListMovements), ctx, filter)
+}
+
+// ListSKUs mocks base method.
+func (m *MockStockRepository) ListSKUs(ctx context.Context, filter models.SKUFilter) ([]models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSKUs", ctx, filter)
+	ret0, _ := ret[0].([]models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSKUs indicates an expected call of ListSKUs.
+func (mr *MockStockRepositoryMockRecorder) ListSKUs(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSKUs", reflect.TypeOf((*MockStockRepository)(nil).ListSKUs), ctx, filter)
 }
 
 // UpdateCount mocks base method.
-func (m *MockStockRepository) UpdateCount(ctx context.Context, userID int64, sku uint32, newCount uint16, price float64) error {
+func (m *MockStockRepository) UpdateCount(ctx context.Context, sku uint32, location string, newCount uint16, price money.Money) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCount", ctx, userID, sku, newCount, price)
+	ret := m.ctrl.Call(m, "UpdateCount", ctx, sku, location, newCount, price)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCount indicates an expected call of UpdateCount.
-func (mr *MockStockRepositoryMockRecorder) UpdateCount(ctx, userID, sku, newCount, price interface{}) *gomock.Call {
+func (mr *MockStockRepositoryMockRecorder) UpdateCount(ctx, sku, location, newCount, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCount", reflect.TypeOf((*MockStockRepository)(nil).UpdateCount), ctx, sku, location, newCount, price)
+}
+
+// UpdateReservationStatus mocks base method.
+func (m *MockStockRepository) UpdateReservationStatus(ctx context.Context, reservationID int64, status models.ReservationStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReservationStatus", ctx, reservationID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReservationStatus indicates an expected call of UpdateReservationStatus.
+func (mr *MockStockRepositoryMockRecorder) UpdateReservationStatus(ctx, reservationID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReservationStatus", reflect.TypeOf((*MockStockRepository)(nil).UpdateReservationStatus), ctx, reservationID, status)
+}
+
+// UpdateSKU mocks base method.
+func (m *MockStockRepository) UpdateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSKU indicates an expected call of UpdateSKU.
+func (mr *MockStockRepositoryMockRecorder) UpdateSKU(ctx, sku interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCount", reflect.TypeOf((*MockStockRepository)(nil).UpdateCount), ctx, userID, sku, newCount, price)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSKU", reflect.TypeOf((*MockStockRepository)(nil).UpdateSKU), ctx, sku)
 }
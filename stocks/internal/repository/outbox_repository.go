@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"stocks/internal/models"
+	"time"
+)
+
+//go:generate mockgen -source=internal/repository/outbox_repository.go -destination=internal/repository/mocks/outboxrepo_mock.go -package=mocks
+
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, msg models.OutboxMessage) error
+	TryLockRelay(ctx context.Context) (bool, error)
+	ListPending(ctx context.Context, limit int) ([]models.OutboxMessage, error)
+	Delete(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+	Stats(ctx context.Context) (pending int64, oldest time.Time, err error)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const skuColumns = `sku, name, type, currency, archived_at, created_at, updated_at`
+
+const uniqueViolation = "23505"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSKU(row rowScanner) (models.SKU, error) {
+	var (
+		sku        models.SKU
+		archivedAt sql.NullTime
+	)
+
+	err := row.Scan(&sku.SKU, &sku.Name, &sku.Type, &sku.Currency, &archivedAt, &sku.CreatedAt, &sku.UpdatedAt)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return models.SKU{}, errors.ErrSKUNotFound
+	}
+
+	if err != nil {
+		return models.SKU{}, err
+	}
+
+	if archivedAt.Valid {
+		sku.ArchivedAt = archivedAt.Time
+	}
+
+	return sku, nil
+}
+
+func (r *PostgresStockRepo) GetSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	return scanSKU(r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+skuColumns+`
+		FROM sku_info
+		WHERE sku = $1
+	`, sku))
+}
+
+func (r *PostgresStockRepo) GetSKUForUpdate(ctx context.Context, sku uint32) (models.SKU, error) {
+	return scanSKU(r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+skuColumns+`
+		FROM sku_info
+		WHERE sku = $1
+		FOR UPDATE
+	`, sku))
+}
+
+func (r *PostgresStockRepo) CreateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	created, err := scanSKU(r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO sku_info (sku, name, type, currency)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+skuColumns+`
+	`, sku.SKU, sku.Name, sku.Type, sku.Currency))
+
+	var pqErr *pq.Error
+	if stdErrors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return models.SKU{}, errors.ErrSKUExists
+	}
+
+	return created, err
+}
+
+func (r *PostgresStockRepo) UpdateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	return scanSKU(r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE sku_info
+		SET name = $2, type = $3, updated_at = now()
+		WHERE sku = $1
+		RETURNING `+skuColumns+`
+	`, sku.SKU, sku.Name, sku.Type))
+}
+
+func (r *PostgresStockRepo) ArchiveSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	return scanSKU(r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE sku_info
+		SET archived_at = now(), updated_at = now()
+		WHERE sku = $1
+		RETURNING `+skuColumns+`
+	`, sku))
+}
+
+// ListSKUs pages through the catalog in SKU order; filter.AfterSKU is the last
+// SKU of the previous page.
+func (r *PostgresStockRepo) ListSKUs(ctx context.Context, filter models.SKUFilter) ([]models.SKU, error) {
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		SELECT `+skuColumns+`
+		FROM sku_info
+		WHERE ($1 = '' OR type = $1)
+		  AND ($2 OR archived_at IS NULL)
+		  AND sku > $3
+		ORDER BY sku
+		LIMIT $4
+	`, filter.Type, filter.IncludeArchived, filter.AfterSKU, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	skus := make([]models.SKU, 0)
+
+	for rows.Next() {
+		sku, err := scanSKU(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		skus = append(skus, sku)
+	}
+
+	return skus, rows.Err()
+}
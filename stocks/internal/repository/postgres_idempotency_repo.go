@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"stocks/internal/models"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sql/v2"
+)
+
+type PostgresIdempotencyRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewPostgresIdempotencyRepo(db *sqlx.DB, getter *trmsqlx.CtxGetter) *PostgresIdempotencyRepo {
+	return &PostgresIdempotencyRepo{db: db, getter: getter}
+}
+
+// Reserve claims key for a request. An expired key is claimed afresh; a live
+// one is left alone and its record returned with false.
+func (r *PostgresIdempotencyRepo) Reserve(ctx context.Context, scope, key string, requestHash []byte, ttl time.Duration) (models.IdempotencyRecord, bool, error) {
+	db := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var reserved bool
+
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO idempotency_keys (scope, key, request_hash, expires_at)
+		VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+		ON CONFLICT (scope, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			response_type = '',
+			response = NULL,
+			created_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= now()
+		RETURNING true
+	`, scope, key, requestHash, ttl.Seconds()).Scan(&reserved)
+	if err == nil {
+		return models.IdempotencyRecord{RequestHash: requestHash}, true, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.IdempotencyRecord{}, false, err
+	}
+
+	var record models.IdempotencyRecord
+
+	err = db.QueryRowContext(ctx, `
+		SELECT request_hash, response_type, response
+		FROM idempotency_keys
+		WHERE scope = $1 AND key = $2
+	`, scope, key).Scan(&record.RequestHash, &record.ResponseType, &record.Response)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released between the two statements: the request is still running
+		// somewhere or has just failed; either way the caller should retry.
+		return models.IdempotencyRecord{RequestHash: requestHash}, false, nil
+	}
+
+	if err != nil {
+		return models.IdempotencyRecord{}, false, err
+	}
+
+	return record, false, nil
+}
+
+func (r *PostgresIdempotencyRepo) Complete(ctx context.Context, scope, key, responseType string, response []byte) error {
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET response_type = $3, response = $4
+		WHERE scope = $1 AND key = $2
+	`, scope, key, responseType, response)
+
+	return err
+}
+
+func (r *PostgresIdempotencyRepo) Release(ctx context.Context, scope, key string) error {
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2`, scope, key)
+
+	return err
+}
+
+func (r *PostgresIdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= now()`)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
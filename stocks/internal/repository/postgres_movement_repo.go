@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"stocks/internal/models"
+)
+
+func (r *PostgresStockRepo) InsertMovement(ctx context.Context, movement models.StockMovement) error {
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO stock_movements (sku, location, delta, reason, user_id, owner_id, trace_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, movement.SKU, movement.Location, movement.Delta, movement.Reason, movement.UserID, movement.OwnerID, movement.TraceID)
+
+	return err
+}
+
+// ListMovements returns the newest movements first. Zero-valued filter fields
+// match everything.
+func (r *PostgresStockRepo) ListMovements(ctx context.Context, filter models.MovementFilter) ([]models.StockMovement, error) {
+	from := sql.NullTime{Time: filter.From, Valid: !filter.From.IsZero()}
+	to := sql.NullTime{Time: filter.To, Valid: !filter.To.IsZero()}
+
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		SELECT movement_id, sku, location, delta, reason, user_id, owner_id, trace_id, created_at
+		FROM stock_movements
+		WHERE ($1 = 0 OR sku = $1)
+		  AND ($2 = '' OR location = $2)
+		  AND ($3 = 0 OR user_id = $3)
+		  AND ($4 = 0 OR owner_id = $4)
+		  AND ($5::timestamptz IS NULL OR created_at >= $5)
+		  AND ($6::timestamptz IS NULL OR created_at < $6)
+		  AND ($7 = 0 OR movement_id < $7)
+		ORDER BY movement_id DESC
+		LIMIT $8
+	`, filter.SKU, filter.Location, filter.UserID, filter.OwnerID, from, to, filter.BeforeID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	movements := make([]models.StockMovement, 0)
+
+	for rows.Next() {
+		var movement models.StockMovement
+
+		err := rows.Scan(
+			&movement.ID,
+			&movement.SKU,
+			&movement.Location,
+			&movement.Delta,
+			&movement.Reason,
+			&movement.UserID,
+			&movement.OwnerID,
+			&movement.TraceID,
+			&movement.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		movements = append(movements, movement)
+	}
+
+	return movements, rows.Err()
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"stocks/internal/models"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sql/v2"
+)
+
+// outboxRelayLockKey is the advisory lock that keeps a single relay
+// publishing at a time, which is what preserves per-key ordering.
+const outboxRelayLockKey = 7_001_001
+
+type PostgresOutboxRepo struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewPostgresOutboxRepo(db *sqlx.DB, getter *trmsqlx.CtxGetter) *PostgresOutboxRepo {
+	return &PostgresOutboxRepo{db: db, getter: getter}
+}
+
+func (r *PostgresOutboxRepo) Enqueue(ctx context.Context, msg models.OutboxMessage) error {
+	headers := []byte("{}")
+	if len(msg.Headers) > 0 {
+		var err error
+		if headers, err = json.Marshal(msg.Headers); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO outbox (message_key, event_type, payload, headers)
+		VALUES ($1, $2, $3, $4)
+	`, msg.Key, msg.EventType, msg.Payload, headers)
+
+	return err
+}
+
+// TryLockRelay takes a transaction-scoped lock, so it only makes sense inside
+// a transaction.
+func (r *PostgresOutboxRepo) TryLockRelay(ctx context.Context) (bool, error) {
+	var locked bool
+	err := r.getter.DefaultTrOrDB(ctx, r.db).
+		QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLockKey).Scan(&locked)
+
+	return locked, err
+}
+
+func (r *PostgresOutboxRepo) ListPending(ctx context.Context, limit int) ([]models.OutboxMessage, error) {
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		SELECT id, message_key, event_type, payload, headers, attempts, next_attempt_at, created_at
+		FROM outbox
+		ORDER BY id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	messages := make([]models.OutboxMessage, 0)
+
+	for rows.Next() {
+		var (
+			msg     models.OutboxMessage
+			headers []byte
+		)
+
+		err := rows.Scan(&msg.ID, &msg.Key, &msg.EventType, &msg.Payload, &headers, &msg.Attempts, &msg.NextAttemptAt, &msg.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(headers, &msg.Headers); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *PostgresOutboxRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, "DELETE FROM outbox WHERE id = $1", id)
+
+	return err
+}
+
+func (r *PostgresOutboxRepo) MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
+		UPDATE outbox
+		SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2
+		WHERE id = $3
+	`, nextAttemptAt, lastError, id)
+
+	return err
+}
+
+func (r *PostgresOutboxRepo) Stats(ctx context.Context) (int64, time.Time, error) {
+	var (
+		pending int64
+		oldest  sql.NullTime
+	)
+
+	err := r.getter.DefaultTrOrDB(ctx, r.db).
+		QueryRowContext(ctx, "SELECT COUNT(*), MIN(created_at) FROM outbox").Scan(&pending, &oldest)
+
+	return pending, oldest.Time, err
+}
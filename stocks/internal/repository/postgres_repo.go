@@ -4,14 +4,32 @@ import (
 	"context"
 	"database/sql"
 	stdErrors "errors"
+	"fmt"
+	"math"
+	"sort"
 	"stocks/internal/errors"
 	"stocks/internal/models"
+	"stocks/internal/money"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sql/v2"
 )
 
+const reservedCountExpr = `COALESCE((
+		SELECT SUM(ri.count)
+		FROM reservation_items ri
+		JOIN reservations r ON r.reservation_id = ri.reservation_id
+		WHERE ri.sku = s.sku AND r.status = 'active' AND r.expires_at > now()
+	), 0)`
+
+// aggregatedStockColumns collapses every location of s.sku into one row. The
+// price is the highest one across locations, and the count is capped so it
+// still fits the uint16 domain type. Callers group by s.sku and the sku_info
+// columns.
+const aggregatedStockColumns = `s.sku, i.name, i.type, i.archived_at IS NOT NULL, i.currency, MAX(s.price), LEAST(SUM(s.count), 65535), ` + reservedCountExpr
+
 type PostgresStockRepo struct {
 	db     *sqlx.DB
 	getter *trmsqlx.CtxGetter
@@ -33,94 +51,303 @@ func (r *PostgresStockRepo) InsertStockItem(ctx context.Context, item models.Sto
 	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
 		INSERT INTO stock_items (user_id, sku, price, count, location)
 		VALUES ($1, $2, $3, $4, $5)
-	`, item.UserID, item.SKU, item.Price, item.Count, item.Location)
+	`, item.UserID, item.SKU, item.Price.Decimal(), item.Count, item.Location)
 
 	return err
 }
 
-func (r *PostgresStockRepo) GetByUserSKU(ctx context.Context, userID int64, sku uint32) (models.StockItem, error) {
-	var item models.StockItem
+func (r *PostgresStockRepo) GetBySKULocation(ctx context.Context, sku uint32, location string) (models.StockItem, error) {
+	var row StockItemRow
 	err := r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
-        SELECT user_id, sku, price, count, location 
-        FROM stock_items
-        WHERE user_id = $1 AND sku = $2
-    `, userID, sku).Scan(&item.UserID, &item.SKU, &item.Price, &item.Count, &item.Location)
+		SELECT s.user_id, s.sku, i.name, i.type, i.archived_at IS NOT NULL, i.currency, s.price, s.count, s.location
+		FROM stock_items s
+		JOIN sku_info i ON s.sku = i.sku
+		WHERE s.sku = $1 AND s.location = $2
+	`, sku, location).Scan(&row.UserID, &row.SKU, &row.Name, &row.Type, &row.Archived, &row.Currency, &row.Price, &row.Count, &row.Location)
 
 	if stdErrors.Is(err, sql.ErrNoRows) {
 		return models.StockItem{}, errors.ErrItemNotFound
 	}
 
-	return item, err
+	return row.ToDomain(), err
 }
 
-func (r *PostgresStockRepo) UpdateCount(ctx context.Context, userID int64, sku uint32, newCount uint16, price float64) error {
+func (r *PostgresStockRepo) UpdateCount(ctx context.Context, sku uint32, location string, newCount uint16, price money.Money) error {
 	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
         UPDATE stock_items SET count = $1, price = $2
-        WHERE user_id = $3 AND sku = $4
-    `, newCount, price, userID, sku)
+        WHERE sku = $3 AND location = $4
+    `, newCount, price.Decimal(), sku, location)
 
 	return err
 }
 
-func (r *PostgresStockRepo) Delete(ctx context.Context, sku uint32) error {
-	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, "DELETE FROM stock_items WHERE sku = $1", sku)
+// DecreaseCount takes count units of sku from its locations, starting with the
+// best stocked one. It returns the aggregated item after the write-off and one
+// movement per location that was touched.
+func (r *PostgresStockRepo) DecreaseCount(ctx context.Context, sku uint32, count uint16) (models.StockItem, []models.StockMovement, error) {
+	rows, err := r.lockSKURows(ctx, sku)
+	if err != nil {
+		return models.StockItem{}, nil, err
+	}
+
+	if len(rows) == 0 {
+		return models.StockItem{}, nil, errors.ErrItemNotFound
+	}
+
+	reserved, err := r.reservedCount(ctx, sku)
 	if err != nil {
-		return err
+		return models.StockItem{}, nil, err
+	}
+
+	var total int64
+	for _, row := range rows {
+		total += int64(row.Count)
 	}
 
-	affected, err := res.RowsAffected()
+	if total-reserved < int64(count) {
+		return models.StockItem{}, nil, errors.ErrNotEnoughStock
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Count > rows[j].Count
+	})
+
+	item := models.StockItem{SKU: sku}
+	movements := make([]models.StockMovement, 0, 1)
+	remaining := count
+
+	for _, row := range rows {
+		if item.Price.Currency == "" || row.Price.Cents > item.Price.Cents {
+			item.Price = row.Price
+		}
+
+		take := min(remaining, row.Count)
+		if take == 0 {
+			continue
+		}
+
+		_, err = r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
+			UPDATE stock_items SET count = count - $1
+			WHERE sku = $2 AND location = $3
+		`, take, sku, row.Location)
+		if err != nil {
+			return models.StockItem{}, nil, err
+		}
+
+		movements = append(movements, models.StockMovement{
+			SKU:      sku,
+			Location: row.Location,
+			Delta:    -int32(take),
+			OwnerID:  row.UserID,
+		})
+		remaining -= take
+	}
+
+	item.Count = uint16(min(total-int64(count), math.MaxUint16))
+
+	return item, movements, nil
+}
+
+// Delete removes the sku from location, or from every location when location
+// is empty, and returns the removed rows.
+func (r *PostgresStockRepo) Delete(ctx context.Context, sku uint32, location string) ([]models.StockItem, error) {
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		DELETE FROM stock_items s
+		USING sku_info i
+		WHERE s.sku = i.sku AND s.sku = $1 AND ($2 = '' OR s.location = $2)
+		RETURNING s.user_id, s.sku, i.currency, s.price, s.count, s.location
+	`, sku, location)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if affected == 0 {
-		return errors.ErrItemNotFound
+	defer rows.Close()
+
+	deleted := make([]models.StockItem, 0)
+
+	for rows.Next() {
+		var row StockItemRow
+
+		err := rows.Scan(&row.UserID, &row.SKU, &row.Currency, &row.Price, &row.Count, &row.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		deleted = append(deleted, row.ToDomain())
 	}
 
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(deleted) == 0 {
+		return nil, errors.ErrItemNotFound
+	}
+
+	return deleted, nil
 }
 
 func (r *PostgresStockRepo) GetBySKU(ctx context.Context, sku uint32) (models.StockItem, error) {
-	var item models.StockItem
+	var row StockItemRow
 	err := r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
-		SELECT s.user_id, s.sku, i.name, i.type, s.price, s.count, s.location 
+		SELECT `+aggregatedStockColumns+`
 		FROM stock_items s
 		JOIN sku_info i ON s.sku = i.sku
 		WHERE s.sku = $1
-	`, sku).Scan(&item.UserID, &item.SKU, &item.Name, &item.Type, &item.Price, &item.Count, &item.Location)
+		GROUP BY s.sku, i.name, i.type, i.archived_at, i.currency
+	`, sku).Scan(&row.SKU, &row.Name, &row.Type, &row.Archived, &row.Currency, &row.Price, &row.Count, &row.Reserved)
 
 	if stdErrors.Is(err, sql.ErrNoRows) {
 		return models.StockItem{}, errors.ErrItemNotFound
 	}
 
-	return item, err
+	return row.ToDomain(), err
 }
 
-func (r *PostgresStockRepo) GetSKUInfo(ctx context.Context, sku uint32) (string, string, error) {
-	var name, typ string
-	err := r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx,
-		`SELECT name, type FROM sku_info WHERE sku = $1`,
-		sku,
-	).Scan(&name, &typ)
+func (r *PostgresStockRepo) GetBySKUs(ctx context.Context, skus []uint32) ([]models.StockItem, error) {
+	skuArray := make(pq.Int64Array, 0, len(skus))
+	for _, sku := range skus {
+		skuArray = append(skuArray, int64(sku))
+	}
 
-	if stdErrors.Is(err, sql.ErrNoRows) {
-		return "", "", errors.ErrItemNotFound
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		SELECT `+aggregatedStockColumns+`
+		FROM stock_items s
+		JOIN sku_info i ON s.sku = i.sku
+		WHERE s.sku = ANY($1)
+		GROUP BY s.sku, i.name, i.type, i.archived_at, i.currency
+		ORDER BY s.sku
+	`, skuArray)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	items := make([]models.StockItem, 0, len(skus))
+
+	for rows.Next() {
+		var row StockItemRow
+
+		err := rows.Scan(&row.SKU, &row.Name, &row.Type, &row.Archived, &row.Currency, &row.Price, &row.Count, &row.Reserved)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, row.ToDomain())
 	}
 
-	return name, typ, err
+	return items, rows.Err()
 }
 
-func (r *PostgresStockRepo) ListByLocation(ctx context.Context, location string, pageSize, currentPage int64) ([]models.StockItem, error) {
-	offset := (currentPage - 1) * pageSize
+// ListByLocation pages through the stock of one location with a keyset
+// cursor: filter.After is the last item of the previous page, compared on the
+// sort key and then on the SKU. Zero-valued filter fields match everything.
+func (r *PostgresStockRepo) ListByLocation(ctx context.Context, filter models.LocationFilter) ([]models.StockItem, error) {
+	column, key, desc := locationOrder(filter.Sort)
+
+	direction, cmp := "ASC", ">"
+	if desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	orderBy := "s.sku " + direction
+	if column != "" {
+		orderBy = column + " " + direction + ", " + orderBy
+	}
+
+	args := []interface{}{
+		filter.Location,
+		filter.Type,
+		filter.MinPrice.Decimal(),
+		filter.MaxPrice.Decimal(),
+		filter.MinCount,
+		filter.Limit,
+		filter.Currency,
+	}
+
+	var keyset string
+
+	if filter.After != nil {
+		if column == "" {
+			keyset = fmt.Sprintf("AND s.sku %s $8", cmp)
+			args = append(args, filter.After.SKU)
+		} else {
+			keyset = fmt.Sprintf("AND (%s, s.sku) %s ($8, $9)", column, cmp)
+			args = append(args, key(*filter.After), filter.After.SKU)
+		}
+	}
+
 	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
-		SELECT s.user_id, s.sku, i.name, i.type, s.price, s.count, s.location 
+		SELECT s.user_id, s.sku, i.name, i.type, i.archived_at IS NOT NULL, i.currency, s.price, s.count, s.location
 		FROM stock_items s
 		JOIN sku_info i ON s.sku = i.sku
 		WHERE s.location = $1
-		ORDER BY s.sku 
-		LIMIT $2 OFFSET $3
-	`, location, pageSize, offset)
+		  AND ($2 = '' OR i.type = $2)
+		  AND ($7 = '' OR i.currency = $7)
+		  AND ($3::numeric = 0 OR s.price >= $3)
+		  AND ($4::numeric = 0 OR s.price <= $4)
+		  AND s.count >= $5
+		  `+keyset+`
+		ORDER BY `+orderBy+`
+		LIMIT $6
+	`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	items := make([]models.StockItem, 0)
+
+	for rows.Next() {
+		var row StockItemRow
+
+		err := rows.Scan(&row.UserID, &row.SKU, &row.Name, &row.Type, &row.Archived, &row.Currency, &row.Price, &row.Count, &row.Location)
+		if err != nil {
+			return nil, err
+		}
 
+		items = append(items, row.ToDomain())
+	}
+
+	return items, rows.Err()
+}
+
+// locationOrder returns the column ListByLocation sorts on before the SKU,
+// the matching cursor key and whether the order is descending. Sorting by SKU
+// alone has no extra column.
+func locationOrder(order models.StockSort) (string, func(models.LocationCursor) interface{}, bool) {
+	byPrice := func(c models.LocationCursor) interface{} { return c.Price.Decimal() }
+	byCount := func(c models.LocationCursor) interface{} { return c.Count }
+
+	switch order {
+	case models.SortBySKUDesc:
+		return "", nil, true
+	case models.SortByPrice:
+		return "s.price", byPrice, false
+	case models.SortByPriceDesc:
+		return "s.price", byPrice, true
+	case models.SortByCount:
+		return "s.count", byCount, false
+	case models.SortByCountDesc:
+		return "s.count", byCount, true
+	default:
+		return "", nil, false
+	}
+}
+
+// lockSKURows locks every location row of sku in a stable order.
+func (r *PostgresStockRepo) lockSKURows(ctx context.Context, sku uint32) ([]models.StockItem, error) {
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		SELECT s.user_id, s.sku, i.currency, s.price, s.count, s.location
+		FROM stock_items s
+		JOIN sku_info i ON s.sku = i.sku
+		WHERE s.sku = $1
+		ORDER BY s.location
+		FOR UPDATE OF s
+	`, sku)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +359,7 @@ func (r *PostgresStockRepo) ListByLocation(ctx context.Context, location string,
 	for rows.Next() {
 		var row StockItemRow
 
-		err := rows.Scan(&row.UserID, &row.SKU, &row.Name, &row.Type, &row.Price, &row.Count, &row.Location)
+		err := rows.Scan(&row.UserID, &row.SKU, &row.Currency, &row.Price, &row.Count, &row.Location)
 		if err != nil {
 			return nil, err
 		}
@@ -142,3 +369,15 @@ func (r *PostgresStockRepo) ListByLocation(ctx context.Context, location string,
 
 	return items, rows.Err()
 }
+
+func (r *PostgresStockRepo) reservedCount(ctx context.Context, sku uint32) (int64, error) {
+	var reserved int64
+	err := r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(ri.count), 0)
+		FROM reservation_items ri
+		JOIN reservations r ON r.reservation_id = ri.reservation_id
+		WHERE ri.sku = $1 AND r.status = 'active' AND r.expires_at > now()
+	`, sku).Scan(&reserved)
+
+	return reserved, err
+}
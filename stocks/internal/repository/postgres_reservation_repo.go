@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"math"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+)
+
+func (r *PostgresStockRepo) GetAvailableForUpdate(ctx context.Context, sku uint32) (uint16, error) {
+	rows, err := r.lockSKURows(ctx, sku)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rows) == 0 {
+		return 0, errors.ErrItemNotFound
+	}
+
+	reserved, err := r.reservedCount(ctx, sku)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, row := range rows {
+		count += int64(row.Count)
+	}
+
+	if reserved >= count {
+		return 0, nil
+	}
+
+	return uint16(min(count-reserved, math.MaxUint16)), nil
+}
+
+func (r *PostgresStockRepo) CreateReservation(ctx context.Context, reservation models.Reservation) (int64, error) {
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var reservationID int64
+	err := tr.QueryRowContext(ctx, `
+		INSERT INTO reservations (user_id, status, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING reservation_id
+	`, reservation.UserID, reservation.Status, reservation.ExpiresAt).Scan(&reservationID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range reservation.Items {
+		_, err = tr.ExecContext(ctx, `
+			INSERT INTO reservation_items (reservation_id, sku, count)
+			VALUES ($1, $2, $3)
+		`, reservationID, item.SKU, item.Count)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return reservationID, nil
+}
+
+func (r *PostgresStockRepo) GetReservationForUpdate(ctx context.Context, reservationID int64) (models.Reservation, error) {
+	reservation := models.Reservation{ID: reservationID}
+
+	err := r.getter.DefaultTrOrDB(ctx, r.db).QueryRowContext(ctx, `
+		SELECT user_id, status, expires_at
+		FROM reservations
+		WHERE reservation_id = $1
+		FOR UPDATE
+	`, reservationID).Scan(&reservation.UserID, &reservation.Status, &reservation.ExpiresAt)
+
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return models.Reservation{}, errors.ErrReservationNotFound
+	}
+
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	reservation.Items, err = r.listReservationItems(ctx, reservationID)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+func (r *PostgresStockRepo) UpdateReservationStatus(ctx context.Context, reservationID int64, status models.ReservationStatus) error {
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, `
+		UPDATE reservations SET status = $1
+		WHERE reservation_id = $2
+	`, status, reservationID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.ErrReservationNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresStockRepo) ExpireReservations(ctx context.Context) ([]models.Reservation, error) {
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		UPDATE reservations SET status = $1
+		WHERE status = $2 AND expires_at <= now()
+		RETURNING reservation_id, user_id, status, expires_at
+	`, models.ReservationStatusExpired, models.ReservationStatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	expired := make([]models.Reservation, 0)
+
+	for rows.Next() {
+		var reservation models.Reservation
+
+		err := rows.Scan(&reservation.ID, &reservation.UserID, &reservation.Status, &reservation.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		expired = append(expired, reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range expired {
+		expired[i].Items, err = r.listReservationItems(ctx, expired[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return expired, nil
+}
+
+func (r *PostgresStockRepo) listReservationItems(ctx context.Context, reservationID int64) ([]models.ReservationItem, error) {
+	rows, err := r.getter.DefaultTrOrDB(ctx, r.db).QueryContext(ctx, `
+		SELECT sku, count
+		FROM reservation_items
+		WHERE reservation_id = $1
+		ORDER BY sku
+	`, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	items := make([]models.ReservationItem, 0)
+
+	for rows.Next() {
+		var item models.ReservationItem
+
+		if err := rows.Scan(&item.SKU, &item.Count); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
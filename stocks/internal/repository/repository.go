@@ -3,16 +3,31 @@ package repository
 import (
 	"context"
 	"stocks/internal/models"
+	"stocks/internal/money"
 )
 
 //go:generate mockgen -source=internal/repository/repository.go -destination=internal/repository/mocks/stockrepo_mock.go -package=mocks
 
 type StockRepository interface {
-	Delete(ctx context.Context, sku uint32) error
+	Delete(ctx context.Context, sku uint32, location string) ([]models.StockItem, error)
 	GetBySKU(ctx context.Context, sku uint32) (models.StockItem, error)
-	ListByLocation(ctx context.Context, location string, pageSize, currentPage int64) ([]models.StockItem, error)
-	GetSKUInfo(ctx context.Context, sku uint32) (string, string, error)
-	GetByUserSKU(ctx context.Context, userID int64, sku uint32) (models.StockItem, error)
+	GetBySKUs(ctx context.Context, skus []uint32) ([]models.StockItem, error)
+	ListByLocation(ctx context.Context, filter models.LocationFilter) ([]models.StockItem, error)
+	GetSKU(ctx context.Context, sku uint32) (models.SKU, error)
+	GetSKUForUpdate(ctx context.Context, sku uint32) (models.SKU, error)
+	CreateSKU(ctx context.Context, sku models.SKU) (models.SKU, error)
+	UpdateSKU(ctx context.Context, sku models.SKU) (models.SKU, error)
+	ArchiveSKU(ctx context.Context, sku uint32) (models.SKU, error)
+	ListSKUs(ctx context.Context, filter models.SKUFilter) ([]models.SKU, error)
+	GetBySKULocation(ctx context.Context, sku uint32, location string) (models.StockItem, error)
 	InsertStockItem(ctx context.Context, item models.StockItem) error
-	UpdateCount(ctx context.Context, userID int64, sku uint32, newCount uint16, price float64) error
+	UpdateCount(ctx context.Context, sku uint32, location string, newCount uint16, price money.Money) error
+	DecreaseCount(ctx context.Context, sku uint32, count uint16) (models.StockItem, []models.StockMovement, error)
+	GetAvailableForUpdate(ctx context.Context, sku uint32) (uint16, error)
+	CreateReservation(ctx context.Context, reservation models.Reservation) (int64, error)
+	GetReservationForUpdate(ctx context.Context, reservationID int64) (models.Reservation, error)
+	UpdateReservationStatus(ctx context.Context, reservationID int64, status models.ReservationStatus) error
+	ExpireReservations(ctx context.Context) ([]models.Reservation, error)
+	InsertMovement(ctx context.Context, movement models.StockMovement) error
+	ListMovements(ctx context.Context, filter models.MovementFilter) ([]models.StockMovement, error)
 }
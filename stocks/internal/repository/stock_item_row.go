@@ -1,15 +1,22 @@
 package repository
 
-import "stocks/internal/models"
+import (
+	"fmt"
+	"stocks/internal/models"
+	"stocks/internal/money"
+)
 
 type StockItemRow struct {
 	UserID   int64
 	SKU      uint32
 	Name     string
 	Type     string
-	Price    float64
+	Currency string
+	Price    cents
 	Count    uint16
+	Reserved uint16
 	Location string
+	Archived bool
 }
 
 func (r *StockItemRow) ToDomain() models.StockItem {
@@ -18,8 +25,36 @@ func (r *StockItemRow) ToDomain() models.StockItem {
 		SKU:      r.SKU,
 		Name:     r.Name,
 		Type:     r.Type,
-		Price:    r.Price,
+		Price:    money.New(r.Currency, int64(r.Price)),
 		Count:    r.Count,
+		Reserved: r.Reserved,
 		Location: r.Location,
+		Archived: r.Archived,
 	}
 }
+
+// cents scans a NUMERIC price column as exact hundredths, without going
+// through float64.
+type cents int64
+
+func (c *cents) Scan(src interface{}) error {
+	var text string
+
+	switch v := src.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("cannot scan %T into a price", src)
+	}
+
+	value, err := money.ParseCents(text)
+	if err != nil {
+		return err
+	}
+
+	*c = cents(value)
+
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"stocks/internal/idempotency"
 	"stocks/internal/log"
 	"stocks/internal/metrics"
+	"strings"
 	"time"
 
 	"stocks/internal/config"
@@ -28,7 +30,7 @@ const (
 func NewGatewayMux(ctx context.Context, cfg *config.Config) (http.Handler, error) {
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	mux := runtime.NewServeMux()
+	mux := newServeMux()
 
 	err := stockpb.RegisterStockServiceHandlerFromEndpoint(ctx, mux, cfg.GRPCPort, opts)
 	if err != nil {
@@ -38,10 +40,23 @@ func NewGatewayMux(ctx context.Context, cfg *config.Config) (http.Handler, error
 	return mux, nil
 }
 
+// newServeMux passes the Authorization header on as "authorization" metadata,
+// which is what the gRPC auth interceptor reads, and Idempotency-Key as
+// "idempotency-key".
+func newServeMux() *runtime.ServeMux {
+	return runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+		if strings.EqualFold(key, idempotency.Header) {
+			return idempotency.Header, true
+		}
+
+		return runtime.DefaultHeaderMatcher(key)
+	}))
+}
+
 func StartGatewayServer(ctx context.Context, cfg *config.Config, logger log.Logger, m metrics.MetricsInterface) error {
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	mux := runtime.NewServeMux()
+	mux := newServeMux()
 
 	err := stockpb.RegisterStockServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCPort, opts)
 	if err != nil {
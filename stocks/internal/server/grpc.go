@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net"
 
+	"stocks/internal/auth"
 	"stocks/internal/config"
 	service "stocks/internal/delivery"
+	"stocks/internal/idempotency"
 	"stocks/internal/log"
 	"stocks/internal/log/zap"
 	"stocks/internal/metrics"
@@ -88,18 +90,36 @@ func TracingInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
-func StartGRPCServer(ctx context.Context, cfg *config.Config, stockUC usecase.StockUseCase, logger *zap.Logger, m *metrics.Metrics) error {
+func StartGRPCServer(ctx context.Context, cfg *config.Config, stockUC usecase.StockUseCase, keys idempotency.Store, logger *zap.Logger, m *metrics.Metrics) error {
 	lis, err := net.Listen("tcp", cfg.GRPCPort)
 	if err != nil {
 		logger.Error("failed to listen on port", log.String("port", cfg.GRPCPort), log.Error(err))
 		return fmt.Errorf("failed to listen on port %s: %w", cfg.GRPCPort, err)
 	}
 
+	verifier, err := auth.NewVerifier(cfg.AuthConfig())
+	if err != nil {
+		logger.Error("failed to configure authentication", log.Error(err))
+		return fmt.Errorf("failed to configure authentication: %w", err)
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			metrics.UnaryServerInterceptor(m),
 			TracingInterceptor(),
-			LoggingInterceptor(logger)),
+			LoggingInterceptor(logger),
+			auth.UnaryServerInterceptor(verifier, logger),
+			idempotency.UnaryServerInterceptor(keys, cfg.IdempotencyKeyTTL, logger,
+				stockpb.StockService_AddItem_FullMethodName,
+				stockpb.StockService_DeleteItem_FullMethodName,
+				stockpb.StockService_ReduceStock_FullMethodName,
+				stockpb.StockService_ReserveItems_FullMethodName,
+				stockpb.StockService_ReleaseReservation_FullMethodName,
+				stockpb.StockService_CommitReservation_FullMethodName,
+				stockpb.StockService_CreateSKU_FullMethodName,
+				stockpb.StockService_UpdateSKU_FullMethodName,
+				stockpb.StockService_ArchiveSKU_FullMethodName,
+			)),
 	)
 
 	stockpb.RegisterStockServiceServer(grpcServer, service.NewStockServer(stockUC, logger))
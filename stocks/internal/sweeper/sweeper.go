@@ -0,0 +1,51 @@
+package sweeper
+
+import (
+	"context"
+	"time"
+
+	"stocks/internal/log"
+	"stocks/internal/usecase"
+)
+
+type Sweeper struct {
+	usecase  usecase.StockUseCase
+	interval time.Duration
+	logger   log.Logger
+}
+
+func New(u usecase.StockUseCase, interval time.Duration, logger log.Logger) *Sweeper {
+	return &Sweeper{
+		usecase:  u,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run expires overdue reservations every interval until ctx is cancelled.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Reservation sweeper stopped")
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+func (s *Sweeper) sweep(ctx context.Context) {
+	expired, err := s.usecase.ExpireReservations(ctx)
+	if err != nil {
+		s.logger.Error("failed to expire reservations", log.Error(err))
+		return
+	}
+
+	if expired > 0 {
+		s.logger.Info("Expired reservations released", log.Int("count", expired))
+	}
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"stocks/internal/errors"
+	"stocks/internal/log"
+	"stocks/internal/models"
+	"stocks/internal/policy"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (u *stockUseCase) CreateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "CreateSKU")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("sku", int64(sku.SKU)),
+		attribute.String("sku.type", sku.Type),
+	)
+
+	if err := policy.ManageCatalog(ctx); err != nil {
+		span.SetStatus(codes.Error, "permission denied")
+		return models.SKU{}, err
+	}
+
+	var created models.SKU
+
+	err := u.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		created, err = u.repo.CreateSKU(ctx, sku)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "insert failed")
+			return err
+		}
+
+		return u.sendCatalogEvent(ctx, u.producer.SendCatalogSKUCreated, created)
+	})
+	if err != nil {
+		return models.SKU{}, err
+	}
+
+	return created, nil
+}
+
+// UpdateSKU renames or retypes a SKU. Empty fields of sku keep their current
+// value; archived SKUs are frozen.
+func (u *stockUseCase) UpdateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "UpdateSKU")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("sku", int64(sku.SKU)))
+
+	if err := policy.ManageCatalog(ctx); err != nil {
+		span.SetStatus(codes.Error, "permission denied")
+		return models.SKU{}, err
+	}
+
+	var updated models.SKU
+
+	err := u.txManager.Do(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetSKUForUpdate(ctx, sku.SKU)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		if current.Archived() {
+			span.SetStatus(codes.Error, "archived SKU")
+			return errors.ErrSKUArchived
+		}
+
+		if sku.Name != "" {
+			current.Name = sku.Name
+		}
+
+		if sku.Type != "" {
+			current.Type = sku.Type
+		}
+
+		updated, err = u.repo.UpdateSKU(ctx, current)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "update failed")
+			return err
+		}
+
+		return u.sendCatalogEvent(ctx, u.producer.SendCatalogSKUUpdated, updated)
+	})
+	if err != nil {
+		return models.SKU{}, err
+	}
+
+	return updated, nil
+}
+
+// ArchiveSKU withdraws a SKU from the catalog. Existing stock rows stay, but
+// Add rejects the SKU from now on.
+func (u *stockUseCase) ArchiveSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "ArchiveSKU")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("sku", int64(sku)))
+
+	if err := policy.ManageCatalog(ctx); err != nil {
+		span.SetStatus(codes.Error, "permission denied")
+		return models.SKU{}, err
+	}
+
+	var archived models.SKU
+
+	err := u.txManager.Do(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetSKUForUpdate(ctx, sku)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		if current.Archived() {
+			span.SetStatus(codes.Error, "archived SKU")
+			return errors.ErrSKUArchived
+		}
+
+		archived, err = u.repo.ArchiveSKU(ctx, sku)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "archive failed")
+			return err
+		}
+
+		return u.sendCatalogEvent(ctx, u.producer.SendCatalogSKUArchived, archived)
+	})
+	if err != nil {
+		return models.SKU{}, err
+	}
+
+	return archived, nil
+}
+
+func (u *stockUseCase) GetSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	return u.repo.GetSKU(ctx, sku)
+}
+
+func (u *stockUseCase) ListSKUs(ctx context.Context, filter models.SKUFilter) ([]models.SKU, error) {
+	return u.repo.ListSKUs(ctx, filter)
+}
+
+func (u *stockUseCase) sendCatalogEvent(ctx context.Context, send func(context.Context, models.SKU) error, sku models.SKU) error {
+	err := send(ctx, sku)
+	if err != nil {
+		u.logger.Error("failed to send catalog event", log.UInt32("sku", sku.SKU), log.Error(err))
+	}
+
+	return err
+}
@@ -0,0 +1,207 @@
+package usecase_test
+
+import (
+	stdErr "errors"
+	"stocks/internal/auth"
+	"stocks/internal/errors"
+	"stocks/internal/models"
+	"stocks/internal/repository/mocks"
+	mockKafka "stocks/internal/usecase/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestStockUseCase_CreateSKU(t *testing.T) {
+	t.Parallel()
+
+	sku := models.SKU{SKU: 11011, Name: "scarf", Type: "apparel"}
+	eventErr := stdErr.New("outbox unavailable")
+
+	tests := []struct {
+		name      string
+		roles     []auth.Role
+		mockSetup func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface)
+		wantErr   error
+	}{
+		{
+			name:  "success",
+			roles: []auth.Role{auth.RoleAdmin},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().CreateSKU(gomock.Any(), sku).Return(sku, nil)
+				mockProducer.EXPECT().SendCatalogSKUCreated(gomock.Any(), sku).Return(nil)
+			},
+		},
+		{
+			name:  "already exists",
+			roles: []auth.Role{auth.RoleAdmin},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().CreateSKU(gomock.Any(), sku).Return(models.SKU{}, errors.ErrSKUExists)
+			},
+			wantErr: errors.ErrSKUExists,
+		},
+		{
+			name:  "event error",
+			roles: []auth.Role{auth.RoleAdmin},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().CreateSKU(gomock.Any(), sku).Return(sku, nil)
+				mockProducer.EXPECT().SendCatalogSKUCreated(gomock.Any(), sku).Return(eventErr)
+			},
+			wantErr: eventErr,
+		},
+		{
+			name:      "seller cannot manage the catalog",
+			roles:     []auth.Role{auth.RoleSeller},
+			mockSetup: func(*mocks.MockStockRepository, *mockKafka.MockProducerInterface) {},
+			wantErr:   errors.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, mockRepo, mockProducer := newReservationUseCase(t)
+			tt.mockSetup(mockRepo, mockProducer)
+
+			created, err := uc.CreateSKU(callerContext(1, tt.roles...), sku)
+			if tt.wantErr != nil {
+				if !stdErr.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if created != sku {
+				t.Fatalf("expected %+v, got %+v", sku, created)
+			}
+		})
+	}
+}
+
+func TestStockUseCase_UpdateSKU(t *testing.T) {
+	t.Parallel()
+
+	ctx := callerContext(1, auth.RoleAdmin)
+	current := models.SKU{SKU: 1001, Name: "t-shirt", Type: "apparel"}
+
+	tests := []struct {
+		name      string
+		update    models.SKU
+		mockSetup func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface)
+		wantErr   error
+	}{
+		{
+			name:   "empty fields keep their value",
+			update: models.SKU{SKU: 1001, Name: "tee"},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				want := models.SKU{SKU: 1001, Name: "tee", Type: "apparel"}
+
+				mockRepo.EXPECT().GetSKUForUpdate(gomock.Any(), uint32(1001)).Return(current, nil)
+				mockRepo.EXPECT().UpdateSKU(gomock.Any(), want).Return(want, nil)
+				mockProducer.EXPECT().SendCatalogSKUUpdated(gomock.Any(), want).Return(nil)
+			},
+		},
+		{
+			name:   "archived sku is frozen",
+			update: models.SKU{SKU: 1001, Name: "tee"},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				archived := current
+				archived.ArchivedAt = time.Now()
+
+				mockRepo.EXPECT().GetSKUForUpdate(gomock.Any(), uint32(1001)).Return(archived, nil)
+			},
+			wantErr: errors.ErrSKUArchived,
+		},
+		{
+			name:   "not found",
+			update: models.SKU{SKU: 1001, Name: "tee"},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().GetSKUForUpdate(gomock.Any(), uint32(1001)).Return(models.SKU{}, errors.ErrSKUNotFound)
+			},
+			wantErr: errors.ErrSKUNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, mockRepo, mockProducer := newReservationUseCase(t)
+			tt.mockSetup(mockRepo, mockProducer)
+
+			_, err := uc.UpdateSKU(ctx, tt.update)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != nil && !stdErr.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStockUseCase_ArchiveSKU(t *testing.T) {
+	t.Parallel()
+
+	ctx := callerContext(1, auth.RoleAdmin)
+	active := models.SKU{SKU: 1001, Name: "t-shirt", Type: "apparel"}
+
+	tests := []struct {
+		name      string
+		mockSetup func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				archived := active
+				archived.ArchivedAt = time.Now()
+
+				mockRepo.EXPECT().GetSKUForUpdate(gomock.Any(), uint32(1001)).Return(active, nil)
+				mockRepo.EXPECT().ArchiveSKU(gomock.Any(), uint32(1001)).Return(archived, nil)
+				mockProducer.EXPECT().SendCatalogSKUArchived(gomock.Any(), archived).Return(nil)
+			},
+		},
+		{
+			name: "already archived",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				archived := active
+				archived.ArchivedAt = time.Now()
+
+				mockRepo.EXPECT().GetSKUForUpdate(gomock.Any(), uint32(1001)).Return(archived, nil)
+			},
+			wantErr: errors.ErrSKUArchived,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, mockRepo, mockProducer := newReservationUseCase(t)
+			tt.mockSetup(mockRepo, mockProducer)
+
+			archived, err := uc.ArchiveSKU(ctx, 1001)
+			if tt.wantErr != nil {
+				if !stdErr.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !archived.Archived() {
+				t.Fatalf("expected archived SKU, got %+v", archived)
+			}
+		})
+	}
+}
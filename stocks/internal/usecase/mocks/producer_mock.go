@@ -7,6 +7,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	kafka "stocks/internal/kafka"
+	models "stocks/internal/models"
+	money "stocks/internal/money"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -48,8 +51,78 @@ func (mr *MockProducerInterfaceMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockProducerInterface)(nil).Close))
 }
 
+// SendCatalogSKUArchived mocks base method.
+func (m *MockProducerInterface) SendCatalogSKUArchived(ctx context.Context, sku models.SKU) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCatalogSKUArchived", ctx, sku)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCatalogSKUArchived indicates an expected call of SendCatalogSKUArchived.
+func (mr *MockProducerInterfaceMockRecorder) SendCatalogSKUArchived(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCatalogSKUArchived", reflect.TypeOf((*MockProducerInterface)(nil).SendCatalogSKUArchived), ctx, sku)
+}
+
+// SendCatalogSKUCreated mocks base method.
+func (m *MockProducerInterface) SendCatalogSKUCreated(ctx context.Context, sku models.SKU) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCatalogSKUCreated", ctx, sku)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCatalogSKUCreated indicates an expected call of SendCatalogSKUCreated.
+func (mr *MockProducerInterfaceMockRecorder) SendCatalogSKUCreated(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCatalogSKUCreated", reflect.TypeOf((*MockProducerInterface)(nil).SendCatalogSKUCreated), ctx, sku)
+}
+
+// SendCatalogSKUUpdated mocks base method.
+func (m *MockProducerInterface) SendCatalogSKUUpdated(ctx context.Context, sku models.SKU) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCatalogSKUUpdated", ctx, sku)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCatalogSKUUpdated indicates an expected call of SendCatalogSKUUpdated.
+func (mr *MockProducerInterfaceMockRecorder) SendCatalogSKUUpdated(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCatalogSKUUpdated", reflect.TypeOf((*MockProducerInterface)(nil).SendCatalogSKUUpdated), ctx, sku)
+}
+
+// SendPriceChanged mocks base method.
+func (m *MockProducerInterface) SendPriceChanged(ctx context.Context, sku, location string, oldPrice, newPrice money.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPriceChanged", ctx, sku, location, oldPrice, newPrice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPriceChanged indicates an expected call of SendPriceChanged.
+func (mr *MockProducerInterfaceMockRecorder) SendPriceChanged(ctx, sku, location, oldPrice, newPrice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPriceChanged", reflect.TypeOf((*MockProducerInterface)(nil).SendPriceChanged), ctx, sku, location, oldPrice, newPrice)
+}
+
+// SendReservationExpired mocks base method.
+func (m *MockProducerInterface) SendReservationExpired(ctx context.Context, reservation models.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendReservationExpired", ctx, reservation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendReservationExpired indicates an expected call of SendReservationExpired.
+func (mr *MockProducerInterfaceMockRecorder) SendReservationExpired(ctx, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendReservationExpired", reflect.TypeOf((*MockProducerInterface)(nil).SendReservationExpired), ctx, reservation)
+}
+
 // SendSKUCreated mocks base method.
-func (m *MockProducerInterface) SendSKUCreated(ctx context.Context, sku string, price float64, count int) error {
+func (m *MockProducerInterface) SendSKUCreated(ctx context.Context, sku string, price money.Money, count int) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendSKUCreated", ctx, sku, price, count)
 	ret0, _ := ret[0].(error)
@@ -63,7 +136,7 @@ func (mr *MockProducerInterfaceMockRecorder) SendSKUCreated(ctx, sku, price, cou
 }
 
 // SendStockChanged mocks base method.
-func (m *MockProducerInterface) SendStockChanged(ctx context.Context, sku string, count int, price float64) error {
+func (m *MockProducerInterface) SendStockChanged(ctx context.Context, sku string, count int, price money.Money) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendStockChanged", ctx, sku, count, price)
 	ret0, _ := ret[0].(error)
@@ -75,3 +148,54 @@ func (mr *MockProducerInterfaceMockRecorder) SendStockChanged(ctx, sku, count, p
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendStockChanged", reflect.TypeOf((*MockProducerInterface)(nil).SendStockChanged), ctx, sku, count, price)
 }
+
+// SendStockDeleted mocks base method.
+func (m *MockProducerInterface) SendStockDeleted(ctx context.Context, item models.StockItem, remaining int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendStockDeleted", ctx, item, remaining)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendStockDeleted indicates an expected call of SendStockDeleted.
+func (mr *MockProducerInterfaceMockRecorder) SendStockDeleted(ctx, item, remaining interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendStockDeleted", reflect.TypeOf((*MockProducerInterface)(nil).SendStockDeleted), ctx, item, remaining)
+}
+
+// MockSink is a mock of Sink interface.
+type MockSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockSinkMockRecorder
+}
+
+// MockSinkMockRecorder is the mock recorder for MockSink.
+type MockSinkMockRecorder struct {
+	mock *MockSink
+}
+
+// NewMockSink creates a new mock instance.
+func NewMockSink(ctrl *gomock.Controller) *MockSink {
+	mock := &MockSink{ctrl: ctrl}
+	mock.recorder = &MockSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSink) EXPECT() *MockSinkMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockSink) Publish(ctx context.Context, msg kafka.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockSinkMockRecorder) Publish(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockSink)(nil).Publish), ctx, msg)
+}
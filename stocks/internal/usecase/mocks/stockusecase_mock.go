@@ -49,46 +49,223 @@ func (mr *MockStockUseCaseMockRecorder) Add(ctx, item interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockStockUseCase)(nil).Add), ctx, item)
 }
 
+// ArchiveSKU mocks base method.
+func (m *MockStockUseCase) ArchiveSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveSKU indicates an expected call of ArchiveSKU.
+func (mr *MockStockUseCaseMockRecorder) ArchiveSKU(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveSKU", reflect.TypeOf((*MockStockUseCase)(nil).ArchiveSKU), ctx, sku)
+}
+
+// Commit mocks base method.
+func (m *MockStockUseCase) Commit(ctx context.Context, reservationID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, reservationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockStockUseCaseMockRecorder) Commit(ctx, reservationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockStockUseCase)(nil).Commit), ctx, reservationID)
+}
+
+// CreateSKU mocks base method.
+func (m *MockStockUseCase) CreateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSKU indicates an expected call of CreateSKU.
+func (mr *MockStockUseCaseMockRecorder) CreateSKU(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSKU", reflect.TypeOf((*MockStockUseCase)(nil).CreateSKU), ctx, sku)
+}
+
 // Delete mocks base method.
-func (m *MockStockUseCase) Delete(ctx context.Context, sku uint32) error {
+func (m *MockStockUseCase) Delete(ctx context.Context, userID int64, sku uint32, location string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, sku)
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, sku, location)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockStockUseCaseMockRecorder) Delete(ctx, sku interface{}) *gomock.Call {
+func (mr *MockStockUseCaseMockRecorder) Delete(ctx, userID, sku, location interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStockUseCase)(nil).Delete), ctx, sku)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStockUseCase)(nil).Delete), ctx, userID, sku, location)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockStockUseCase) ExpireReservations(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockStockUseCaseMockRecorder) ExpireReservations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockStockUseCase)(nil).ExpireReservations), ctx)
 }
 
 // GetBySKU mocks base method.
-func (m *MockStockUseCase) GetBySKU(ctx context.Context, sku uint32) (models.StockItem, error) {
+func (m *MockStockUseCase) GetBySKU(ctx context.Context, sku uint32, location string) (models.StockItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBySKU", ctx, sku)
+	ret := m.ctrl.Call(m, "GetBySKU", ctx, sku, location)
 	ret0, _ := ret[0].(models.StockItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBySKU indicates an expected call of GetBySKU.
-func (mr *MockStockUseCaseMockRecorder) GetBySKU(ctx, sku interface{}) *gomock.Call {
+func (mr *MockStockUseCaseMockRecorder) GetBySKU(ctx, sku, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockStockUseCase)(nil).GetBySKU), ctx, sku, location)
+}
+
+// GetBySKUs mocks base method.
+func (m *MockStockUseCase) GetBySKUs(ctx context.Context, skus []uint32) ([]models.StockItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKUs", ctx, skus)
+	ret0, _ := ret[0].([]models.StockItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKUs indicates an expected call of GetBySKUs.
+func (mr *MockStockUseCaseMockRecorder) GetBySKUs(ctx, skus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKUs", reflect.TypeOf((*MockStockUseCase)(nil).GetBySKUs), ctx, skus)
+}
+
+// GetSKU mocks base method.
+func (m *MockStockUseCase) GetSKU(ctx context.Context, sku uint32) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSKU indicates an expected call of GetSKU.
+func (mr *MockStockUseCaseMockRecorder) GetSKU(ctx, sku interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockStockUseCase)(nil).GetBySKU), ctx, sku)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSKU", reflect.TypeOf((*MockStockUseCase)(nil).GetSKU), ctx, sku)
 }
 
 // ListByLocation mocks base method.
-func (m *MockStockUseCase) ListByLocation(ctx context.Context, location string, pageSize, currentPage int64) ([]models.StockItem, error) {
+func (m *MockStockUseCase) ListByLocation(ctx context.Context, filter models.LocationFilter) ([]models.StockItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByLocation", ctx, location, pageSize, currentPage)
+	ret := m.ctrl.Call(m, "ListByLocation", ctx, filter)
 	ret0, _ := ret[0].([]models.StockItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByLocation indicates an expected call of ListByLocation.
-func (mr *MockStockUseCaseMockRecorder) ListByLocation(ctx, location, pageSize, currentPage interface{}) *gomock.Call {
+func (mr *MockStockUseCaseMockRecorder) ListByLocation(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLocation", reflect.TypeOf((*MockStockUseCase)(nil).ListByLocation), ctx, filter)
+}
+
+// ListMovements mocks base method.
+func (m *MockStockUseCase) ListMovements(ctx context.Context, filter models.MovementFilter) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, filter)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockStockUseCaseMockRecorder) ListMovements(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockStockUseCase)(nil).ListMovements), ctx, filter)
+}
+
+// ListSKUs mocks base method.
+func (m *MockStockUseCase) ListSKUs(ctx context.Context, filter models.SKUFilter) ([]models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSKUs", ctx, filter)
+	ret0, _ := ret[0].([]models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSKUs indicates an expected call of ListSKUs.
+func (mr *MockStockUseCaseMockRecorder) ListSKUs(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSKUs", reflect.TypeOf((*MockStockUseCase)(nil).ListSKUs), ctx, filter)
+}
+
+// Reduce mocks base method.
+func (m *MockStockUseCase) Reduce(ctx context.Context, items []models.StockItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reduce", ctx, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reduce indicates an expected call of Reduce.
+func (mr *MockStockUseCaseMockRecorder) Reduce(ctx, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reduce", reflect.TypeOf((*MockStockUseCase)(nil).Reduce), ctx, items)
+}
+
+// Release mocks base method.
+func (m *MockStockUseCase) Release(ctx context.Context, reservationID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, reservationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockStockUseCaseMockRecorder) Release(ctx, reservationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockStockUseCase)(nil).Release), ctx, reservationID)
+}
+
+// Reserve mocks base method.
+func (m *MockStockUseCase) Reserve(ctx context.Context, reservation models.Reservation) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, reservation)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockStockUseCaseMockRecorder) Reserve(ctx, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockStockUseCase)(nil).Reserve), ctx, reservation)
+}
+
+// UpdateSKU mocks base method.
+func (m *MockStockUseCase) UpdateSKU(ctx context.Context, sku models.SKU) (models.SKU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSKU", ctx, sku)
+	ret0, _ := ret[0].(models.SKU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSKU indicates an expected call of UpdateSKU.
+func (mr *MockStockUseCaseMockRecorder) UpdateSKU(ctx, sku interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLocation", reflect.TypeOf((*MockStockUseCase)(nil).ListByLocation), ctx, location, pageSize, currentPage)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSKU", reflect.TypeOf((*MockStockUseCase)(nil).UpdateSKU), ctx, sku)
 }
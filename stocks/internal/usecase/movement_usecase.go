@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"stocks/internal/log"
+	"stocks/internal/models"
+	"stocks/internal/policy"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordMovements appends movements to the ledger. It must run inside the
+// transaction that changed the counts so both commit or roll back together.
+func (u *stockUseCase) recordMovements(ctx context.Context, reason models.MovementReason, userID int64, movements ...models.StockMovement) error {
+	var traceID string
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		traceID = spanCtx.TraceID().String()
+	}
+
+	for _, movement := range movements {
+		movement.Reason = reason
+		movement.UserID = userID
+		movement.TraceID = traceID
+
+		if err := u.repo.InsertMovement(ctx, movement); err != nil {
+			u.logger.Error("failed to record stock movement",
+				log.UInt32("sku", movement.SKU),
+				log.String("location", movement.Location),
+				log.Error(err),
+			)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *stockUseCase) ListMovements(ctx context.Context, filter models.MovementFilter) ([]models.StockMovement, error) {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "ListMovements")
+	defer span.End()
+
+	if err := policy.ListMovements(ctx, &filter); err != nil {
+		span.SetStatus(codes.Error, "permission denied")
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("filter.sku", int64(filter.SKU)),
+		attribute.String("filter.location", filter.Location),
+		attribute.Int64("filter.user_id", filter.UserID),
+		attribute.Int64("filter.owner_id", filter.OwnerID),
+	)
+
+	movements, err := u.repo.ListMovements(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "list failed")
+		return nil, err
+	}
+
+	return movements, nil
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"stocks/internal/errors"
+	"stocks/internal/log"
+	"stocks/internal/models"
+	"stocks/internal/policy"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (u *stockUseCase) Reserve(ctx context.Context, reservation models.Reservation) (models.Reservation, error) {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "Reserve")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("user.id", reservation.UserID),
+		attribute.Int("items.count", len(reservation.Items)),
+	)
+
+	// Lock stock rows in a stable order so concurrent reservations
+	// touching the same SKUs cannot deadlock each other.
+	sort.Slice(reservation.Items, func(i, j int) bool {
+		return reservation.Items[i].SKU < reservation.Items[j].SKU
+	})
+
+	reservation.Status = models.ReservationStatusActive
+
+	err := u.txManager.Do(ctx, func(ctx context.Context) error {
+		for _, item := range reservation.Items {
+			available, err := u.repo.GetAvailableForUpdate(ctx, item.SKU)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "availability check failed")
+				return err
+			}
+
+			if item.Count > available {
+				u.logger.Warn("not enough stock to reserve",
+					log.UInt32("sku", item.SKU),
+					log.UInt16("requested", item.Count),
+					log.UInt16("available", available),
+				)
+				span.SetStatus(codes.Error, "not enough stock")
+				return errors.ErrNotEnoughStock
+			}
+		}
+
+		reservationID, err := u.repo.CreateReservation(ctx, reservation)
+		if err != nil {
+			u.logger.Error("failed to create reservation", log.Error(err))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "insert failed")
+			return err
+		}
+
+		reservation.ID = reservationID
+
+		return nil
+	})
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+func (u *stockUseCase) Release(ctx context.Context, reservationID int64) error {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "Release")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("reservation.id", reservationID))
+
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		reservation, err := u.repo.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		if err := policy.ManageReservation(ctx, reservation.UserID); err != nil {
+			span.SetStatus(codes.Error, "permission denied")
+			return err
+		}
+
+		if reservation.Status != models.ReservationStatusActive {
+			span.SetStatus(codes.Error, "reservation inactive")
+			return errors.ErrReservationInactive
+		}
+
+		return u.repo.UpdateReservationStatus(ctx, reservationID, models.ReservationStatusReleased)
+	})
+}
+
+func (u *stockUseCase) Commit(ctx context.Context, reservationID int64) error {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "Commit")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("reservation.id", reservationID))
+
+	if err := policy.CommitReservation(ctx); err != nil {
+		span.SetStatus(codes.Error, "permission denied")
+		return err
+	}
+
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		reservation, err := u.repo.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		if reservation.Status != models.ReservationStatusActive {
+			span.SetStatus(codes.Error, "reservation inactive")
+			return errors.ErrReservationInactive
+		}
+
+		if !reservation.ExpiresAt.After(time.Now()) {
+			span.SetStatus(codes.Error, "reservation expired")
+			return errors.ErrReservationExpired
+		}
+
+		// The hold has to stop counting as reserved before the units are
+		// written off, otherwise it would block its own decrement.
+		err = u.repo.UpdateReservationStatus(ctx, reservationID, models.ReservationStatusCommitted)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		for _, item := range reservation.Items {
+			updated, movements, err := u.repo.DecreaseCount(ctx, item.SKU, item.Count)
+			if err != nil {
+				u.logger.Error("failed to commit reservation item",
+					log.Int64("reservation_id", reservationID),
+					log.UInt32("sku", item.SKU),
+					log.Error(err),
+				)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "decrease failed")
+				return err
+			}
+
+			err = u.recordMovements(ctx, models.MovementReasonReservationCommit, reservation.UserID, movements...)
+			if err != nil {
+				span.RecordError(err)
+				return err
+			}
+
+			if err := u.sendStockChangedEvent(ctx, updated.SKU, int(updated.Count), updated.Price); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (u *stockUseCase) ExpireReservations(ctx context.Context) (int, error) {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "ExpireReservations")
+	defer span.End()
+
+	var expired []models.Reservation
+
+	err := u.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		expired, err = u.repo.ExpireReservations(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "expire failed")
+			return err
+		}
+
+		for _, reservation := range expired {
+			if err := u.producer.SendReservationExpired(ctx, reservation); err != nil {
+				u.logger.Error("failed to send ReservationExpired event", log.Error(err))
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int("reservations.expired", len(expired)))
+
+	return len(expired), nil
+}
@@ -0,0 +1,303 @@
+package usecase_test
+
+import (
+	"context"
+	stdErr "errors"
+	"stocks/internal/auth"
+	"stocks/internal/errors"
+	"stocks/internal/log/zap"
+	"stocks/internal/models"
+	"stocks/internal/money"
+	"stocks/internal/repository/mocks"
+	"stocks/internal/usecase"
+	mockKafka "stocks/internal/usecase/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newReservationUseCase(t *testing.T) (usecase.StockUseCase, *mocks.MockStockRepository, *mockKafka.MockProducerInterface) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockStockRepository(ctrl)
+	mockProducer := mockKafka.NewMockProducerInterface(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	return usecase.NewStockUsecase(mockRepo, &mockTxManager{}, mockProducer, logger), mockRepo, mockProducer
+}
+
+func TestStockUseCase_Reserve(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		mockSetup func(mockRepo *mocks.MockStockRepository)
+		wantID    int64
+		wantErr   error
+	}{
+		{
+			name: "success locks skus in order",
+			mockSetup: func(mockRepo *mocks.MockStockRepository) {
+				gomock.InOrder(
+					mockRepo.EXPECT().GetAvailableForUpdate(gomock.Any(), uint32(1001)).Return(uint16(5), nil),
+					mockRepo.EXPECT().GetAvailableForUpdate(gomock.Any(), uint32(2020)).Return(uint16(1), nil),
+				)
+				mockRepo.EXPECT().CreateReservation(gomock.Any(), models.Reservation{
+					UserID: 7,
+					Status: models.ReservationStatusActive,
+					Items: []models.ReservationItem{
+						{SKU: 1001, Count: 2},
+						{SKU: 2020, Count: 1},
+					},
+					ExpiresAt: expiresAt,
+				}).Return(int64(42), nil)
+			},
+			wantID: 42,
+		},
+		{
+			name: "not enough available stock",
+			mockSetup: func(mockRepo *mocks.MockStockRepository) {
+				mockRepo.EXPECT().GetAvailableForUpdate(gomock.Any(), uint32(1001)).Return(uint16(1), nil)
+			},
+			wantErr: errors.ErrNotEnoughStock,
+		},
+		{
+			name: "unknown sku",
+			mockSetup: func(mockRepo *mocks.MockStockRepository) {
+				mockRepo.EXPECT().GetAvailableForUpdate(gomock.Any(), uint32(1001)).Return(uint16(0), errors.ErrItemNotFound)
+			},
+			wantErr: errors.ErrItemNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, mockRepo, _ := newReservationUseCase(t)
+			tt.mockSetup(mockRepo)
+
+			reservation, err := uc.Reserve(ctx, models.Reservation{
+				UserID: 7,
+				Items: []models.ReservationItem{
+					{SKU: 2020, Count: 1},
+					{SKU: 1001, Count: 2},
+				},
+				ExpiresAt: expiresAt,
+			})
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != nil && !stdErr.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if reservation.ID != tt.wantID {
+				t.Fatalf("expected reservation id %d, got %d", tt.wantID, reservation.ID)
+			}
+		})
+	}
+}
+
+func TestStockUseCase_Release(t *testing.T) {
+	t.Parallel()
+
+	ctx := callerContext(7, auth.RoleBuyer)
+
+	tests := []struct {
+		name      string
+		mockSetup func(mockRepo *mocks.MockStockRepository)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockSetup: func(mockRepo *mocks.MockStockRepository) {
+				mockRepo.EXPECT().GetReservationForUpdate(gomock.Any(), int64(42)).
+					Return(models.Reservation{ID: 42, UserID: 7, Status: models.ReservationStatusActive}, nil)
+				mockRepo.EXPECT().UpdateReservationStatus(gomock.Any(), int64(42), models.ReservationStatusReleased).Return(nil)
+			},
+		},
+		{
+			name: "another user's reservation",
+			mockSetup: func(mockRepo *mocks.MockStockRepository) {
+				mockRepo.EXPECT().GetReservationForUpdate(gomock.Any(), int64(42)).
+					Return(models.Reservation{ID: 42, UserID: 8, Status: models.ReservationStatusActive}, nil)
+			},
+			wantErr: errors.ErrPermissionDenied,
+		},
+		{
+			name: "already committed",
+			mockSetup: func(mockRepo *mocks.MockStockRepository) {
+				mockRepo.EXPECT().GetReservationForUpdate(gomock.Any(), int64(42)).
+					Return(models.Reservation{ID: 42, UserID: 7, Status: models.ReservationStatusCommitted}, nil)
+			},
+			wantErr: errors.ErrReservationInactive,
+		},
+		{
+			name: "not found",
+			mockSetup: func(mockRepo *mocks.MockStockRepository) {
+				mockRepo.EXPECT().GetReservationForUpdate(gomock.Any(), int64(42)).
+					Return(models.Reservation{}, errors.ErrReservationNotFound)
+			},
+			wantErr: errors.ErrReservationNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, mockRepo, _ := newReservationUseCase(t)
+			tt.mockSetup(mockRepo)
+
+			err := uc.Release(ctx, 42)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != nil && !stdErr.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStockUseCase_Commit(t *testing.T) {
+	t.Parallel()
+
+	service := callerContext(0, auth.RoleService)
+	active := models.Reservation{
+		ID:        42,
+		UserID:    7,
+		Status:    models.ReservationStatusActive,
+		Items:     []models.ReservationItem{{SKU: 1001, Count: 2}},
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		mockSetup func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().GetReservationForUpdate(gomock.Any(), int64(42)).Return(active, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().UpdateReservationStatus(gomock.Any(), int64(42), models.ReservationStatusCommitted).Return(nil),
+					mockRepo.EXPECT().DecreaseCount(gomock.Any(), uint32(1001), uint16(2)).
+						Return(models.StockItem{SKU: 1001, Count: 3, Price: money.New("RUB", 1000)}, []models.StockMovement{
+							{SKU: 1001, Location: "loc1", Delta: -2, OwnerID: 2},
+						}, nil),
+					mockRepo.EXPECT().InsertMovement(gomock.Any(), models.StockMovement{
+						SKU:      1001,
+						Location: "loc1",
+						Delta:    -2,
+						Reason:   models.MovementReasonReservationCommit,
+						UserID:   7,
+						OwnerID:  2,
+					}).Return(nil),
+				)
+				mockProducer.EXPECT().SendStockChanged(gomock.Any(), "1001", 3, money.New("RUB", 1000)).Return(nil)
+			},
+		},
+		{
+			name: "expired",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				expired := active
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				mockRepo.EXPECT().GetReservationForUpdate(gomock.Any(), int64(42)).Return(expired, nil)
+			},
+			wantErr: errors.ErrReservationExpired,
+		},
+		{
+			name: "released",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				released := active
+				released.Status = models.ReservationStatusReleased
+				mockRepo.EXPECT().GetReservationForUpdate(gomock.Any(), int64(42)).Return(released, nil)
+			},
+			wantErr: errors.ErrReservationInactive,
+		},
+		{
+			name:      "buyer cannot commit their own reservation",
+			ctx:       callerContext(7, auth.RoleBuyer),
+			mockSetup: func(*mocks.MockStockRepository, *mockKafka.MockProducerInterface) {},
+			wantErr:   errors.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, mockRepo, mockProducer := newReservationUseCase(t)
+			tt.mockSetup(mockRepo, mockProducer)
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = service
+			}
+
+			err := uc.Commit(ctx, 42)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != nil && !stdErr.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStockUseCase_ExpireReservations(t *testing.T) {
+	t.Parallel()
+
+	uc, mockRepo, mockProducer := newReservationUseCase(t)
+
+	expired := []models.Reservation{
+		{ID: 1, Status: models.ReservationStatusExpired},
+		{ID: 2, Status: models.ReservationStatusExpired},
+	}
+
+	mockRepo.EXPECT().ExpireReservations(gomock.Any()).Return(expired, nil)
+	mockProducer.EXPECT().SendReservationExpired(gomock.Any(), expired[0]).Return(nil)
+	mockProducer.EXPECT().SendReservationExpired(gomock.Any(), expired[1]).Return(nil)
+
+	count, err := uc.ExpireReservations(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count != 2 {
+		t.Fatalf("expected 2 expired reservations, got %d", count)
+	}
+}
+
+func TestStockUseCase_ExpireReservations_EventError(t *testing.T) {
+	t.Parallel()
+
+	uc, mockRepo, mockProducer := newReservationUseCase(t)
+
+	expired := []models.Reservation{{ID: 1, Status: models.ReservationStatusExpired}}
+	eventErr := stdErr.New("outbox unavailable")
+
+	mockRepo.EXPECT().ExpireReservations(gomock.Any()).Return(expired, nil)
+	mockProducer.EXPECT().SendReservationExpired(gomock.Any(), expired[0]).Return(eventErr)
+
+	if _, err := uc.ExpireReservations(context.Background()); !stdErr.Is(err, eventErr) {
+		t.Fatalf("expected error %v, got %v", eventErr, err)
+	}
+}
@@ -3,10 +3,13 @@ package usecase
 import (
 	"context"
 	stdErrors "errors"
+	"fmt"
 	"stocks/internal/errors"
 	"stocks/internal/kafka"
 	"stocks/internal/log"
 	"stocks/internal/models"
+	"stocks/internal/money"
+	"stocks/internal/policy"
 	"stocks/internal/repository"
 	"strconv"
 
@@ -32,18 +35,36 @@ func NewStockUsecase(repo repository.StockRepository, txManager trm.Manager, pro
 	}
 }
 
-func (u *stockUseCase) sendSKUCreatedEvent(ctx context.Context, sku uint32, price float64, count int) {
+func (u *stockUseCase) sendSKUCreatedEvent(ctx context.Context, sku uint32, price money.Money, count int) error {
 	err := u.producer.SendSKUCreated(ctx, strconv.FormatUint(uint64(sku), 10), price, count)
 	if err != nil {
 		u.logger.Error("failed to send SKUCreated event", log.Error(err))
 	}
+	return err
 }
 
-func (u *stockUseCase) sendStockChangedEvent(ctx context.Context, sku uint32, count int, price float64) {
+func (u *stockUseCase) sendStockChangedEvent(ctx context.Context, sku uint32, count int, price money.Money) error {
 	err := u.producer.SendStockChanged(ctx, strconv.FormatUint(uint64(sku), 10), count, price)
 	if err != nil {
 		u.logger.Error("failed to send StockChanged event", log.Error(err))
 	}
+	return err
+}
+
+func (u *stockUseCase) sendStockDeletedEvent(ctx context.Context, item models.StockItem, remaining int) error {
+	err := u.producer.SendStockDeleted(ctx, item, remaining)
+	if err != nil {
+		u.logger.Error("failed to send StockDeleted event", log.Error(err))
+	}
+	return err
+}
+
+func (u *stockUseCase) sendPriceChangedEvent(ctx context.Context, sku uint32, location string, oldPrice, newPrice money.Money) error {
+	err := u.producer.SendPriceChanged(ctx, strconv.FormatUint(uint64(sku), 10), location, oldPrice, newPrice)
+	if err != nil {
+		u.logger.Error("failed to send PriceChanged event", log.Error(err))
+	}
+	return err
 }
 
 func (u *stockUseCase) Add(ctx context.Context, item models.StockItem) error {
@@ -55,18 +76,39 @@ func (u *stockUseCase) Add(ctx context.Context, item models.StockItem) error {
 		attribute.Int64("user.id", item.UserID),
 		attribute.Int64("item.sku", int64(item.SKU)),
 		attribute.Int64("item.count", int64(item.Count)),
-		attribute.Float64("item.price", item.Price),
+		attribute.String("item.price", item.Price.String()),
 	)
 
+	if err := policy.CreateStock(ctx); err != nil {
+		span.SetStatus(codes.Error, "permission denied")
+		return err
+	}
+
 	return u.txManager.Do(ctx, func(ctx context.Context) error {
-		_, _, err := u.repo.GetSKUInfo(ctx, item.SKU)
+		sku, err := u.repo.GetSKU(ctx, item.SKU)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, "invalid SKU")
 			return errors.ErrInvalidSKU
 		}
 
-		existingItem, err := u.repo.GetByUserSKU(ctx, item.UserID, item.SKU)
+		if sku.Archived() {
+			span.SetStatus(codes.Error, "archived SKU")
+			return errors.ErrSKUArchived
+		}
+
+		// Deprecated float prices arrive without a currency and are read in the
+		// SKU's one.
+		if item.Price.Currency == "" {
+			item.Price.Currency = sku.Currency
+		}
+
+		if item.Price.Currency != sku.Currency {
+			span.SetStatus(codes.Error, "currency mismatch")
+			return fmt.Errorf("%w: SKU %d is priced in %s", errors.ErrCurrencyMismatch, item.SKU, sku.Currency)
+		}
+
+		existingItem, err := u.repo.GetBySKULocation(ctx, item.SKU, item.Location)
 		if err != nil {
 			if !stdErrors.Is(err, errors.ErrItemNotFound) {
 				span.RecordError(err)
@@ -74,54 +116,213 @@ func (u *stockUseCase) Add(ctx context.Context, item models.StockItem) error {
 				return err
 			}
 
+			// sku_created is only for the first row of a SKU; a new location
+			// of a SKU already on sale changes its SKU-wide stock instead.
+			_, err = u.repo.GetBySKU(ctx, item.SKU)
+			firstRow := stdErrors.Is(err, errors.ErrItemNotFound)
+
+			if err != nil && !firstRow {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "db error fetching item")
+				return err
+			}
+
 			err = u.repo.InsertStockItem(ctx, item)
-			if err == nil {
-				u.sendSKUCreatedEvent(ctx, item.SKU, item.Price, int(item.Count))
-			} else {
+			if err != nil {
 				u.logger.Error("failed to insert stock item", log.Error(err))
 				span.RecordError(err)
 				span.SetStatus(codes.Error, "insert failed")
+
+				return err
 			}
 
-			return err
+			err = u.recordMovements(ctx, models.MovementReasonRestock, item.UserID, restockMovement(item, item.UserID))
+			if err != nil {
+				span.RecordError(err)
+				return err
+			}
+
+			if firstRow {
+				return u.sendSKUCreatedEvent(ctx, item.SKU, item.Price, int(item.Count))
+			}
+
+			total, err := u.repo.GetBySKU(ctx, item.SKU)
+			if err != nil {
+				span.RecordError(err)
+				return err
+			}
+
+			return u.sendStockChangedEvent(ctx, total.SKU, int(total.Count), total.Price)
 		}
 
-		if existingItem.UserID != item.UserID {
-			span.SetStatus(codes.Error, "ownership violation")
-			return errors.ErrOwnershipViolation
+		if err := policy.ManageStock(ctx, existingItem.UserID); err != nil {
+			span.SetStatus(codes.Error, "permission denied")
+			return err
 		}
 
+		oldPrice := existingItem.Price
 		existingItem.Count += item.Count
+		existingItem.Price = item.Price
 
-		err = u.repo.UpdateCount(ctx, existingItem.UserID, existingItem.SKU, existingItem.Count, item.Price)
-		if err == nil {
-			u.sendStockChangedEvent(ctx, existingItem.SKU, int(existingItem.Count), existingItem.Price)
-		} else {
+		err = u.repo.UpdateCount(ctx, existingItem.SKU, existingItem.Location, existingItem.Count, item.Price)
+		if err != nil {
 			u.logger.Error("failed to update stock count", log.Error(err))
 			span.RecordError(err)
 			span.SetStatus(codes.Error, "update failed")
+
+			return err
 		}
 
-		return err
+		err = u.recordMovements(ctx, models.MovementReasonRestock, item.UserID, restockMovement(item, existingItem.UserID))
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		if oldPrice != existingItem.Price {
+			err = u.sendPriceChangedEvent(ctx, existingItem.SKU, existingItem.Location, oldPrice, existingItem.Price)
+			if err != nil {
+				return err
+			}
+		}
+
+		// stock_changed carries the SKU-wide stock, as after a reduction, not
+		// the count of the restocked location.
+		total, err := u.repo.GetBySKU(ctx, existingItem.SKU)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		return u.sendStockChangedEvent(ctx, total.SKU, int(total.Count), total.Price)
 	})
 }
 
-func (u *stockUseCase) Delete(ctx context.Context, sku uint32) error {
+func restockMovement(item models.StockItem, ownerID int64) models.StockMovement {
+	return models.StockMovement{
+		SKU:      item.SKU,
+		Location: item.Location,
+		Delta:    int32(item.Count),
+		OwnerID:  ownerID,
+	}
+}
+
+func (u *stockUseCase) Delete(ctx context.Context, userID int64, sku uint32, location string) error {
 	tracer := otel.Tracer("stocks-usecase")
 	ctx, span := tracer.Start(ctx, "Delete")
 	defer span.End()
 
-	span.SetAttributes(attribute.Int64("item.sku", int64(sku)))
+	span.SetAttributes(
+		attribute.Int64("user.id", userID),
+		attribute.Int64("item.sku", int64(sku)),
+		attribute.String("item.location", location),
+	)
 
 	return u.txManager.Do(ctx, func(ctx context.Context) error {
-		return u.repo.Delete(ctx, sku)
+		deleted, err := u.repo.Delete(ctx, sku, location)
+		if err != nil {
+			return err
+		}
+
+		// Delete may span rows of several owners; any row the caller may not
+		// manage rolls the whole delete back.
+		movements := make([]models.StockMovement, 0, len(deleted))
+		for _, item := range deleted {
+			if err := policy.ManageStock(ctx, item.UserID); err != nil {
+				span.SetStatus(codes.Error, "permission denied")
+				return err
+			}
+
+			movements = append(movements, models.StockMovement{
+				SKU:      item.SKU,
+				Location: item.Location,
+				Delta:    -int32(item.Count),
+				OwnerID:  item.UserID,
+			})
+		}
+
+		err = u.recordMovements(ctx, models.MovementReasonRemoval, userID, movements...)
+		if err != nil {
+			return err
+		}
+
+		remaining := 0
+
+		left, err := u.repo.GetBySKU(ctx, sku)
+		switch {
+		case err == nil:
+			remaining = int(left.Count)
+		case !stdErrors.Is(err, errors.ErrItemNotFound):
+			span.RecordError(err)
+			return err
+		}
+
+		for _, item := range deleted {
+			if err := u.sendStockDeletedEvent(ctx, item, remaining); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
-func (u *stockUseCase) GetBySKU(ctx context.Context, sku uint32) (models.StockItem, error) {
-	return u.repo.GetBySKU(ctx, sku)
+// GetBySKU returns the stock of sku at location, or its total across all
+// locations when location is empty.
+func (u *stockUseCase) GetBySKU(ctx context.Context, sku uint32, location string) (models.StockItem, error) {
+	if location == "" {
+		return u.repo.GetBySKU(ctx, sku)
+	}
+
+	return u.repo.GetBySKULocation(ctx, sku, location)
+}
+
+func (u *stockUseCase) GetBySKUs(ctx context.Context, skus []uint32) ([]models.StockItem, error) {
+	return u.repo.GetBySKUs(ctx, skus)
 }
 
-func (u *stockUseCase) ListByLocation(ctx context.Context, location string, pageSize, currentPage int64) ([]models.StockItem, error) {
-	return u.repo.ListByLocation(ctx, location, pageSize, currentPage)
+func (u *stockUseCase) ListByLocation(ctx context.Context, filter models.LocationFilter) ([]models.StockItem, error) {
+	return u.repo.ListByLocation(ctx, filter)
+}
+
+func (u *stockUseCase) Reduce(ctx context.Context, items []models.StockItem) error {
+	tracer := otel.Tracer("stocks-usecase")
+	ctx, span := tracer.Start(ctx, "Reduce")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("items.count", len(items)))
+
+	if err := policy.ReduceStock(ctx); err != nil {
+		span.SetStatus(codes.Error, "permission denied")
+		return err
+	}
+
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		for _, item := range items {
+			updated, movements, err := u.repo.DecreaseCount(ctx, item.SKU, item.Count)
+			if err != nil {
+				u.logger.Error("failed to decrease stock count",
+					log.UInt32("sku", item.SKU),
+					log.UInt16("count", item.Count),
+					log.Error(err),
+				)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "decrease failed")
+
+				return err
+			}
+
+			err = u.recordMovements(ctx, models.MovementReasonSale, item.UserID, movements...)
+			if err != nil {
+				span.RecordError(err)
+				return err
+			}
+
+			if err := u.sendStockChangedEvent(ctx, updated.SKU, int(updated.Count), updated.Price); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
@@ -4,13 +4,16 @@ import (
 	"context"
 	stdErr "errors"
 	"fmt"
+	"stocks/internal/auth"
 	"stocks/internal/errors"
 	"stocks/internal/log/zap"
 	"stocks/internal/models"
+	"stocks/internal/money"
 	"stocks/internal/repository/mocks"
 	"stocks/internal/usecase"
 	mockKafka "stocks/internal/usecase/mocks"
 	"testing"
+	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/golang/mock/gomock"
@@ -19,17 +22,19 @@ import (
 func TestStockUseCase_Add(t *testing.T) {
 	t.Parallel()
 
-	ctx := context.Background()
+	sellerCtx := callerContext(1, auth.RoleSeller)
 	item := models.StockItem{
 		UserID:   1,
 		SKU:      1001,
-		Price:    10.0,
+		Price:    money.New("RUB", 1000),
 		Count:    5,
 		Location: "loc1",
 	}
 
 	tests := []struct {
 		name      string
+		ctx       context.Context
+		item      *models.StockItem
 		mockSetup func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface)
 		wantErr   error
 	}{
@@ -37,9 +42,18 @@ func TestStockUseCase_Add(t *testing.T) {
 		{
 			name: "success new insert",
 			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
-				mockRepo.EXPECT().GetSKUInfo(gomock.Any(), item.SKU).Return("t-shirt", "apparel", nil)
-				mockRepo.EXPECT().GetByUserSKU(gomock.Any(), item.UserID, item.SKU).Return(models.StockItem{}, errors.ErrItemNotFound)
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(models.StockItem{}, errors.ErrItemNotFound)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(models.StockItem{}, errors.ErrItemNotFound)
 				mockRepo.EXPECT().InsertStockItem(gomock.Any(), item).Return(nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), models.StockMovement{
+					SKU:      item.SKU,
+					Location: item.Location,
+					Delta:    int32(item.Count),
+					Reason:   models.MovementReasonRestock,
+					UserID:   item.UserID,
+					OwnerID:  item.UserID,
+				}).Return(nil)
 				mockProducer.EXPECT().
 					SendSKUCreated(gomock.Any(), fmt.Sprint(item.SKU), item.Price, int(item.Count)).
 					Return(nil)
@@ -48,15 +62,41 @@ func TestStockUseCase_Add(t *testing.T) {
 			wantErr: nil,
 		},
 
+		{
+			name: "new location of a SKU on sale elsewhere",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(models.StockItem{}, errors.ErrItemNotFound)
+				gomock.InOrder(
+					mockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(models.StockItem{SKU: item.SKU, Count: 12, Price: item.Price}, nil),
+					mockRepo.EXPECT().InsertStockItem(gomock.Any(), item).Return(nil),
+					mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil),
+					mockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(models.StockItem{SKU: item.SKU, Count: 17, Price: item.Price}, nil),
+				)
+				// The SKU-wide 17, not sku_created with the new location's 5.
+				mockProducer.EXPECT().SendStockChanged(gomock.Any(), fmt.Sprint(item.SKU), 17, item.Price).Return(nil)
+			},
+		},
+
 		{
 			name: "success update existing",
 			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
 				existing := item
 				existing.Count = 3
 
-				mockRepo.EXPECT().GetSKUInfo(gomock.Any(), item.SKU).Return("t-shirt", "apparel", nil)
-				mockRepo.EXPECT().GetByUserSKU(gomock.Any(), item.UserID, item.SKU).Return(existing, nil)
-				mockRepo.EXPECT().UpdateCount(gomock.Any(), item.UserID, item.SKU, existing.Count+item.Count, item.Price).Return(nil)
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(existing, nil)
+				mockRepo.EXPECT().UpdateCount(gomock.Any(), item.SKU, item.Location, existing.Count+item.Count, item.Price).Return(nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), models.StockMovement{
+					SKU:      item.SKU,
+					Location: item.Location,
+					Delta:    int32(item.Count),
+					Reason:   models.MovementReasonRestock,
+					UserID:   item.UserID,
+					OwnerID:  item.UserID,
+				}).Return(nil)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).
+					Return(models.StockItem{SKU: item.SKU, Count: existing.Count + item.Count, Price: item.Price}, nil)
 				mockProducer.EXPECT().
 					SendStockChanged(gomock.Any(), fmt.Sprint(existing.SKU), int(existing.Count+item.Count), existing.Price).
 					Return(nil)
@@ -64,29 +104,106 @@ func TestStockUseCase_Add(t *testing.T) {
 			wantErr: nil,
 		},
 
+		{
+			name: "restock at a new price",
+			item: &models.StockItem{UserID: 1, SKU: 1001, Price: money.New("RUB", 1200), Count: 5, Location: "loc1"},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				existing := item
+				existing.Count = 3
+
+				newPrice := money.New("RUB", 1200)
+
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(existing, nil)
+				mockRepo.EXPECT().UpdateCount(gomock.Any(), item.SKU, item.Location, existing.Count+item.Count, newPrice).Return(nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+				mockProducer.EXPECT().
+					SendPriceChanged(gomock.Any(), fmt.Sprint(item.SKU), item.Location, item.Price, newPrice).
+					Return(nil)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).
+					Return(models.StockItem{SKU: item.SKU, Count: existing.Count + item.Count, Price: newPrice}, nil)
+				mockProducer.EXPECT().
+					SendStockChanged(gomock.Any(), fmt.Sprint(item.SKU), int(existing.Count+item.Count), newPrice).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "price without currency is read in the SKU's",
+			item: &models.StockItem{UserID: 1, SKU: 1001, Price: money.New("", 1000), Count: 5, Location: "loc1"},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(models.StockItem{}, errors.ErrItemNotFound)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).Return(models.StockItem{}, errors.ErrItemNotFound)
+				mockRepo.EXPECT().InsertStockItem(gomock.Any(), item).Return(nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+				mockProducer.EXPECT().SendSKUCreated(gomock.Any(), fmt.Sprint(item.SKU), item.Price, int(item.Count)).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "currency mismatch error",
+			item: &models.StockItem{UserID: 1, SKU: 1001, Price: money.New("USD", 1000), Count: 5, Location: "loc1"},
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+			},
+			wantErr: errors.ErrCurrencyMismatch,
+		},
 		{
 			name: "invalid sku error",
 			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
-				mockRepo.EXPECT().GetSKUInfo(gomock.Any(), item.SKU).Return("", "", stdErr.New("not found"))
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{}, errors.ErrSKUNotFound)
 			},
 			wantErr: errors.ErrInvalidSKU,
 		},
+		{
+			name: "archived sku error",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).
+					Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB", ArchivedAt: time.Now()}, nil)
+			},
+			wantErr: errors.ErrSKUArchived,
+		},
 		{
 			name: "ownership violation error",
 			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
 				existing := item
 				existing.UserID = 999
-				mockRepo.EXPECT().GetSKUInfo(gomock.Any(), item.SKU).Return("t-shirt", "apparel", nil)
-				mockRepo.EXPECT().GetByUserSKU(gomock.Any(), item.UserID, item.SKU).Return(existing, nil)
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(existing, nil)
 			},
 			wantErr: errors.ErrOwnershipViolation,
 		},
+		{
+			name: "admin restocks another seller's row",
+			ctx:  callerContext(2, auth.RoleAdmin),
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				existing := item
+				existing.UserID = 999
+				existing.Count = 3
+
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(existing, nil)
+				mockRepo.EXPECT().UpdateCount(gomock.Any(), item.SKU, item.Location, existing.Count+item.Count, item.Price).Return(nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), item.SKU).
+					Return(models.StockItem{SKU: item.SKU, Count: existing.Count + item.Count, Price: item.Price}, nil)
+				mockProducer.EXPECT().SendStockChanged(gomock.Any(), fmt.Sprint(item.SKU), int(existing.Count+item.Count), existing.Price).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "buyer cannot add stock",
+			ctx:       callerContext(1, auth.RoleBuyer),
+			mockSetup: func(*mocks.MockStockRepository, *mockKafka.MockProducerInterface) {},
+			wantErr:   errors.ErrPermissionDenied,
+		},
 
 		{
 			name: "other repo error",
 			mockSetup: func(mockRepo *mocks.MockStockRepository, _ *mockKafka.MockProducerInterface) {
-				mockRepo.EXPECT().GetSKUInfo(gomock.Any(), item.SKU).Return("t-shirt", "apparel", nil)
-				mockRepo.EXPECT().GetByUserSKU(gomock.Any(), item.UserID, item.SKU).Return(models.StockItem{}, stdErr.New("some error"))
+				mockRepo.EXPECT().GetSKU(gomock.Any(), item.SKU).Return(models.SKU{SKU: item.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+				mockRepo.EXPECT().GetBySKULocation(gomock.Any(), item.SKU, item.Location).Return(models.StockItem{}, stdErr.New("some error"))
 			},
 			wantErr: stdErr.New("some error"),
 		},
@@ -111,7 +228,17 @@ func TestStockUseCase_Add(t *testing.T) {
 			uc := usecase.NewStockUsecase(mockRepo, txManager, mockProducer, logger)
 			tt.mockSetup(mockRepo, mockProducer)
 
-			err = uc.Add(ctx, item)
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = sellerCtx
+			}
+
+			input := item
+			if tt.item != nil {
+				input = *tt.item
+			}
+
+			err = uc.Add(ctx, input)
 
 			if tt.wantErr == nil {
 				if err != nil {
@@ -143,40 +270,106 @@ func TestStockUseCase_Delete(t *testing.T) {
 	defer cleanup()
 
 	uc := usecase.NewStockUsecase(mockRepo, txManager, mockProducer, logger)
-	ctx := context.Background()
+	ctx := callerContext(1, auth.RoleSeller)
 
 	tests := []struct {
 		name      string
+		sku       uint32
 		mockSetup func()
 		wantErr   error
 	}{
 
 		{
 			name: "success delete",
+			sku:  1001,
+			mockSetup: func() {
+				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1001), "loc1").Return([]models.StockItem{
+					{UserID: 1, SKU: 1001, Count: 4, Location: "loc1"},
+				}, nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), models.StockMovement{
+					SKU:      1001,
+					Location: "loc1",
+					Delta:    -4,
+					Reason:   models.MovementReasonRemoval,
+					OwnerID:  1,
+					UserID:   1,
+				}).Return(nil)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(1001)).Return(models.StockItem{SKU: 1001, Count: 6}, nil)
+				mockProducer.EXPECT().
+					SendStockDeleted(gomock.Any(), models.StockItem{UserID: 1, SKU: 1001, Count: 4, Location: "loc1"}, 6).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "last stock of the sku deleted",
+			sku:  1005,
 			mockSetup: func() {
-				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1001)).Return(nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1005), "loc1").Return([]models.StockItem{
+					{UserID: 1, SKU: 1005, Count: 2, Location: "loc1"},
+				}, nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(1005)).Return(models.StockItem{}, errors.ErrItemNotFound)
+				mockProducer.EXPECT().
+					SendStockDeleted(gomock.Any(), models.StockItem{UserID: 1, SKU: 1005, Count: 2, Location: "loc1"}, 0).
+					Return(nil)
 			},
 			wantErr: nil,
 		},
+		{
+			name: "event error",
+			sku:  1006,
+			mockSetup: func() {
+				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1006), "loc1").Return([]models.StockItem{
+					{UserID: 1, SKU: 1006, Count: 2, Location: "loc1"},
+				}, nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetBySKU(gomock.Any(), uint32(1006)).Return(models.StockItem{SKU: 1006, Count: 1}, nil)
+				mockProducer.EXPECT().SendStockDeleted(gomock.Any(), gomock.Any(), 1).Return(stdErr.New("kafka error"))
+			},
+			wantErr: stdErr.New("kafka error"),
+		},
 		{
 			name: "delete error",
+			sku:  1002,
 			mockSetup: func() {
-				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1001)).Return(stdErr.New("delete error"))
+				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1002), "loc1").Return(nil, stdErr.New("delete error"))
 			},
 			wantErr: stdErr.New("delete error"),
 		},
+		{
+			name: "ledger error",
+			sku:  1003,
+			mockSetup: func() {
+				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1003), "loc1").Return([]models.StockItem{
+					{UserID: 1, SKU: 1003, Count: 1, Location: "loc1"},
+				}, nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(stdErr.New("ledger error"))
+			},
+			wantErr: stdErr.New("ledger error"),
+		},
+		{
+			name: "row owned by another seller",
+			sku:  1004,
+			mockSetup: func() {
+				mockRepo.EXPECT().Delete(gomock.Any(), uint32(1004), "loc1").Return([]models.StockItem{
+					{UserID: 2, SKU: 1004, Count: 1, Location: "loc1"},
+				}, nil)
+			},
+			wantErr: errors.ErrOwnershipViolation,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			tt.mockSetup()
 
-			err := uc.Delete(ctx, 1001)
+			err := uc.Delete(ctx, 1, tt.sku, "loc1")
 			if tt.wantErr == nil && err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
 
-			if tt.wantErr != nil && (err == nil || err.Error() != tt.wantErr.Error()) {
+			if tt.wantErr != nil && (err == nil || (!stdErr.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error())) {
 				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
 			}
 		})
@@ -207,30 +400,53 @@ func TestStockUseCase_GetBySKU(t *testing.T) {
 		SKU:      1001,
 		Name:     "t-shirt",
 		Type:     "apparel",
-		Price:    10.0,
+		Price:    money.New("RUB", 1000),
 		Count:    5,
 		Location: "loc1",
 	}
 
+	aggregatedItem := models.StockItem{
+		SKU:      1002,
+		Name:     "t-shirt",
+		Type:     "apparel",
+		Price:    money.New("RUB", 1200),
+		Count:    9,
+		Reserved: 2,
+	}
+
 	tests := []struct {
 		name         string
+		sku          uint32
+		location     string
 		mockSetup    func()
 		wantItem     models.StockItem
 		expectErrStr string
 	}{
 
 		{
-			name: "success",
+			name:     "success",
+			sku:      1001,
+			location: "loc1",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetBySKU(ctx, uint32(1001)).Return(expectedItem, nil)
+				mockRepo.EXPECT().GetBySKULocation(ctx, uint32(1001), "loc1").Return(expectedItem, nil)
 			},
 			wantItem: expectedItem,
 		},
+		{
+			name: "all locations",
+			sku:  1002,
+			mockSetup: func() {
+				mockRepo.EXPECT().GetBySKU(ctx, uint32(1002)).Return(aggregatedItem, nil)
+			},
+			wantItem: aggregatedItem,
+		},
 		{
 
-			name: "not found",
+			name:     "not found",
+			sku:      1003,
+			location: "loc1",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetBySKU(ctx, uint32(1001)).Return(models.StockItem{}, stdErr.New("not found"))
+				mockRepo.EXPECT().GetBySKULocation(ctx, uint32(1003), "loc1").Return(models.StockItem{}, stdErr.New("not found"))
 			},
 			expectErrStr: "not found",
 		},
@@ -241,7 +457,7 @@ func TestStockUseCase_GetBySKU(t *testing.T) {
 			t.Parallel()
 			tt.mockSetup()
 
-			item, err := uc.GetBySKU(ctx, 1001)
+			item, err := uc.GetBySKU(ctx, tt.sku, tt.location)
 			if tt.expectErrStr == "" {
 				if err != nil {
 					t.Fatalf("unexpected error: %v", err)
@@ -277,6 +493,7 @@ func TestStockUseCase_ListByLocation(t *testing.T) {
 
 	uc := usecase.NewStockUsecase(mockRepo, txManager, mockProducer, logger)
 	ctx := context.Background()
+	filter := models.LocationFilter{Location: "loc1", Sort: models.SortByPrice, Limit: 10}
 
 	expectedItems := []models.StockItem{
 		{
@@ -284,7 +501,7 @@ func TestStockUseCase_ListByLocation(t *testing.T) {
 			SKU:      1001,
 			Name:     "t-shirt",
 			Type:     "apparel",
-			Price:    10.0,
+			Price:    money.New("RUB", 1000),
 			Count:    5,
 			Location: "loc1",
 		},
@@ -293,7 +510,7 @@ func TestStockUseCase_ListByLocation(t *testing.T) {
 			SKU:      2020,
 			Name:     "cup",
 			Type:     "accessory",
-			Price:    5.0,
+			Price:    money.New("RUB", 500),
 			Count:    3,
 			Location: "loc1",
 		},
@@ -309,7 +526,7 @@ func TestStockUseCase_ListByLocation(t *testing.T) {
 		{
 			name: "success",
 			mockSetup: func() {
-				mockRepo.EXPECT().ListByLocation(ctx, "loc1", int64(10), int64(1)).Return(expectedItems, nil)
+				mockRepo.EXPECT().ListByLocation(ctx, filter).Return(expectedItems, nil)
 			},
 			wantItems: expectedItems,
 		},
@@ -317,7 +534,7 @@ func TestStockUseCase_ListByLocation(t *testing.T) {
 		{
 			name: "error",
 			mockSetup: func() {
-				mockRepo.EXPECT().ListByLocation(ctx, "loc1", int64(10), int64(1)).Return(nil, stdErr.New("db error"))
+				mockRepo.EXPECT().ListByLocation(ctx, filter).Return(nil, stdErr.New("db error"))
 			},
 			expectErrStr: "db error",
 		},
@@ -327,7 +544,7 @@ func TestStockUseCase_ListByLocation(t *testing.T) {
 			t.Parallel()
 			tt.mockSetup()
 
-			items, err := uc.ListByLocation(ctx, "loc1", 10, 1)
+			items, err := uc.ListByLocation(ctx, filter)
 
 			if tt.expectErrStr == "" {
 				if err != nil {
@@ -346,6 +563,153 @@ func TestStockUseCase_ListByLocation(t *testing.T) {
 	}
 }
 
+func TestStockUseCase_Reduce(t *testing.T) {
+	t.Parallel()
+
+	items := []models.StockItem{
+		{SKU: 1001, Count: 2, UserID: 7},
+		{SKU: 2020, Count: 1, UserID: 7},
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		mockSetup func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface)
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().DecreaseCount(gomock.Any(), uint32(1001), uint16(2)).
+					Return(models.StockItem{SKU: 1001, Count: 3, Price: money.New("RUB", 1000)}, []models.StockMovement{
+						{SKU: 1001, Location: "loc1", Delta: -1, OwnerID: 2},
+						{SKU: 1001, Location: "loc2", Delta: -1, OwnerID: 3},
+					}, nil)
+				mockRepo.EXPECT().DecreaseCount(gomock.Any(), uint32(2020), uint16(1)).
+					Return(models.StockItem{SKU: 2020, Count: 0, Price: money.New("RUB", 500)}, []models.StockMovement{
+						{SKU: 2020, Location: "loc1", Delta: -1, OwnerID: 2},
+					}, nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), models.StockMovement{
+					SKU: 1001, Location: "loc1", Delta: -1, Reason: models.MovementReasonSale, UserID: 7, OwnerID: 2,
+				}).Return(nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), models.StockMovement{
+					SKU: 1001, Location: "loc2", Delta: -1, Reason: models.MovementReasonSale, UserID: 7, OwnerID: 3,
+				}).Return(nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), models.StockMovement{
+					SKU: 2020, Location: "loc1", Delta: -1, Reason: models.MovementReasonSale, UserID: 7, OwnerID: 2,
+				}).Return(nil)
+				mockProducer.EXPECT().SendStockChanged(gomock.Any(), "1001", 3, money.New("RUB", 1000)).Return(nil)
+				mockProducer.EXPECT().SendStockChanged(gomock.Any(), "2020", 0, money.New("RUB", 500)).Return(nil)
+			},
+		},
+		{
+			name: "not enough stock stops reduction",
+			mockSetup: func(mockRepo *mocks.MockStockRepository, mockProducer *mockKafka.MockProducerInterface) {
+				mockRepo.EXPECT().DecreaseCount(gomock.Any(), uint32(1001), uint16(2)).
+					Return(models.StockItem{SKU: 1001, Count: 3, Price: money.New("RUB", 1000)}, []models.StockMovement{
+						{SKU: 1001, Location: "loc1", Delta: -2},
+					}, nil)
+				mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+				mockProducer.EXPECT().SendStockChanged(gomock.Any(), "1001", 3, money.New("RUB", 1000)).Return(nil)
+				mockRepo.EXPECT().DecreaseCount(gomock.Any(), uint32(2020), uint16(1)).
+					Return(models.StockItem{}, nil, errors.ErrNotEnoughStock)
+			},
+			wantErr: errors.ErrNotEnoughStock,
+		},
+		{
+			name:      "buyer cannot reduce stock",
+			ctx:       callerContext(7, auth.RoleBuyer),
+			mockSetup: func(*mocks.MockStockRepository, *mockKafka.MockProducerInterface) {},
+			wantErr:   errors.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockStockRepository(ctrl)
+			mockProducer := mockKafka.NewMockProducerInterface(ctrl)
+			txManager := &mockTxManager{}
+
+			logger, cleanup, err := zap.NewLogger()
+			if err != nil {
+				t.Fatalf("failed to create logger: %v", err)
+			}
+			defer cleanup()
+
+			uc := usecase.NewStockUsecase(mockRepo, txManager, mockProducer, logger)
+			tt.mockSetup(mockRepo, mockProducer)
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = callerContext(0, auth.RoleService)
+			}
+
+			err = uc.Reduce(ctx, items)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != nil && !stdErr.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// SKU 1001 is held in loc1 and loc2: stock_changed reports both locations
+// whichever of them changed.
+func TestStockUseCase_StockChangedIsSKUWide(t *testing.T) {
+	t.Parallel()
+
+	loc1 := models.StockItem{UserID: 1, SKU: 1001, Price: money.New("RUB", 1000), Count: 3, Location: "loc1"}
+	loc2Price := money.New("RUB", 1200)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStockRepository(ctrl)
+	mockProducer := mockKafka.NewMockProducerInterface(ctrl)
+
+	logger, cleanup, err := zap.NewLogger()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer cleanup()
+
+	uc := usecase.NewStockUsecase(mockRepo, &mockTxManager{}, mockProducer, logger)
+
+	// Restocking 5 at loc1 (3 -> 8) next to 12 at loc2.
+	mockRepo.EXPECT().GetSKU(gomock.Any(), loc1.SKU).Return(models.SKU{SKU: loc1.SKU, Name: "t-shirt", Type: "apparel", Currency: "RUB"}, nil)
+	mockRepo.EXPECT().GetBySKULocation(gomock.Any(), loc1.SKU, loc1.Location).Return(loc1, nil)
+	mockRepo.EXPECT().UpdateCount(gomock.Any(), loc1.SKU, loc1.Location, uint16(8), loc1.Price).Return(nil)
+	mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetBySKU(gomock.Any(), loc1.SKU).Return(models.StockItem{SKU: loc1.SKU, Count: 20, Price: loc2Price}, nil)
+	mockProducer.EXPECT().SendStockChanged(gomock.Any(), "1001", 20, loc2Price).Return(nil)
+
+	restock := loc1
+	restock.Count = 5
+
+	if err = uc.Add(callerContext(1, auth.RoleSeller), restock); err != nil {
+		t.Fatalf("unexpected error on add: %v", err)
+	}
+
+	// Selling 2 of the 20.
+	mockRepo.EXPECT().DecreaseCount(gomock.Any(), loc1.SKU, uint16(2)).
+		Return(models.StockItem{SKU: loc1.SKU, Count: 18, Price: loc2Price}, []models.StockMovement{
+			{SKU: loc1.SKU, Location: "loc2", Delta: -2},
+		}, nil)
+	mockRepo.EXPECT().InsertMovement(gomock.Any(), gomock.Any()).Return(nil)
+	mockProducer.EXPECT().SendStockChanged(gomock.Any(), "1001", 18, loc2Price).Return(nil)
+
+	if err = uc.Reduce(callerContext(0, auth.RoleService), []models.StockItem{{SKU: loc1.SKU, Count: 2, UserID: 7}}); err != nil {
+		t.Fatalf("unexpected error on reduce: %v", err)
+	}
+}
+
 type mockTxManager struct{}
 
 func (m *mockTxManager) Do(ctx context.Context, f func(ctx context.Context) error) error {
@@ -355,3 +719,7 @@ func (m *mockTxManager) Do(ctx context.Context, f func(ctx context.Context) erro
 func (m *mockTxManager) DoWithSettings(ctx context.Context, settings trm.Settings, f func(ctx context.Context) error) error {
 	return f(ctx)
 }
+
+func callerContext(userID int64, roles ...auth.Role) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{UserID: userID, Roles: roles})
+}
@@ -9,7 +9,19 @@ import (
 
 type StockUseCase interface {
 	Add(ctx context.Context, item models.StockItem) error
-	Delete(ctx context.Context, sku uint32) error
-	GetBySKU(ctx context.Context, sku uint32) (models.StockItem, error)
-	ListByLocation(ctx context.Context, location string, pageSize, currentPage int64) ([]models.StockItem, error)
+	Delete(ctx context.Context, userID int64, sku uint32, location string) error
+	GetBySKU(ctx context.Context, sku uint32, location string) (models.StockItem, error)
+	GetBySKUs(ctx context.Context, skus []uint32) ([]models.StockItem, error)
+	ListByLocation(ctx context.Context, filter models.LocationFilter) ([]models.StockItem, error)
+	Reduce(ctx context.Context, items []models.StockItem) error
+	Reserve(ctx context.Context, reservation models.Reservation) (models.Reservation, error)
+	Release(ctx context.Context, reservationID int64) error
+	Commit(ctx context.Context, reservationID int64) error
+	ExpireReservations(ctx context.Context) (int, error)
+	ListMovements(ctx context.Context, filter models.MovementFilter) ([]models.StockMovement, error)
+	CreateSKU(ctx context.Context, sku models.SKU) (models.SKU, error)
+	UpdateSKU(ctx context.Context, sku models.SKU) (models.SKU, error)
+	ArchiveSKU(ctx context.Context, sku uint32) (models.SKU, error)
+	GetSKU(ctx context.Context, sku uint32) (models.SKU, error)
+	ListSKUs(ctx context.Context, filter models.SKUFilter) ([]models.SKU, error)
 }
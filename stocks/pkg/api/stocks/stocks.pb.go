@@ -8,6 +8,7 @@ package stockpb
 
 import (
 	reflect "reflect"
+	money "stocks/pkg/api/money"
 	sync "sync"
 	unsafe "unsafe"
 
@@ -23,13 +24,130 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ItemStatus int32
+
+const (
+	ItemStatus_ITEM_STATUS_UNSPECIFIED ItemStatus = 0
+	ItemStatus_ITEM_STATUS_FOUND       ItemStatus = 1
+	ItemStatus_ITEM_STATUS_NOT_FOUND   ItemStatus = 2
+)
+
+// Enum value maps for ItemStatus.
+var (
+	ItemStatus_name = map[int32]string{
+		0: "ITEM_STATUS_UNSPECIFIED",
+		1: "ITEM_STATUS_FOUND",
+		2: "ITEM_STATUS_NOT_FOUND",
+	}
+	ItemStatus_value = map[string]int32{
+		"ITEM_STATUS_UNSPECIFIED": 0,
+		"ITEM_STATUS_FOUND":       1,
+		"ITEM_STATUS_NOT_FOUND":   2,
+	}
+)
+
+func (x ItemStatus) Enum() *ItemStatus {
+	p := new(ItemStatus)
+	*p = x
+	return p
+}
+
+func (x ItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_stocks_stocks_proto_enumTypes[0].Descriptor()
+}
+
+func (ItemStatus) Type() protoreflect.EnumType {
+	return &file_stocks_stocks_proto_enumTypes[0]
+}
+
+func (x ItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ItemStatus.Descriptor instead.
+func (ItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{0}
+}
+
+// StockSort orders ListByLocation results. Ties are broken by SKU in the same
+// direction, so every order is total and can be paged with a cursor.
+type StockSort int32
+
+const (
+	StockSort_STOCK_SORT_UNSPECIFIED StockSort = 0
+	StockSort_STOCK_SORT_SKU_ASC     StockSort = 1
+	StockSort_STOCK_SORT_SKU_DESC    StockSort = 2
+	StockSort_STOCK_SORT_PRICE_ASC   StockSort = 3
+	StockSort_STOCK_SORT_PRICE_DESC  StockSort = 4
+	StockSort_STOCK_SORT_COUNT_ASC   StockSort = 5
+	StockSort_STOCK_SORT_COUNT_DESC  StockSort = 6
+)
+
+// Enum value maps for StockSort.
+var (
+	StockSort_name = map[int32]string{
+		0: "STOCK_SORT_UNSPECIFIED",
+		1: "STOCK_SORT_SKU_ASC",
+		2: "STOCK_SORT_SKU_DESC",
+		3: "STOCK_SORT_PRICE_ASC",
+		4: "STOCK_SORT_PRICE_DESC",
+		5: "STOCK_SORT_COUNT_ASC",
+		6: "STOCK_SORT_COUNT_DESC",
+	}
+	StockSort_value = map[string]int32{
+		"STOCK_SORT_UNSPECIFIED": 0,
+		"STOCK_SORT_SKU_ASC":     1,
+		"STOCK_SORT_SKU_DESC":    2,
+		"STOCK_SORT_PRICE_ASC":   3,
+		"STOCK_SORT_PRICE_DESC":  4,
+		"STOCK_SORT_COUNT_ASC":   5,
+		"STOCK_SORT_COUNT_DESC":  6,
+	}
+)
+
+func (x StockSort) Enum() *StockSort {
+	p := new(StockSort)
+	*p = x
+	return p
+}
+
+func (x StockSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_stocks_stocks_proto_enumTypes[1].Descriptor()
+}
+
+func (StockSort) Type() protoreflect.EnumType {
+	return &file_stocks_stocks_proto_enumTypes[1]
+}
+
+func (x StockSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockSort.Descriptor instead.
+func (StockSort) EnumDescriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{1}
+}
+
 type AddItemRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
-	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
-	UserId        uint64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Price         float32                `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sku      string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Location string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Count    int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	UserId   uint64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: use unit_price. Read as the SKU's currency when unit_price is
+	// not set.
+	//
+	// Deprecated: Marked as deprecated in stocks/stocks.proto.
+	Price         float32      `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -92,6 +210,7 @@ func (x *AddItemRequest) GetUserId() uint64 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in stocks/stocks.proto.
 func (x *AddItemRequest) GetPrice() float32 {
 	if x != nil {
 		return x.Price
@@ -99,6 +218,13 @@ func (x *AddItemRequest) GetPrice() float32 {
 	return 0
 }
 
+func (x *AddItemRequest) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
 type DeleteItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
@@ -219,28 +345,28 @@ func (x *GetItemRequest) GetUserId() uint64 {
 	return 0
 }
 
-type ListByLocationRequest struct {
+type GetItemsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Skus          []string               `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`
 	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListByLocationRequest) Reset() {
-	*x = ListByLocationRequest{}
+func (x *GetItemsRequest) Reset() {
+	*x = GetItemsRequest{}
 	mi := &file_stocks_stocks_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListByLocationRequest) String() string {
+func (x *GetItemsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListByLocationRequest) ProtoMessage() {}
+func (*GetItemsRequest) ProtoMessage() {}
 
-func (x *ListByLocationRequest) ProtoReflect() protoreflect.Message {
+func (x *GetItemsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_stocks_stocks_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -252,50 +378,48 @@ func (x *ListByLocationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListByLocationRequest.ProtoReflect.Descriptor instead.
-func (*ListByLocationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetItemsRequest) Descriptor() ([]byte, []int) {
 	return file_stocks_stocks_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ListByLocationRequest) GetLocation() string {
+func (x *GetItemsRequest) GetSkus() []string {
 	if x != nil {
-		return x.Location
+		return x.Skus
 	}
-	return ""
+	return nil
 }
 
-func (x *ListByLocationRequest) GetUserId() uint64 {
+func (x *GetItemsRequest) GetUserId() uint64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-type StockItem struct {
+type GetItemsResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
-	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
-	UserId        uint64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Price         float32                `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	Status        ItemStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=stock.ItemStatus" json:"status,omitempty"`
+	Item          *StockItem             `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StockItem) Reset() {
-	*x = StockItem{}
+func (x *GetItemsResult) Reset() {
+	*x = GetItemsResult{}
 	mi := &file_stocks_stocks_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StockItem) String() string {
+func (x *GetItemsResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StockItem) ProtoMessage() {}
+func (*GetItemsResult) ProtoMessage() {}
 
-func (x *StockItem) ProtoReflect() protoreflect.Message {
+func (x *GetItemsResult) ProtoReflect() protoreflect.Message {
 	mi := &file_stocks_stocks_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -307,68 +431,118 @@ func (x *StockItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
-func (*StockItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetItemsResult.ProtoReflect.Descriptor instead.
+func (*GetItemsResult) Descriptor() ([]byte, []int) {
 	return file_stocks_stocks_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *StockItem) GetSku() string {
+func (x *GetItemsResult) GetSku() string {
 	if x != nil {
 		return x.Sku
 	}
 	return ""
 }
 
-func (x *StockItem) GetLocation() string {
+func (x *GetItemsResult) GetStatus() ItemStatus {
 	if x != nil {
-		return x.Location
+		return x.Status
 	}
-	return ""
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
 }
 
-func (x *StockItem) GetCount() int32 {
+func (x *GetItemsResult) GetItem() *StockItem {
 	if x != nil {
-		return x.Count
+		return x.Item
 	}
-	return 0
+	return nil
 }
 
-func (x *StockItem) GetUserId() uint64 {
+type GetItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*GetItemsResult      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemsResponse) Reset() {
+	*x = GetItemsResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemsResponse) ProtoMessage() {}
+
+func (x *GetItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[5]
 	if x != nil {
-		return x.UserId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *StockItem) GetPrice() float32 {
+// Deprecated: Use GetItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetItemsResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetItemsResponse) GetResults() []*GetItemsResult {
 	if x != nil {
-		return x.Price
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-type StockResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+type ListByLocationRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	UserId   uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// page_size defaults to 100, at most 1000.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page. It is bound to
+	// the sort order it was issued for.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Optional filters; zero values match everything.
+	Type string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	// Deprecated: use min_unit_price and max_unit_price.
+	//
+	// Deprecated: Marked as deprecated in stocks/stocks.proto.
+	MinPrice float32 `protobuf:"fixed32,6,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	// Deprecated: Marked as deprecated in stocks/stocks.proto.
+	MaxPrice float32 `protobuf:"fixed32,7,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	MinCount int32   `protobuf:"varint,8,opt,name=min_count,json=minCount,proto3" json:"min_count,omitempty"`
+	// sort defaults to STOCK_SORT_SKU_ASC.
+	Sort StockSort `protobuf:"varint,9,opt,name=sort,proto3,enum=stock.StockSort" json:"sort,omitempty"`
+	// A price bound also restricts the result to SKUs priced in its currency.
+	MinUnitPrice  *money.Money `protobuf:"bytes,10,opt,name=min_unit_price,json=minUnitPrice,proto3" json:"min_unit_price,omitempty"`
+	MaxUnitPrice  *money.Money `protobuf:"bytes,11,opt,name=max_unit_price,json=maxUnitPrice,proto3" json:"max_unit_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StockResponse) Reset() {
-	*x = StockResponse{}
-	mi := &file_stocks_stocks_proto_msgTypes[5]
+func (x *ListByLocationRequest) Reset() {
+	*x = ListByLocationRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StockResponse) String() string {
+func (x *ListByLocationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StockResponse) ProtoMessage() {}
+func (*ListByLocationRequest) ProtoMessage() {}
 
-func (x *StockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stocks_stocks_proto_msgTypes[5]
+func (x *ListByLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,42 +553,125 @@ func (x *StockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StockResponse.ProtoReflect.Descriptor instead.
-func (*StockResponse) Descriptor() ([]byte, []int) {
-	return file_stocks_stocks_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListByLocationRequest.ProtoReflect.Descriptor instead.
+func (*ListByLocationRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *StockResponse) GetMessage() string {
+func (x *ListByLocationRequest) GetLocation() string {
 	if x != nil {
-		return x.Message
+		return x.Location
 	}
 	return ""
 }
 
-type ListByLocationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
-	Items         []*StockItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	UserId        uint64                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+func (x *ListByLocationRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListByLocationRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListByLocationRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListByLocationRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in stocks/stocks.proto.
+func (x *ListByLocationRequest) GetMinPrice() float32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in stocks/stocks.proto.
+func (x *ListByLocationRequest) GetMaxPrice() float32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListByLocationRequest) GetMinCount() int32 {
+	if x != nil {
+		return x.MinCount
+	}
+	return 0
+}
+
+func (x *ListByLocationRequest) GetSort() StockSort {
+	if x != nil {
+		return x.Sort
+	}
+	return StockSort_STOCK_SORT_UNSPECIFIED
+}
+
+func (x *ListByLocationRequest) GetMinUnitPrice() *money.Money {
+	if x != nil {
+		return x.MinUnitPrice
+	}
+	return nil
+}
+
+func (x *ListByLocationRequest) GetMaxUnitPrice() *money.Money {
+	if x != nil {
+		return x.MaxUnitPrice
+	}
+	return nil
+}
+
+type StockItem struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sku      string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Location string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Count    int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	UserId   uint64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: use unit_price.
+	//
+	// Deprecated: Marked as deprecated in stocks/stocks.proto.
+	Price         float32      `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	Reserved      int32        `protobuf:"varint,6,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available     int32        `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
+	Name          string       `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string       `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	Archived      bool         `protobuf:"varint,10,opt,name=archived,proto3" json:"archived,omitempty"`
+	UnitPrice     *money.Money `protobuf:"bytes,11,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListByLocationResponse) Reset() {
-	*x = ListByLocationResponse{}
-	mi := &file_stocks_stocks_proto_msgTypes[6]
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_stocks_stocks_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListByLocationResponse) String() string {
+func (x *StockItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListByLocationResponse) ProtoMessage() {}
+func (*StockItem) ProtoMessage() {}
 
-func (x *ListByLocationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stocks_stocks_proto_msgTypes[6]
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -425,72 +682,1415 @@ func (x *ListByLocationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListByLocationResponse.ProtoReflect.Descriptor instead.
-func (*ListByLocationResponse) Descriptor() ([]byte, []int) {
-	return file_stocks_stocks_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListByLocationResponse) GetLocation() string {
+func (x *StockItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *StockItem) GetLocation() string {
 	if x != nil {
 		return x.Location
 	}
 	return ""
 }
 
-func (x *ListByLocationResponse) GetItems() []*StockItem {
+func (x *StockItem) GetCount() int32 {
 	if x != nil {
-		return x.Items
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-func (x *ListByLocationResponse) GetUserId() uint64 {
+func (x *StockItem) GetUserId() uint64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-var File_stocks_stocks_proto protoreflect.FileDescriptor
+// Deprecated: Marked as deprecated in stocks/stocks.proto.
+func (x *StockItem) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
 
-const file_stocks_stocks_proto_rawDesc = "" +
-	"\n" +
-	"\x13stocks/stocks.proto\x12\x05stock\x1a\x1cgoogle/api/annotations.proto\"\x83\x01\n" +
-	"\x0eAddItemRequest\x12\x10\n" +
-	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\blocation\x18\x02 \x01(\tR\blocation\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\x04R\x06userId\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x02R\x05price\"Z\n" +
-	"\x11DeleteItemRequest\x12\x10\n" +
-	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\blocation\x18\x02 \x01(\tR\blocation\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\x04R\x06userId\"W\n" +
-	"\x0eGetItemRequest\x12\x10\n" +
-	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\blocation\x18\x02 \x01(\tR\blocation\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\x04R\x06userId\"L\n" +
-	"\x15ListByLocationRequest\x12\x1a\n" +
-	"\blocation\x18\x01 \x01(\tR\blocation\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x04R\x06userId\"~\n" +
-	"\tStockItem\x12\x10\n" +
-	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\blocation\x18\x02 \x01(\tR\blocation\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\x04R\x06userId\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x02R\x05price\")\n" +
+func (x *StockItem) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *StockItem) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *StockItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StockItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StockItem) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *StockItem) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+type StockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockResponse) Reset() {
+	*x = StockResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockResponse) ProtoMessage() {}
+
+func (x *StockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockResponse.ProtoReflect.Descriptor instead.
+func (*StockResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListByLocationResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Items    []*StockItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	UserId   uint64                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByLocationResponse) Reset() {
+	*x = ListByLocationResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByLocationResponse) ProtoMessage() {}
+
+func (x *ListByLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByLocationResponse.ProtoReflect.Descriptor instead.
+func (*ListByLocationResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListByLocationResponse) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ListByLocationResponse) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListByLocationResponse) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListByLocationResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ReduceStockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReduceStockItem) Reset() {
+	*x = ReduceStockItem{}
+	mi := &file_stocks_stocks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReduceStockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReduceStockItem) ProtoMessage() {}
+
+func (x *ReduceStockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReduceStockItem.ProtoReflect.Descriptor instead.
+func (*ReduceStockItem) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReduceStockItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ReduceStockItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReduceStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ReduceStockItem     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReduceStockRequest) Reset() {
+	*x = ReduceStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReduceStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReduceStockRequest) ProtoMessage() {}
+
+func (x *ReduceStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReduceStockRequest.ProtoReflect.Descriptor instead.
+func (*ReduceStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReduceStockRequest) GetItems() []*ReduceStockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReduceStockRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ReservationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationItem) Reset() {
+	*x = ReservationItem{}
+	mi := &file_stocks_stocks_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationItem) ProtoMessage() {}
+
+func (x *ReservationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationItem.ProtoReflect.Descriptor instead.
+func (*ReservationItem) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReservationItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ReservationItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReserveItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ReservationItem     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveItemsRequest) Reset() {
+	*x = ReserveItemsRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveItemsRequest) ProtoMessage() {}
+
+func (x *ReserveItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveItemsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveItemsRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReserveItemsRequest) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveItemsRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReserveItemsRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type ReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationRequest) Reset() {
+	*x = ReservationRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationRequest) ProtoMessage() {}
+
+func (x *ReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReservationRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReservationRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_stocks_stocks_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Reservation) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *Reservation) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reservation) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Reservation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type ListMovementsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sku      string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Location string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	UserId   uint64                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// RFC3339, inclusive.
+	From string `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	// RFC3339, exclusive.
+	To            string `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	PageSize      int32  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsRequest) Reset() {
+	*x = ListMovementsRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsRequest) ProtoMessage() {}
+
+func (x *ListMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMovementsRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type StockMovement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MovementId    string                 `protobuf:"bytes,1,opt,name=movement_id,json=movementId,proto3" json:"movement_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Delta         int32                  `protobuf:"varint,4,opt,name=delta,proto3" json:"delta,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	UserId        uint64                 `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TraceId       string                 `protobuf:"bytes,7,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_stocks_stocks_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StockMovement) GetMovementId() string {
+	if x != nil {
+		return x.MovementId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *StockMovement) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *StockMovement) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockMovement) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StockMovement) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsResponse) Reset() {
+	*x = ListMovementsResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsResponse) ProtoMessage() {}
+
+func (x *ListMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *ListMovementsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SKU struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sku      string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type     string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Archived bool                   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	// RFC3339, empty unless archived.
+	ArchivedAt string `protobuf:"bytes,5,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	CreatedAt  string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  string `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// ISO 4217 code every location of the SKU is priced in.
+	CurrencyCode  string `protobuf:"bytes,8,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SKU) Reset() {
+	*x = SKU{}
+	mi := &file_stocks_stocks_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SKU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SKU) ProtoMessage() {}
+
+func (x *SKU) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SKU.ProtoReflect.Descriptor instead.
+func (*SKU) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SKU) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SKU) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SKU) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SKU) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *SKU) GetArchivedAt() string {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return ""
+}
+
+func (x *SKU) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SKU) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *SKU) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+type CreateSKURequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type  string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Defaults to RUB. Cannot be changed later.
+	CurrencyCode  string `protobuf:"bytes,4,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSKURequest) Reset() {
+	*x = CreateSKURequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSKURequest) ProtoMessage() {}
+
+func (x *CreateSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSKURequest.ProtoReflect.Descriptor instead.
+func (*CreateSKURequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateSKURequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateSKURequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSKURequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateSKURequest) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+// Empty fields keep their current value.
+type UpdateSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSKURequest) Reset() {
+	*x = UpdateSKURequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSKURequest) ProtoMessage() {}
+
+func (x *UpdateSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSKURequest.ProtoReflect.Descriptor instead.
+func (*UpdateSKURequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateSKURequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *UpdateSKURequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSKURequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ArchiveSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveSKURequest) Reset() {
+	*x = ArchiveSKURequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveSKURequest) ProtoMessage() {}
+
+func (x *ArchiveSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveSKURequest.ProtoReflect.Descriptor instead.
+func (*ArchiveSKURequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ArchiveSKURequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+type GetSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSKURequest) Reset() {
+	*x = GetSKURequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSKURequest) ProtoMessage() {}
+
+func (x *GetSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSKURequest.ProtoReflect.Descriptor instead.
+func (*GetSKURequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetSKURequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+type ListSKUsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	PageSize        int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken       string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListSKUsRequest) Reset() {
+	*x = ListSKUsRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSKUsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSKUsRequest) ProtoMessage() {}
+
+func (x *ListSKUsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSKUsRequest.ProtoReflect.Descriptor instead.
+func (*ListSKUsRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListSKUsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListSKUsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *ListSKUsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSKUsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSKUsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skus          []*SKU                 `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSKUsResponse) Reset() {
+	*x = ListSKUsResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSKUsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSKUsResponse) ProtoMessage() {}
+
+func (x *ListSKUsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSKUsResponse.ProtoReflect.Descriptor instead.
+func (*ListSKUsResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSKUsResponse) GetSkus() []*SKU {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *ListSKUsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_stocks_stocks_proto protoreflect.FileDescriptor
+
+const file_stocks_stocks_proto_rawDesc = "" +
+	"\n" +
+	"\x13stocks/stocks.proto\x12\x05stock\x1a\x1cgoogle/api/annotations.proto\x1a\x11money/money.proto\"\xb4\x01\n" +
+	"\x0eAddItemRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x04R\x06userId\x12\x18\n" +
+	"\x05price\x18\x05 \x01(\x02B\x02\x18\x01R\x05price\x12+\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\v2\f.money.MoneyR\tunitPrice\"Z\n" +
+	"\x11DeleteItemRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\"W\n" +
+	"\x0eGetItemRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\">\n" +
+	"\x0fGetItemsRequest\x12\x12\n" +
+	"\x04skus\x18\x01 \x03(\tR\x04skus\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\"s\n" +
+	"\x0eGetItemsResult\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12)\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x11.stock.ItemStatusR\x06status\x12$\n" +
+	"\x04item\x18\x03 \x01(\v2\x10.stock.StockItemR\x04item\"C\n" +
+	"\x10GetItemsResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.stock.GetItemsResultR\aresults\"\x89\x03\n" +
+	"\x15ListByLocationRequest\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x1f\n" +
+	"\tmin_price\x18\x06 \x01(\x02B\x02\x18\x01R\bminPrice\x12\x1f\n" +
+	"\tmax_price\x18\a \x01(\x02B\x02\x18\x01R\bmaxPrice\x12\x1b\n" +
+	"\tmin_count\x18\b \x01(\x05R\bminCount\x12$\n" +
+	"\x04sort\x18\t \x01(\x0e2\x10.stock.StockSortR\x04sort\x122\n" +
+	"\x0emin_unit_price\x18\n" +
+	" \x01(\v2\f.money.MoneyR\fminUnitPrice\x122\n" +
+	"\x0emax_unit_price\x18\v \x01(\v2\f.money.MoneyR\fmaxUnitPrice\"\xad\x02\n" +
+	"\tStockItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x04R\x06userId\x12\x18\n" +
+	"\x05price\x18\x05 \x01(\x02B\x02\x18\x01R\x05price\x12\x1a\n" +
+	"\breserved\x18\x06 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\x05R\tavailable\x12\x12\n" +
+	"\x04name\x18\b \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\t \x01(\tR\x04type\x12\x1a\n" +
+	"\barchived\x18\n" +
+	" \x01(\bR\barchived\x12+\n" +
+	"\n" +
+	"unit_price\x18\v \x01(\v2\f.money.MoneyR\tunitPrice\")\n" +
 	"\rStockResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"u\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x9d\x01\n" +
 	"\x16ListByLocationResponse\x12\x1a\n" +
 	"\blocation\x18\x01 \x01(\tR\blocation\x12&\n" +
 	"\x05items\x18\x02 \x03(\v2\x10.stock.StockItemR\x05items\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\x04R\x06userId2\xfa\x02\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"9\n" +
+	"\x0fReduceStockItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"[\n" +
+	"\x12ReduceStockRequest\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.stock.ReduceStockItemR\x05items\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\"9\n" +
+	"\x0fReservationItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"}\n" +
+	"\x13ReserveItemsRequest\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.stock.ReservationItemR\x05items\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\"T\n" +
+	"\x12ReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\"\xb2\x01\n" +
+	"\vReservation\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12,\n" +
+	"\x05items\x18\x04 \x03(\v2\x16.stock.ReservationItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\"\xbd\x01\n" +
+	"\x14ListMovementsRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"\xdf\x01\n" +
+	"\rStockMovement\x12\x1f\n" +
+	"\vmovement_id\x18\x01 \x01(\tR\n" +
+	"movementId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\x05R\x05delta\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\x04R\x06userId\x12\x19\n" +
+	"\btrace_id\x18\a \x01(\tR\atraceId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"s\n" +
+	"\x15ListMovementsResponse\x122\n" +
+	"\tmovements\x18\x01 \x03(\v2\x14.stock.StockMovementR\tmovements\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xdf\x01\n" +
+	"\x03SKU\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x12\x1f\n" +
+	"\varchived_at\x18\x05 \x01(\tR\n" +
+	"archivedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12#\n" +
+	"\rcurrency_code\x18\b \x01(\tR\fcurrencyCode\"q\n" +
+	"\x10CreateSKURequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12#\n" +
+	"\rcurrency_code\x18\x04 \x01(\tR\fcurrencyCode\"L\n" +
+	"\x10UpdateSKURequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"%\n" +
+	"\x11ArchiveSKURequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\"!\n" +
+	"\rGetSKURequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\"\x8c\x01\n" +
+	"\x0fListSKUsRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"Z\n" +
+	"\x10ListSKUsResponse\x12\x1e\n" +
+	"\x04skus\x18\x01 \x03(\v2\n" +
+	".stock.SKUR\x04skus\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*[\n" +
+	"\n" +
+	"ItemStatus\x12\x1b\n" +
+	"\x17ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11ITEM_STATUS_FOUND\x10\x01\x12\x19\n" +
+	"\x15ITEM_STATUS_NOT_FOUND\x10\x02*\xc2\x01\n" +
+	"\tStockSort\x12\x1a\n" +
+	"\x16STOCK_SORT_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12STOCK_SORT_SKU_ASC\x10\x01\x12\x17\n" +
+	"\x13STOCK_SORT_SKU_DESC\x10\x02\x12\x18\n" +
+	"\x14STOCK_SORT_PRICE_ASC\x10\x03\x12\x19\n" +
+	"\x15STOCK_SORT_PRICE_DESC\x10\x04\x12\x18\n" +
+	"\x14STOCK_SORT_COUNT_ASC\x10\x05\x12\x19\n" +
+	"\x15STOCK_SORT_COUNT_DESC\x10\x062\xf0\n" +
+	"\n" +
 	"\fStockService\x12S\n" +
 	"\aAddItem\x12\x15.stock.AddItemRequest\x1a\x14.stock.StockResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/stocks/item/add\x12Y\n" +
 	"\n" +
 	"DeleteItem\x12\x18.stock.DeleteItemRequest\x1a\x14.stock.StockResponse\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/stocks/item/delete\x12L\n" +
-	"\aGetItem\x12\x15.stock.GetItemRequest\x1a\x10.stock.StockItem\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/stocks/item/get\x12l\n" +
-	"\x0eListByLocation\x12\x1c.stock.ListByLocationRequest\x1a\x1d.stock.ListByLocationResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/stocks/list/locationB\x11Z\x0fpkg/api/stockpbb\x06proto3"
+	"\aGetItem\x12\x15.stock.GetItemRequest\x1a\x10.stock.StockItem\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/stocks/item/get\x12W\n" +
+	"\bGetItems\x12\x16.stock.GetItemsRequest\x1a\x17.stock.GetItemsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/stocks/item/batch\x12l\n" +
+	"\x0eListByLocation\x12\x1c.stock.ListByLocationRequest\x1a\x1d.stock.ListByLocationResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/stocks/list/location\x12^\n" +
+	"\vReduceStock\x12\x19.stock.ReduceStockRequest\x1a\x14.stock.StockResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/stocks/item/reduce\x12e\n" +
+	"\rListMovements\x12\x1b.stock.ListMovementsRequest\x1a\x1c.stock.ListMovementsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/stocks/movements\x12f\n" +
+	"\fReserveItems\x12\x1a.stock.ReserveItemsRequest\x1a\x12.stock.Reservation\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/stocks/reservation/reserve\x12m\n" +
+	"\x12ReleaseReservation\x12\x19.stock.ReservationRequest\x1a\x14.stock.StockResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/stocks/reservation/release\x12k\n" +
+	"\x11CommitReservation\x12\x19.stock.ReservationRequest\x1a\x14.stock.StockResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/stocks/reservation/commit\x12O\n" +
+	"\tCreateSKU\x12\x17.stock.CreateSKURequest\x1a\n" +
+	".stock.SKU\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/stocks/sku/create\x12O\n" +
+	"\tUpdateSKU\x12\x17.stock.UpdateSKURequest\x1a\n" +
+	".stock.SKU\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/stocks/sku/update\x12R\n" +
+	"\n" +
+	"ArchiveSKU\x12\x18.stock.ArchiveSKURequest\x1a\n" +
+	".stock.SKU\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/stocks/sku/archive\x12C\n" +
+	"\x06GetSKU\x12\x14.stock.GetSKURequest\x1a\n" +
+	".stock.SKU\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/stocks/sku/get\x12U\n" +
+	"\bListSKUs\x12\x16.stock.ListSKUsRequest\x1a\x17.stock.ListSKUsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/stocks/sku/listB\x11Z\x0fpkg/api/stockpbb\x06proto3"
 
 var (
 	file_stocks_stocks_proto_rawDescOnce sync.Once
@@ -504,31 +2104,89 @@ func file_stocks_stocks_proto_rawDescGZIP() []byte {
 	return file_stocks_stocks_proto_rawDescData
 }
 
-var file_stocks_stocks_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_stocks_stocks_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_stocks_stocks_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_stocks_stocks_proto_goTypes = []any{
-	(*AddItemRequest)(nil),         // 0: stock.AddItemRequest
-	(*DeleteItemRequest)(nil),      // 1: stock.DeleteItemRequest
-	(*GetItemRequest)(nil),         // 2: stock.GetItemRequest
-	(*ListByLocationRequest)(nil),  // 3: stock.ListByLocationRequest
-	(*StockItem)(nil),              // 4: stock.StockItem
-	(*StockResponse)(nil),          // 5: stock.StockResponse
-	(*ListByLocationResponse)(nil), // 6: stock.ListByLocationResponse
+	(ItemStatus)(0),                // 0: stock.ItemStatus
+	(StockSort)(0),                 // 1: stock.StockSort
+	(*AddItemRequest)(nil),         // 2: stock.AddItemRequest
+	(*DeleteItemRequest)(nil),      // 3: stock.DeleteItemRequest
+	(*GetItemRequest)(nil),         // 4: stock.GetItemRequest
+	(*GetItemsRequest)(nil),        // 5: stock.GetItemsRequest
+	(*GetItemsResult)(nil),         // 6: stock.GetItemsResult
+	(*GetItemsResponse)(nil),       // 7: stock.GetItemsResponse
+	(*ListByLocationRequest)(nil),  // 8: stock.ListByLocationRequest
+	(*StockItem)(nil),              // 9: stock.StockItem
+	(*StockResponse)(nil),          // 10: stock.StockResponse
+	(*ListByLocationResponse)(nil), // 11: stock.ListByLocationResponse
+	(*ReduceStockItem)(nil),        // 12: stock.ReduceStockItem
+	(*ReduceStockRequest)(nil),     // 13: stock.ReduceStockRequest
+	(*ReservationItem)(nil),        // 14: stock.ReservationItem
+	(*ReserveItemsRequest)(nil),    // 15: stock.ReserveItemsRequest
+	(*ReservationRequest)(nil),     // 16: stock.ReservationRequest
+	(*Reservation)(nil),            // 17: stock.Reservation
+	(*ListMovementsRequest)(nil),   // 18: stock.ListMovementsRequest
+	(*StockMovement)(nil),          // 19: stock.StockMovement
+	(*ListMovementsResponse)(nil),  // 20: stock.ListMovementsResponse
+	(*SKU)(nil),                    // 21: stock.SKU
+	(*CreateSKURequest)(nil),       // 22: stock.CreateSKURequest
+	(*UpdateSKURequest)(nil),       // 23: stock.UpdateSKURequest
+	(*ArchiveSKURequest)(nil),      // 24: stock.ArchiveSKURequest
+	(*GetSKURequest)(nil),          // 25: stock.GetSKURequest
+	(*ListSKUsRequest)(nil),        // 26: stock.ListSKUsRequest
+	(*ListSKUsResponse)(nil),       // 27: stock.ListSKUsResponse
+	(*money.Money)(nil),            // 28: money.Money
 }
 var file_stocks_stocks_proto_depIdxs = []int32{
-	4, // 0: stock.ListByLocationResponse.items:type_name -> stock.StockItem
-	0, // 1: stock.StockService.AddItem:input_type -> stock.AddItemRequest
-	1, // 2: stock.StockService.DeleteItem:input_type -> stock.DeleteItemRequest
-	2, // 3: stock.StockService.GetItem:input_type -> stock.GetItemRequest
-	3, // 4: stock.StockService.ListByLocation:input_type -> stock.ListByLocationRequest
-	5, // 5: stock.StockService.AddItem:output_type -> stock.StockResponse
-	5, // 6: stock.StockService.DeleteItem:output_type -> stock.StockResponse
-	4, // 7: stock.StockService.GetItem:output_type -> stock.StockItem
-	6, // 8: stock.StockService.ListByLocation:output_type -> stock.ListByLocationResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	28, // 0: stock.AddItemRequest.unit_price:type_name -> money.Money
+	0,  // 1: stock.GetItemsResult.status:type_name -> stock.ItemStatus
+	9,  // 2: stock.GetItemsResult.item:type_name -> stock.StockItem
+	6,  // 3: stock.GetItemsResponse.results:type_name -> stock.GetItemsResult
+	1,  // 4: stock.ListByLocationRequest.sort:type_name -> stock.StockSort
+	28, // 5: stock.ListByLocationRequest.min_unit_price:type_name -> money.Money
+	28, // 6: stock.ListByLocationRequest.max_unit_price:type_name -> money.Money
+	28, // 7: stock.StockItem.unit_price:type_name -> money.Money
+	9,  // 8: stock.ListByLocationResponse.items:type_name -> stock.StockItem
+	12, // 9: stock.ReduceStockRequest.items:type_name -> stock.ReduceStockItem
+	14, // 10: stock.ReserveItemsRequest.items:type_name -> stock.ReservationItem
+	14, // 11: stock.Reservation.items:type_name -> stock.ReservationItem
+	19, // 12: stock.ListMovementsResponse.movements:type_name -> stock.StockMovement
+	21, // 13: stock.ListSKUsResponse.skus:type_name -> stock.SKU
+	2,  // 14: stock.StockService.AddItem:input_type -> stock.AddItemRequest
+	3,  // 15: stock.StockService.DeleteItem:input_type -> stock.DeleteItemRequest
+	4,  // 16: stock.StockService.GetItem:input_type -> stock.GetItemRequest
+	5,  // 17: stock.StockService.GetItems:input_type -> stock.GetItemsRequest
+	8,  // 18: stock.StockService.ListByLocation:input_type -> stock.ListByLocationRequest
+	13, // 19: stock.StockService.ReduceStock:input_type -> stock.ReduceStockRequest
+	18, // 20: stock.StockService.ListMovements:input_type -> stock.ListMovementsRequest
+	15, // 21: stock.StockService.ReserveItems:input_type -> stock.ReserveItemsRequest
+	16, // 22: stock.StockService.ReleaseReservation:input_type -> stock.ReservationRequest
+	16, // 23: stock.StockService.CommitReservation:input_type -> stock.ReservationRequest
+	22, // 24: stock.StockService.CreateSKU:input_type -> stock.CreateSKURequest
+	23, // 25: stock.StockService.UpdateSKU:input_type -> stock.UpdateSKURequest
+	24, // 26: stock.StockService.ArchiveSKU:input_type -> stock.ArchiveSKURequest
+	25, // 27: stock.StockService.GetSKU:input_type -> stock.GetSKURequest
+	26, // 28: stock.StockService.ListSKUs:input_type -> stock.ListSKUsRequest
+	10, // 29: stock.StockService.AddItem:output_type -> stock.StockResponse
+	10, // 30: stock.StockService.DeleteItem:output_type -> stock.StockResponse
+	9,  // 31: stock.StockService.GetItem:output_type -> stock.StockItem
+	7,  // 32: stock.StockService.GetItems:output_type -> stock.GetItemsResponse
+	11, // 33: stock.StockService.ListByLocation:output_type -> stock.ListByLocationResponse
+	10, // 34: stock.StockService.ReduceStock:output_type -> stock.StockResponse
+	20, // 35: stock.StockService.ListMovements:output_type -> stock.ListMovementsResponse
+	17, // 36: stock.StockService.ReserveItems:output_type -> stock.Reservation
+	10, // 37: stock.StockService.ReleaseReservation:output_type -> stock.StockResponse
+	10, // 38: stock.StockService.CommitReservation:output_type -> stock.StockResponse
+	21, // 39: stock.StockService.CreateSKU:output_type -> stock.SKU
+	21, // 40: stock.StockService.UpdateSKU:output_type -> stock.SKU
+	21, // 41: stock.StockService.ArchiveSKU:output_type -> stock.SKU
+	21, // 42: stock.StockService.GetSKU:output_type -> stock.SKU
+	27, // 43: stock.StockService.ListSKUs:output_type -> stock.ListSKUsResponse
+	29, // [29:44] is the sub-list for method output_type
+	14, // [14:29] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_stocks_stocks_proto_init() }
@@ -541,13 +2199,14 @@ func file_stocks_stocks_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stocks_stocks_proto_rawDesc), len(file_stocks_stocks_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_stocks_stocks_proto_goTypes,
 		DependencyIndexes: file_stocks_stocks_proto_depIdxs,
+		EnumInfos:         file_stocks_stocks_proto_enumTypes,
 		MessageInfos:      file_stocks_stocks_proto_msgTypes,
 	}.Build()
 	File_stocks_stocks_proto = out.File
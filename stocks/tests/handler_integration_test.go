@@ -74,6 +74,7 @@ func TestIntegration_AddItem(t *testing.T) {
 
 			req := httptest.NewRequest(tt.method, "/stocks/item/add", bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			authorize(t, req)
 			rec := httptest.NewRecorder()
 
 			server.ServeHTTP(rec, req)
@@ -143,6 +144,7 @@ func TestIntegration_DeleteItem(t *testing.T) {
 				}
 				addReq := httptest.NewRequest(http.MethodPost, "/stocks/item/add", bytes.NewReader(addBody))
 				addReq.Header.Set("Content-Type", "application/json")
+				authorize(t, addReq)
 				addRec := httptest.NewRecorder()
 				server.ServeHTTP(addRec, addReq)
 
@@ -167,6 +169,7 @@ func TestIntegration_DeleteItem(t *testing.T) {
 			}
 			req := httptest.NewRequest(tt.method, "/stocks/item/delete", bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			authorize(t, req)
 			rec := httptest.NewRecorder()
 
 			server.ServeHTTP(rec, req)
@@ -243,6 +246,7 @@ func TestIntegration_GetItem(t *testing.T) {
 				}
 				addReq := httptest.NewRequest(http.MethodPost, "/stocks/item/add", bytes.NewReader(addBody))
 				addReq.Header.Set("Content-Type", "application/json")
+				authorize(t, addReq)
 				addRec := httptest.NewRecorder()
 				server.ServeHTTP(addRec, addReq)
 
@@ -268,6 +272,7 @@ func TestIntegration_GetItem(t *testing.T) {
 
 			req := httptest.NewRequest(tt.method, "/stocks/item/get", bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			authorize(t, req)
 			rec := httptest.NewRecorder()
 
 			server.ServeHTTP(rec, req)
@@ -346,6 +351,7 @@ func TestIntegration_ListByLocation(t *testing.T) {
 					}
 					req := httptest.NewRequest(http.MethodPost, "/stocks/item/add", bytes.NewReader(body))
 					req.Header.Set("Content-Type", "application/json")
+					authorize(t, req)
 					rec := httptest.NewRecorder()
 					server.ServeHTTP(rec, req)
 
@@ -372,6 +378,7 @@ func TestIntegration_ListByLocation(t *testing.T) {
 
 			req := httptest.NewRequest(tt.method, "/stocks/list/location", bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			authorize(t, req)
 			rec := httptest.NewRecorder()
 
 			server.ServeHTTP(rec, req)
@@ -382,3 +389,77 @@ func TestIntegration_ListByLocation(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegration_ListByLocation_Pagination(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	server := setupServer(t, db, ctrl)
+
+	_, err := db.Exec(`
+		INSERT INTO sku_info (sku, name, type) VALUES
+		(3001, 'socks', 'apparel'),
+		(3002, 'scarf', 'apparel'),
+		(3003, 'mug', 'accessory')
+		ON CONFLICT (sku) DO NOTHING;
+
+		INSERT INTO stock_items (user_id, sku, price, count, location) VALUES
+		(1, 3001, 5.00, 10, 'page-loc'),
+		(1, 3002, 25.00, 4, 'page-loc'),
+		(1, 3003, 12.50, 8, 'page-loc')
+		ON CONFLICT (sku, location) DO NOTHING;
+	`)
+	if err != nil {
+		t.Fatalf("failed to insert stock for pagination test: %v", err)
+	}
+
+	var (
+		skus  []string
+		token string
+	)
+
+	for page := 0; page < 3; page++ {
+		target := "/stocks/list/location?location=page-loc&type=apparel&page_size=1&sort=STOCK_SORT_PRICE_DESC"
+		if token != "" {
+			target += "&page_token=" + token
+		}
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		authorize(t, req)
+		rec := httptest.NewRecorder()
+
+		server.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d, body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var resp struct {
+			Items []struct {
+				Sku string `json:"sku"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		for _, item := range resp.Items {
+			skus = append(skus, item.Sku)
+		}
+
+		token = resp.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+
+	if len(skus) != 2 || skus[0] != "3002" || skus[1] != "3001" {
+		t.Fatalf("expected apparel SKUs by price descending, got %v", skus)
+	}
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"stocks/internal/models"
+	"stocks/internal/money"
+	"stocks/internal/repository"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sql/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// A seller sees the sales of their stock rows, which buyers made.
+func TestIntegration_ListMovements_SellerSeesSales(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := repository.NewPostgresStockRepo(db, trmsqlx.DefaultCtxGetter)
+
+	const (
+		sellerID = int64(2)
+		buyerID  = int64(3)
+	)
+
+	err := repo.InsertStockItem(ctx, models.StockItem{
+		UserID:   sellerID,
+		SKU:      1001,
+		Price:    money.New("RUB", 2050),
+		Count:    10,
+		Location: "loc1",
+	})
+	assert.NoError(t, err)
+
+	_, movements, err := repo.DecreaseCount(ctx, 1001, 2)
+	assert.NoError(t, err)
+
+	for _, movement := range movements {
+		movement.Reason = models.MovementReasonSale
+		movement.UserID = buyerID
+		assert.NoError(t, repo.InsertMovement(ctx, movement))
+	}
+
+	// The ledger is append-only, so earlier runs may have left rows behind:
+	// only the newest one is this run's sale.
+	got, err := repo.ListMovements(ctx, models.MovementFilter{SKU: 1001, OwnerID: sellerID, Limit: 1})
+	assert.NoError(t, err)
+
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, models.MovementReasonSale, got[0].Reason)
+		assert.Equal(t, int32(-2), got[0].Delta)
+		assert.Equal(t, buyerID, got[0].UserID)
+		assert.Equal(t, sellerID, got[0].OwnerID)
+	}
+
+	got, err = repo.ListMovements(ctx, models.MovementFilter{SKU: 1001, OwnerID: buyerID, Limit: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, got, "the buyer owns no stock")
+}
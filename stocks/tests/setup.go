@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"context"
 	"stocks/internal/config"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/golang-jwt/jwt/v5"
 	_ "github.com/lib/pq"
 )
 
@@ -74,3 +76,27 @@ func setupServer(t *testing.T, db *sqlx.DB, ctrl *gomock.Controller) http.Handle
 
 	return mux
 }
+
+// authorize signs a short-lived admin token for user 1 with the HMAC secret
+// from the test environment.
+func authorize(t *testing.T, req *http.Request) {
+	cfg := mustLoadConfig(t)
+
+	claims := struct {
+		jwt.RegisteredClaims
+		Roles []string `json:"roles"`
+	}{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: []string{"admin"},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.AuthHMACSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+}